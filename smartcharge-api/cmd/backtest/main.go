@@ -0,0 +1,129 @@
+// Command backtest replays forecasting models over stations' recent history and prints how far off they were.
+//
+// For each of the last -weeks weeks, a model is trained on the history before the week and scored on the week
+// itself, exactly as the scheduled retraining would have done. Use it to compare models before switching
+// a station to another one.
+//
+//	go run ./cmd/backtest -weeks 4 -model all
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"smartcharge-api/db/generated"
+	"smartcharge-api/internal/config"
+	"smartcharge-api/internal/forecast"
+	"smartcharge-api/internal/pricing"
+)
+
+func main() {
+	stationID := flag.Int("station", 0, "station to backtest (default all)")
+	modelName := flag.String("model", "", `model to backtest: a model name, "all", or empty for each station's own model`)
+	weeks := flag.Int("weeks", 4, "number of past weeks to score")
+	byHour := flag.Bool("hours", false, "also print the errors per hour of day")
+	noWeather := flag.Bool("no-weather", false, "ignore the weather, to see how much it improves the forecasts")
+	flag.Parse()
+
+	if *weeks < 1 {
+		log.Fatal("-weeks must be at least 1")
+	}
+	if *modelName != "" && *modelName != "all" {
+		if _, ok := forecast.Models[*modelName]; !ok {
+			log.Fatalf("Unknown model %q; available: %v", *modelName, forecast.ModelNames())
+		}
+	}
+
+	cfg := config.Load()
+	if err := pricing.SetSlotLocation(cfg.Timezone); err != nil {
+		log.Fatalf("Invalid TIMEZONE: %v", err)
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, cfg.DatabaseURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer pool.Close()
+	queries := generated.New(pool)
+
+	stations, err := queries.ListStations(ctx)
+	if err != nil {
+		log.Fatalf("Failed to list stations: %v", err)
+	}
+
+	now := time.Now().Truncate(time.Hour)
+	cutoffs := make([]time.Time, *weeks)
+	for i := range cutoffs {
+		cutoffs[i] = now.Add(-time.Duration(*weeks-i) * forecast.EvaluationWindow)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "STATION\tMODEL\tHOUR\tSAMPLES\tMAE\tMAPE %\tBIAS\t")
+	for _, s := range stations {
+		if *stationID != 0 && s.ID != int32(*stationID) {
+			continue
+		}
+
+		since := cutoffs[0].Add(-forecast.EventHistoryWindow)
+		history, err := forecast.LoadHistory(ctx, queries, s.ID, since, now)
+		if err != nil {
+			log.Fatalf("Failed to load history for station %d: %v", s.ID, err)
+		}
+		events, err := forecast.LoadEvents(ctx, queries, s.ID, since, now)
+		if err != nil {
+			log.Fatalf("Failed to load calendar for station %d: %v", s.ID, err)
+		}
+		var weather forecast.WeatherSeries
+		if !*noWeather {
+			if weather, err = forecast.LoadWeather(ctx, queries, s.ID, cutoffs[0].Add(-forecast.HistoryWindow), now); err != nil {
+				log.Fatalf("Failed to load weather for station %d: %v", s.ID, err)
+			}
+		}
+
+		for _, model := range modelsFor(*modelName, s.ForecastModel) {
+			e, ok := forecast.Backtest(history, events, weather, model, cutoffs...)
+			if !ok {
+				continue
+			}
+			printAccuracy(w, s.ID, model.Name(), "all", e.Overall)
+			if *byHour {
+				for hour, a := range e.ByHour {
+					printAccuracy(w, s.ID, model.Name(), fmt.Sprintf("%02d", hour), a)
+				}
+			}
+		}
+	}
+	w.Flush()
+}
+
+// modelsFor returns the models to backtest a station with.
+func modelsFor(name, stationModel string) []forecast.Model {
+	switch name {
+	case "":
+		return []forecast.Model{forecast.Lookup(stationModel)}
+	case "all":
+		models := make([]forecast.Model, 0, len(forecast.Models))
+		for _, n := range forecast.ModelNames() {
+			models = append(models, forecast.Models[n])
+		}
+		return models
+	default:
+		return []forecast.Model{forecast.Models[name]}
+	}
+}
+
+func printAccuracy(w *tabwriter.Writer, stationID int32, model, hour string, a forecast.Accuracy) {
+	mape := "-"
+	if a.MAPESamples > 0 {
+		mape = fmt.Sprintf("%.1f", a.MAPE)
+	}
+	fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%.2f\t%s\t%+.2f\t\n", stationID, model, hour, a.Samples, a.MAE, mape, a.Bias)
+}
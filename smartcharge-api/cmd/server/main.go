@@ -16,20 +16,33 @@ import (
 	"smartcharge-api/db/generated"
 	"smartcharge-api/internal/auth"
 	"smartcharge-api/internal/badge"
+	"smartcharge-api/internal/calendar"
 	"smartcharge-api/internal/campaign"
 	"smartcharge-api/internal/chat"
 	"smartcharge-api/internal/config"
 	"smartcharge-api/internal/demouser"
+	"smartcharge-api/internal/forecast"
+	"smartcharge-api/internal/forecastversion"
 	"smartcharge-api/internal/middleware"
+	"smartcharge-api/internal/notification"
 	"smartcharge-api/internal/operator"
+	"smartcharge-api/internal/pricing"
 	"smartcharge-api/internal/reservation"
+	"smartcharge-api/internal/reward"
+	"smartcharge-api/internal/scheduler"
+	"smartcharge-api/internal/statement"
 	"smartcharge-api/internal/station"
 	"smartcharge-api/internal/user"
+	"smartcharge-api/internal/wallet"
+	"smartcharge-api/internal/weather"
 )
 
 func main() {
 	// Load configuration
 	cfg := config.Load()
+	if err := pricing.SetSlotLocation(cfg.Timezone); err != nil {
+		log.Fatalf("Invalid TIMEZONE: %v", err)
+	}
 
 	// Set Gin mode
 	gin.SetMode(cfg.GinMode)
@@ -56,16 +69,38 @@ func main() {
 
 	// Auth middleware
 	authMiddleware := middleware.AuthRequired(jwtSecret)
+	optionalAuth := middleware.OptionalAuth(jwtSecret)
 
 	// ── Services ──────────────────────────────────────────
 	authService := auth.NewService(queries, jwtSecret)
 	stationService := station.NewService(queries)
-	reservationService := reservation.NewService(queries, pool)
+	badgeEngine := badge.NewEngine(queries)
+	badgeEngine.OnAward(func(ctx context.Context, ev badge.AwardEvent) {
+		err := notification.Send(ctx, queries, notification.Message{
+			UserID:    ev.UserID,
+			Type:      notification.TypeBadgeEarned,
+			Title:     fmt.Sprintf("Yeni rozet: %s %s", ev.Badge.Icon, ev.Badge.Name),
+			Body:      ev.Badge.Description,
+			DedupeKey: fmt.Sprintf("badge-earned:%d:%d", ev.UserID, ev.Badge.ID),
+		})
+		if err != nil {
+			log.Printf("Failed to send badge notification: %v", err)
+		}
+	})
+	reservationService := reservation.NewService(queries, pool, badgeEngine)
 	userService := user.NewService(queries)
-	badgeService := badge.NewService(queries)
-	campaignService := campaign.NewService(queries)
-	operatorService := operator.NewService(queries)
+	badgeService := badge.NewService(queries, pool, badgeEngine, badge.NewIconStore(cfg.AssetsDir))
+	campaignService := campaign.NewService(queries, pool)
+	operatorService := operator.NewService(queries, pool)
 	chatService := chat.NewService(queries)
+	statementService := statement.NewService(queries)
+	coinExpiry := wallet.ExpiryPolicy{Months: cfg.CoinExpiryMonths, WarningDays: cfg.CoinExpiryWarningDays}
+	walletService := wallet.NewService(queries, coinExpiry)
+	rewardService := reward.NewService(queries, pool)
+	notificationService := notification.NewService(queries)
+	calendarService := calendar.NewService(queries, pool)
+	weatherService := weather.NewService(queries, pool)
+	forecastVersionService := forecastversion.NewService(queries, pool)
 
 	// ── Handlers ──────────────────────────────────────────
 	authHandler := auth.NewHandler(authService)
@@ -77,6 +112,13 @@ func main() {
 	operatorHandler := operator.NewHandler(operatorService)
 	chatHandler := chat.NewHandler(chatService)
 	demoUserHandler := demouser.NewHandler(queries)
+	statementHandler := statement.NewHandler(statementService)
+	walletHandler := wallet.NewHandler(walletService)
+	rewardHandler := reward.NewHandler(rewardService)
+	notificationHandler := notification.NewHandler(notificationService)
+	calendarHandler := calendar.NewHandler(calendarService)
+	weatherHandler := weather.NewHandler(weatherService)
+	forecastVersionHandler := forecastversion.NewHandler(forecastVersionService)
 
 	// ── Router ────────────────────────────────────────────
 	router := gin.Default()
@@ -84,19 +126,42 @@ func main() {
 	// Global middleware
 	router.Use(middleware.CORS(cfg.FrontendURL))
 
+	// Uploaded assets such as badge icons
+	router.Static("/assets", cfg.AssetsDir)
+
 	// API v1 group
 	v1 := router.Group("/v1")
 
 	// Register all routes
 	authHandler.RegisterRoutes(v1)
-	stationHandler.RegisterRoutes(v1, authMiddleware)
+	stationHandler.RegisterRoutes(v1, authMiddleware, optionalAuth)
 	reservationHandler.RegisterRoutes(v1, authMiddleware)
 	userHandler.RegisterRoutes(v1, authMiddleware)
-	badgeHandler.RegisterRoutes(v1)
+	badgeHandler.RegisterRoutes(v1, authMiddleware)
 	campaignHandler.RegisterRoutes(v1, authMiddleware)
 	operatorHandler.RegisterRoutes(v1, authMiddleware)
 	chatHandler.RegisterRoutes(v1)
 	demoUserHandler.RegisterRoutes(v1)
+	statementHandler.RegisterRoutes(v1, authMiddleware)
+	walletHandler.RegisterRoutes(v1, authMiddleware)
+	rewardHandler.RegisterRoutes(v1, authMiddleware)
+	notificationHandler.RegisterRoutes(v1, authMiddleware)
+	calendarHandler.RegisterRoutes(v1, authMiddleware)
+	weatherHandler.RegisterRoutes(v1, authMiddleware)
+	forecastVersionHandler.RegisterRoutes(v1, authMiddleware)
+
+	// ── Background jobs ───────────────────────────────────
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	jobs := scheduler.New()
+	jobs.Every(cfg.CoinExpiryInterval, wallet.NewExpiryJob(queries, pool, coinExpiry))
+	jobs.Every(cfg.BadgeBackfillInterval, badge.NewBackfillJob(badgeEngine))
+	jobs.Every(cfg.CampaignScheduleInterval, campaign.NewScheduleJob(queries))
+	jobs.Every(cfg.ForecastRetrainInterval, forecast.NewRetrainJob(queries, pool))
+	jobs.Every(cfg.ForecastAccuracyInterval, forecast.NewAccuracyJob(queries))
+	if cfg.WeatherURL != "" {
+		jobs.Every(cfg.WeatherFetchInterval, weather.NewFetchJob(queries, cfg.WeatherURL))
+	}
+	jobs.Start(jobsCtx)
 
 	// Health check
 	router.GET("/health", func(c *gin.Context) {
@@ -136,5 +201,9 @@ func main() {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
 
+	// Stop background jobs before the pool is closed
+	stopJobs()
+	jobs.Wait()
+
 	log.Println("Server exited gracefully")
 }
@@ -0,0 +1,83 @@
+// Command weatherstub is a local stand-in for an Open-Meteo style weather service, for development without
+// network access: it serves plausible hourly temperature and precipitation for the past week and the
+// forecast horizon, the same every time for the same hour.
+//
+//	go run ./cmd/weatherstub -addr :8090 -offset -8   # a cold snap
+//	WEATHER_URL=http://localhost:8090/v1/forecast go run ./cmd/server
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"hash/fnv"
+	"log"
+	"math"
+	"net/http"
+	"time"
+
+	"smartcharge-api/internal/forecast"
+)
+
+func main() {
+	addr := flag.String("addr", ":8090", "address to listen on")
+	offset := flag.Float64("offset", 0, "degrees added to every temperature, to simulate a cold snap or heat wave")
+	flag.Parse()
+
+	http.HandleFunc("/v1/forecast", func(w http.ResponseWriter, r *http.Request) {
+		now := time.Now().UTC().Truncate(time.Hour)
+		from, to := now.Add(-7*24*time.Hour), now.Add(forecast.Horizon)
+
+		var times []string
+		var temperatures, precipitation []float64
+		for t := from; t.Before(to); t = t.Add(time.Hour) {
+			temp, rain := weatherAt(t)
+			times = append(times, t.Format("2006-01-02T15:04"))
+			temperatures = append(temperatures, math.Round((temp+*offset)*10)/10)
+			precipitation = append(precipitation, rain)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]any{
+			"utc_offset_seconds": 0,
+			"hourly": map[string]any{
+				"time":           times,
+				"temperature_2m": temperatures,
+				"precipitation":  precipitation,
+			},
+		}); err != nil {
+			log.Printf("Failed to write response: %v", err)
+		}
+	})
+
+	log.Printf("Weather stand-in listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
+
+// weatherAt returns a made-up temperature (°C) and precipitation (mm) for an hour, in the rough shape
+// of the Aegean climate: mild wet winters, hot dry summers, warmest mid-afternoon.
+func weatherAt(t time.Time) (temperature, precipitation float64) {
+	season := math.Cos(2 * math.Pi * float64(t.YearDay()-200) / 365) // 1 in mid-July, -1 in mid-January
+	daily := math.Cos(2 * math.Pi * float64(t.Hour()-13) / 24)       // 1 at 13:00 UTC
+
+	// Weather comes in spells of a few days: one noise value per three-day block, one per hour on top
+	spell := noise(t.Unix()/(3*24*3600), 1)
+	temperature = 17 + 10*season + 5*daily + 4*spell + noise(t.Unix(), 2)
+
+	// Wetter in winter and during cold spells
+	if noise(t.Unix(), 3) > 0.6+0.3*season-0.2*spell {
+		precipitation = math.Round((1+noise(t.Unix(), 4))*20) / 10
+	}
+	return temperature, precipitation
+}
+
+// noise returns a pseudo-random value in [-1, 1) that depends only on its arguments.
+func noise(key int64, stream uint64) float64 {
+	h := fnv.New64a()
+	var b [16]byte
+	for i := range 8 {
+		b[i] = byte(uint64(key) >> (8 * i))
+		b[8+i] = byte(stream >> (8 * i))
+	}
+	h.Write(b[:])
+	return float64(h.Sum64()>>11)/float64(1<<52) - 1
+}
@@ -7,12 +7,33 @@ package generated
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const awardUserBadge = `-- name: AwardUserBadge :execrows
+INSERT INTO user_badges (user_id, badge_id, earned_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT DO NOTHING
+`
+
+type AwardUserBadgeParams struct {
+	UserID  int32 `json:"user_id"`
+	BadgeID int32 `json:"badge_id"`
+}
+
+func (q *Queries) AwardUserBadge(ctx context.Context, arg AwardUserBadgeParams) (int64, error) {
+	result, err := q.db.Exec(ctx, awardUserBadge, arg.UserID, arg.BadgeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const createBadge = `-- name: CreateBadge :one
 INSERT INTO badges (name, description, icon)
 VALUES ($1, $2, $3)
-RETURNING id, name, description, icon
+RETURNING id, name, description, icon, icon_url, retired_at
 `
 
 type CreateBadgeParams struct {
@@ -29,12 +50,40 @@ func (q *Queries) CreateBadge(ctx context.Context, arg CreateBadgeParams) (Badge
 		&i.Name,
 		&i.Description,
 		&i.Icon,
+		&i.IconUrl,
+		&i.RetiredAt,
 	)
 	return i, err
 }
 
+const deleteBadgeRule = `-- name: DeleteBadgeRule :exec
+DELETE FROM badge_rules WHERE badge_id = $1
+`
+
+func (q *Queries) DeleteBadgeRule(ctx context.Context, badgeID int32) error {
+	_, err := q.db.Exec(ctx, deleteBadgeRule, badgeID)
+	return err
+}
+
+const deleteBadgeTranslation = `-- name: DeleteBadgeTranslation :execrows
+DELETE FROM badge_translations WHERE badge_id = $1 AND locale = $2
+`
+
+type DeleteBadgeTranslationParams struct {
+	BadgeID int32  `json:"badge_id"`
+	Locale  string `json:"locale"`
+}
+
+func (q *Queries) DeleteBadgeTranslation(ctx context.Context, arg DeleteBadgeTranslationParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteBadgeTranslation, arg.BadgeID, arg.Locale)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getBadgeByID = `-- name: GetBadgeByID :one
-SELECT id, name, description, icon FROM badges WHERE id = $1
+SELECT id, name, description, icon, icon_url, retired_at FROM badges WHERE id = $1
 `
 
 func (q *Queries) GetBadgeByID(ctx context.Context, id int32) (Badge, error) {
@@ -45,12 +94,258 @@ func (q *Queries) GetBadgeByID(ctx context.Context, id int32) (Badge, error) {
 		&i.Name,
 		&i.Description,
 		&i.Icon,
+		&i.IconUrl,
+		&i.RetiredAt,
+	)
+	return i, err
+}
+
+const getBadgeRule = `-- name: GetBadgeRule :one
+SELECT badge_id, metric, threshold FROM badge_rules WHERE badge_id = $1
+`
+
+func (q *Queries) GetBadgeRule(ctx context.Context, badgeID int32) (BadgeRule, error) {
+	row := q.db.QueryRow(ctx, getBadgeRule, badgeID)
+	var i BadgeRule
+	err := row.Scan(&i.BadgeID, &i.Metric, &i.Threshold)
+	return i, err
+}
+
+const getUserBadgeMetrics = `-- name: GetUserBadgeMetrics :one
+SELECT
+    COUNT(r.id)::int AS completed_sessions,
+    COUNT(r.id) FILTER (WHERE r.is_green)::int AS green_sessions,
+    COUNT(r.id) FILTER (
+        WHERE split_part(r.hour, ':', 1)::int >= 23 OR split_part(r.hour, ':', 1)::int <= 6
+    )::int AS night_sessions,
+    COUNT(r.id) FILTER (
+        WHERE split_part(r.hour, ':', 1)::int BETWEEN 6 AND 8
+    )::int AS morning_sessions,
+    COUNT(r.id) FILTER (WHERE EXTRACT(ISODOW FROM r.date) >= 6)::int AS weekend_sessions,
+    COUNT(r.id) FILTER (WHERE s.density_profile = 'outskirt')::int AS outskirt_sessions,
+    COUNT(DISTINCT r.station_id)::int AS distinct_stations,
+    (SELECT u.co2_saved FROM users u WHERE u.id = $1)::double precision AS co2_saved
+FROM reservations r
+JOIN stations s ON s.id = r.station_id
+WHERE r.user_id = $1
+  AND r.status = 'COMPLETED'
+`
+
+type GetUserBadgeMetricsRow struct {
+	CompletedSessions int32   `json:"completed_sessions"`
+	GreenSessions     int32   `json:"green_sessions"`
+	NightSessions     int32   `json:"night_sessions"`
+	MorningSessions   int32   `json:"morning_sessions"`
+	WeekendSessions   int32   `json:"weekend_sessions"`
+	OutskirtSessions  int32   `json:"outskirt_sessions"`
+	DistinctStations  int32   `json:"distinct_stations"`
+	Co2Saved          float64 `json:"co2_saved"`
+}
+
+func (q *Queries) GetUserBadgeMetrics(ctx context.Context, userID int32) (GetUserBadgeMetricsRow, error) {
+	row := q.db.QueryRow(ctx, getUserBadgeMetrics, userID)
+	var i GetUserBadgeMetricsRow
+	err := row.Scan(
+		&i.CompletedSessions,
+		&i.GreenSessions,
+		&i.NightSessions,
+		&i.MorningSessions,
+		&i.WeekendSessions,
+		&i.OutskirtSessions,
+		&i.DistinctStations,
+		&i.Co2Saved,
 	)
 	return i, err
 }
 
+const listAllBadgeRules = `-- name: ListAllBadgeRules :many
+SELECT badge_id, metric, threshold FROM badge_rules ORDER BY badge_id
+`
+
+func (q *Queries) ListAllBadgeRules(ctx context.Context) ([]BadgeRule, error) {
+	rows, err := q.db.Query(ctx, listAllBadgeRules)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []BadgeRule{}
+	for rows.Next() {
+		var i BadgeRule
+		if err := rows.Scan(&i.BadgeID, &i.Metric, &i.Threshold); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAllBadges = `-- name: ListAllBadges :many
+SELECT id, name, description, icon, icon_url, retired_at FROM badges ORDER BY id ASC
+`
+
+func (q *Queries) ListAllBadges(ctx context.Context) ([]Badge, error) {
+	rows, err := q.db.Query(ctx, listAllBadges)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Badge{}
+	for rows.Next() {
+		var i Badge
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Icon,
+			&i.IconUrl,
+			&i.RetiredAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBadgeRules = `-- name: ListBadgeRules :many
+SELECT b.id, b.name, b.description, b.icon, bc.metric, bc.threshold
+FROM badge_rules bc
+JOIN badges b ON b.id = bc.badge_id
+WHERE b.retired_at IS NULL
+ORDER BY b.id
+`
+
+type ListBadgeRulesRow struct {
+	ID          int32   `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Icon        string  `json:"icon"`
+	Metric      string  `json:"metric"`
+	Threshold   float64 `json:"threshold"`
+}
+
+func (q *Queries) ListBadgeRules(ctx context.Context) ([]ListBadgeRulesRow, error) {
+	rows, err := q.db.Query(ctx, listBadgeRules)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListBadgeRulesRow{}
+	for rows.Next() {
+		var i ListBadgeRulesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Icon,
+			&i.Metric,
+			&i.Threshold,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBadgeTranslations = `-- name: ListBadgeTranslations :many
+SELECT badge_id, locale, name, description FROM badge_translations ORDER BY badge_id, locale
+`
+
+func (q *Queries) ListBadgeTranslations(ctx context.Context) ([]BadgeTranslation, error) {
+	rows, err := q.db.Query(ctx, listBadgeTranslations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []BadgeTranslation{}
+	for rows.Next() {
+		var i BadgeTranslation
+		if err := rows.Scan(
+			&i.BadgeID,
+			&i.Locale,
+			&i.Name,
+			&i.Description,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBadgeTranslationsByBadge = `-- name: ListBadgeTranslationsByBadge :many
+SELECT badge_id, locale, name, description FROM badge_translations WHERE badge_id = $1 ORDER BY locale
+`
+
+func (q *Queries) ListBadgeTranslationsByBadge(ctx context.Context, badgeID int32) ([]BadgeTranslation, error) {
+	rows, err := q.db.Query(ctx, listBadgeTranslationsByBadge, badgeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []BadgeTranslation{}
+	for rows.Next() {
+		var i BadgeTranslation
+		if err := rows.Scan(
+			&i.BadgeID,
+			&i.Locale,
+			&i.Name,
+			&i.Description,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBadgeTranslationsByLocale = `-- name: ListBadgeTranslationsByLocale :many
+SELECT badge_id, locale, name, description FROM badge_translations WHERE locale = $1
+`
+
+func (q *Queries) ListBadgeTranslationsByLocale(ctx context.Context, locale string) ([]BadgeTranslation, error) {
+	rows, err := q.db.Query(ctx, listBadgeTranslationsByLocale, locale)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []BadgeTranslation{}
+	for rows.Next() {
+		var i BadgeTranslation
+		if err := rows.Scan(
+			&i.BadgeID,
+			&i.Locale,
+			&i.Name,
+			&i.Description,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listBadges = `-- name: ListBadges :many
-SELECT id, name, description, icon FROM badges ORDER BY name ASC
+SELECT id, name, description, icon, icon_url, retired_at FROM badges WHERE retired_at IS NULL ORDER BY name ASC
 `
 
 func (q *Queries) ListBadges(ctx context.Context) ([]Badge, error) {
@@ -67,6 +362,87 @@ func (q *Queries) ListBadges(ctx context.Context) ([]Badge, error) {
 			&i.Name,
 			&i.Description,
 			&i.Icon,
+			&i.IconUrl,
+			&i.RetiredAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserBadgeIDs = `-- name: ListUserBadgeIDs :many
+SELECT badge_id FROM user_badges WHERE user_id = $1
+`
+
+func (q *Queries) ListUserBadgeIDs(ctx context.Context, userID int32) ([]int32, error) {
+	rows, err := q.db.Query(ctx, listUserBadgeIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int32{}
+	for rows.Next() {
+		var badge_id int32
+		if err := rows.Scan(&badge_id); err != nil {
+			return nil, err
+		}
+		items = append(items, badge_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserBadgeProgress = `-- name: ListUserBadgeProgress :many
+SELECT b.id AS badge_id, b.name, b.description, b.icon, b.icon_url, br.metric, br.threshold,
+       bp.current_value, bp.updated_at AS progress_updated_at, ub.earned_at
+FROM badge_rules br
+JOIN badges b ON b.id = br.badge_id
+LEFT JOIN badge_progress bp ON bp.badge_id = br.badge_id AND bp.user_id = $1
+LEFT JOIN user_badges ub ON ub.badge_id = br.badge_id AND ub.user_id = $1
+WHERE b.retired_at IS NULL
+ORDER BY b.name ASC
+`
+
+type ListUserBadgeProgressRow struct {
+	BadgeID           int32              `json:"badge_id"`
+	Name              string             `json:"name"`
+	Description       string             `json:"description"`
+	Icon              string             `json:"icon"`
+	IconUrl           pgtype.Text        `json:"icon_url"`
+	Metric            string             `json:"metric"`
+	Threshold         float64            `json:"threshold"`
+	CurrentValue      pgtype.Float8      `json:"current_value"`
+	ProgressUpdatedAt pgtype.Timestamptz `json:"progress_updated_at"`
+	EarnedAt          pgtype.Timestamptz `json:"earned_at"`
+}
+
+func (q *Queries) ListUserBadgeProgress(ctx context.Context, userID int32) ([]ListUserBadgeProgressRow, error) {
+	rows, err := q.db.Query(ctx, listUserBadgeProgress, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUserBadgeProgressRow{}
+	for rows.Next() {
+		var i ListUserBadgeProgressRow
+		if err := rows.Scan(
+			&i.BadgeID,
+			&i.Name,
+			&i.Description,
+			&i.Icon,
+			&i.IconUrl,
+			&i.Metric,
+			&i.Threshold,
+			&i.CurrentValue,
+			&i.ProgressUpdatedAt,
+			&i.EarnedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -77,3 +453,183 @@ func (q *Queries) ListBadges(ctx context.Context) ([]Badge, error) {
 	}
 	return items, nil
 }
+
+const listUserIDsWithCompletedReservations = `-- name: ListUserIDsWithCompletedReservations :many
+SELECT DISTINCT user_id FROM reservations WHERE status = 'COMPLETED' ORDER BY user_id
+`
+
+func (q *Queries) ListUserIDsWithCompletedReservations(ctx context.Context) ([]int32, error) {
+	rows, err := q.db.Query(ctx, listUserIDsWithCompletedReservations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int32{}
+	for rows.Next() {
+		var user_id int32
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retireBadge = `-- name: RetireBadge :one
+UPDATE badges SET retired_at = COALESCE(retired_at, NOW())
+WHERE id = $1
+RETURNING id, name, description, icon, icon_url, retired_at
+`
+
+func (q *Queries) RetireBadge(ctx context.Context, id int32) (Badge, error) {
+	row := q.db.QueryRow(ctx, retireBadge, id)
+	var i Badge
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Icon,
+		&i.IconUrl,
+		&i.RetiredAt,
+	)
+	return i, err
+}
+
+const setBadgeIconURL = `-- name: SetBadgeIconURL :one
+UPDATE badges SET icon_url = $2 WHERE id = $1
+RETURNING id, name, description, icon, icon_url, retired_at
+`
+
+type SetBadgeIconURLParams struct {
+	ID      int32       `json:"id"`
+	IconUrl pgtype.Text `json:"icon_url"`
+}
+
+func (q *Queries) SetBadgeIconURL(ctx context.Context, arg SetBadgeIconURLParams) (Badge, error) {
+	row := q.db.QueryRow(ctx, setBadgeIconURL, arg.ID, arg.IconUrl)
+	var i Badge
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Icon,
+		&i.IconUrl,
+		&i.RetiredAt,
+	)
+	return i, err
+}
+
+const updateBadge = `-- name: UpdateBadge :one
+UPDATE badges
+SET name = $2, description = $3, icon = $4
+WHERE id = $1
+RETURNING id, name, description, icon, icon_url, retired_at
+`
+
+type UpdateBadgeParams struct {
+	ID          int32  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Icon        string `json:"icon"`
+}
+
+func (q *Queries) UpdateBadge(ctx context.Context, arg UpdateBadgeParams) (Badge, error) {
+	row := q.db.QueryRow(ctx, updateBadge,
+		arg.ID,
+		arg.Name,
+		arg.Description,
+		arg.Icon,
+	)
+	var i Badge
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Icon,
+		&i.IconUrl,
+		&i.RetiredAt,
+	)
+	return i, err
+}
+
+const upsertBadgeProgress = `-- name: UpsertBadgeProgress :exec
+INSERT INTO badge_progress (user_id, badge_id, current_value, target, updated_at)
+VALUES ($1, $2, $3, $4, NOW())
+ON CONFLICT (user_id, badge_id) DO UPDATE
+SET current_value = EXCLUDED.current_value,
+    target = EXCLUDED.target,
+    updated_at = NOW()
+`
+
+type UpsertBadgeProgressParams struct {
+	UserID       int32   `json:"user_id"`
+	BadgeID      int32   `json:"badge_id"`
+	CurrentValue float64 `json:"current_value"`
+	Target       float64 `json:"target"`
+}
+
+func (q *Queries) UpsertBadgeProgress(ctx context.Context, arg UpsertBadgeProgressParams) error {
+	_, err := q.db.Exec(ctx, upsertBadgeProgress,
+		arg.UserID,
+		arg.BadgeID,
+		arg.CurrentValue,
+		arg.Target,
+	)
+	return err
+}
+
+const upsertBadgeRule = `-- name: UpsertBadgeRule :one
+INSERT INTO badge_rules (badge_id, metric, threshold)
+VALUES ($1, $2, $3)
+ON CONFLICT (badge_id) DO UPDATE
+SET metric = EXCLUDED.metric, threshold = EXCLUDED.threshold
+RETURNING badge_id, metric, threshold
+`
+
+type UpsertBadgeRuleParams struct {
+	BadgeID   int32   `json:"badge_id"`
+	Metric    string  `json:"metric"`
+	Threshold float64 `json:"threshold"`
+}
+
+func (q *Queries) UpsertBadgeRule(ctx context.Context, arg UpsertBadgeRuleParams) (BadgeRule, error) {
+	row := q.db.QueryRow(ctx, upsertBadgeRule, arg.BadgeID, arg.Metric, arg.Threshold)
+	var i BadgeRule
+	err := row.Scan(&i.BadgeID, &i.Metric, &i.Threshold)
+	return i, err
+}
+
+const upsertBadgeTranslation = `-- name: UpsertBadgeTranslation :one
+INSERT INTO badge_translations (badge_id, locale, name, description)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (badge_id, locale) DO UPDATE
+SET name = EXCLUDED.name, description = EXCLUDED.description
+RETURNING badge_id, locale, name, description
+`
+
+type UpsertBadgeTranslationParams struct {
+	BadgeID     int32  `json:"badge_id"`
+	Locale      string `json:"locale"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (q *Queries) UpsertBadgeTranslation(ctx context.Context, arg UpsertBadgeTranslationParams) (BadgeTranslation, error) {
+	row := q.db.QueryRow(ctx, upsertBadgeTranslation,
+		arg.BadgeID,
+		arg.Locale,
+		arg.Name,
+		arg.Description,
+	)
+	var i BadgeTranslation
+	err := row.Scan(
+		&i.BadgeID,
+		&i.Locale,
+		&i.Name,
+		&i.Description,
+	)
+	return i, err
+}
@@ -11,6 +11,23 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const activateScheduledCampaigns = `-- name: ActivateScheduledCampaigns :execrows
+UPDATE campaigns
+SET status = 'ACTIVE', updated_at = NOW()
+WHERE status = 'DRAFT'
+  AND start_date IS NOT NULL
+  AND start_date <= NOW()
+  AND (end_date IS NULL OR end_date >= NOW())
+`
+
+func (q *Queries) ActivateScheduledCampaigns(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, activateScheduledCampaigns)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const addCampaignTargetBadge = `-- name: AddCampaignTargetBadge :exec
 INSERT INTO campaign_target_badges (campaign_id, badge_id)
 VALUES ($1, $2)
@@ -27,22 +44,168 @@ func (q *Queries) AddCampaignTargetBadge(ctx context.Context, arg AddCampaignTar
 	return err
 }
 
+const addCampaignTargetBadges = `-- name: AddCampaignTargetBadges :many
+INSERT INTO campaign_target_badges (campaign_id, badge_id)
+SELECT $1, b.id
+FROM badges b
+WHERE b.id = ANY($2::int[])
+ON CONFLICT DO NOTHING
+RETURNING badge_id
+`
+
+type AddCampaignTargetBadgesParams struct {
+	CampaignID int32   `json:"campaign_id"`
+	BadgeIds   []int32 `json:"badge_ids"`
+}
+
+func (q *Queries) AddCampaignTargetBadges(ctx context.Context, arg AddCampaignTargetBadgesParams) ([]int32, error) {
+	rows, err := q.db.Query(ctx, addCampaignTargetBadges, arg.CampaignID, arg.BadgeIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int32{}
+	for rows.Next() {
+		var badge_id int32
+		if err := rows.Scan(&badge_id); err != nil {
+			return nil, err
+		}
+		items = append(items, badge_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const addCampaignWindow = `-- name: AddCampaignWindow :exec
+INSERT INTO campaign_windows (campaign_id, days, start_hour, end_hour)
+VALUES ($1, $2, $3, $4)
+`
+
+type AddCampaignWindowParams struct {
+	CampaignID int32   `json:"campaign_id"`
+	Days       []int32 `json:"days"`
+	StartHour  int32   `json:"start_hour"`
+	EndHour    int32   `json:"end_hour"`
+}
+
+func (q *Queries) AddCampaignWindow(ctx context.Context, arg AddCampaignWindowParams) error {
+	_, err := q.db.Exec(ctx, addCampaignWindow,
+		arg.CampaignID,
+		arg.Days,
+		arg.StartHour,
+		arg.EndHour,
+	)
+	return err
+}
+
+const consumeCampaignBudget = `-- name: ConsumeCampaignBudget :one
+UPDATE campaigns
+SET redemptions = redemptions + 1,
+    coins_spent = coins_spent + $2,
+    discount_spent = discount_spent + $3,
+    updated_at = NOW()
+WHERE id = $1
+  AND (status = 'ACTIVE' OR (status = 'DRAFT' AND start_date IS NOT NULL))
+  AND (max_redemptions IS NULL OR redemptions < max_redemptions)
+  AND (coin_budget IS NULL OR coins_spent + $2 <= coin_budget)
+  AND (discount_budget IS NULL OR discount_spent + $3 <= discount_budget)
+RETURNING id, title, description, status, target, end_date, owner_id, station_id, coin_reward, created_at, updated_at, new_customers_only, vehicle_types, discount_type, discount_value, priority, exclusive, stackable, start_date, timezone, coin_budget, discount_budget, max_redemptions, max_redemptions_per_user, coins_spent, discount_spent, redemptions, paused_reason
+`
+
+type ConsumeCampaignBudgetParams struct {
+	ID       int32   `json:"id"`
+	Coins    int32   `json:"coins"`
+	Discount float64 `json:"discount"`
+}
+
+func (q *Queries) ConsumeCampaignBudget(ctx context.Context, arg ConsumeCampaignBudgetParams) (Campaign, error) {
+	row := q.db.QueryRow(ctx, consumeCampaignBudget, arg.ID, arg.Coins, arg.Discount)
+	var i Campaign
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.Status,
+		&i.Target,
+		&i.EndDate,
+		&i.OwnerID,
+		&i.StationID,
+		&i.CoinReward,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.NewCustomersOnly,
+		&i.VehicleTypes,
+		&i.DiscountType,
+		&i.DiscountValue,
+		&i.Priority,
+		&i.Exclusive,
+		&i.Stackable,
+		&i.StartDate,
+		&i.Timezone,
+		&i.CoinBudget,
+		&i.DiscountBudget,
+		&i.MaxRedemptions,
+		&i.MaxRedemptionsPerUser,
+		&i.CoinsSpent,
+		&i.DiscountSpent,
+		&i.Redemptions,
+		&i.PausedReason,
+	)
+	return i, err
+}
+
+const countUserCampaignRedemptions = `-- name: CountUserCampaignRedemptions :one
+SELECT COUNT(*) FROM reservation_campaigns rc
+JOIN reservations r ON r.id = rc.reservation_id
+WHERE r.user_id = $1
+  AND rc.campaign_id = $2
+  AND r.status <> 'CANCELLED'
+`
+
+type CountUserCampaignRedemptionsParams struct {
+	UserID     int32 `json:"user_id"`
+	CampaignID int32 `json:"campaign_id"`
+}
+
+func (q *Queries) CountUserCampaignRedemptions(ctx context.Context, arg CountUserCampaignRedemptionsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countUserCampaignRedemptions, arg.UserID, arg.CampaignID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createCampaign = `-- name: CreateCampaign :one
-INSERT INTO campaigns (title, description, status, target, discount, end_date, owner_id, station_id, coin_reward)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-RETURNING id, title, description, status, target, discount, end_date, owner_id, station_id, coin_reward, created_at, updated_at
+INSERT INTO campaigns (title, description, status, target, discount_type, discount_value, end_date, owner_id,
+                       station_id, coin_reward, new_customers_only, vehicle_types, priority, exclusive, stackable,
+                       start_date, timezone, coin_budget, discount_budget, max_redemptions, max_redemptions_per_user)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+RETURNING id, title, description, status, target, end_date, owner_id, station_id, coin_reward, created_at, updated_at, new_customers_only, vehicle_types, discount_type, discount_value, priority, exclusive, stackable, start_date, timezone, coin_budget, discount_budget, max_redemptions, max_redemptions_per_user, coins_spent, discount_spent, redemptions, paused_reason
 `
 
 type CreateCampaignParams struct {
-	Title       string             `json:"title"`
-	Description string             `json:"description"`
-	Status      string             `json:"status"`
-	Target      string             `json:"target"`
-	Discount    string             `json:"discount"`
-	EndDate     pgtype.Timestamptz `json:"end_date"`
-	OwnerID     int32              `json:"owner_id"`
-	StationID   pgtype.Int4        `json:"station_id"`
-	CoinReward  int32              `json:"coin_reward"`
+	Title                 string             `json:"title"`
+	Description           string             `json:"description"`
+	Status                string             `json:"status"`
+	Target                string             `json:"target"`
+	DiscountType          string             `json:"discount_type"`
+	DiscountValue         float64            `json:"discount_value"`
+	EndDate               pgtype.Timestamptz `json:"end_date"`
+	OwnerID               int32              `json:"owner_id"`
+	StationID             pgtype.Int4        `json:"station_id"`
+	CoinReward            int32              `json:"coin_reward"`
+	NewCustomersOnly      bool               `json:"new_customers_only"`
+	VehicleTypes          []string           `json:"vehicle_types"`
+	Priority              int32              `json:"priority"`
+	Exclusive             bool               `json:"exclusive"`
+	Stackable             bool               `json:"stackable"`
+	StartDate             pgtype.Timestamptz `json:"start_date"`
+	Timezone              string             `json:"timezone"`
+	CoinBudget            pgtype.Int4        `json:"coin_budget"`
+	DiscountBudget        pgtype.Float8      `json:"discount_budget"`
+	MaxRedemptions        pgtype.Int4        `json:"max_redemptions"`
+	MaxRedemptionsPerUser pgtype.Int4        `json:"max_redemptions_per_user"`
 }
 
 func (q *Queries) CreateCampaign(ctx context.Context, arg CreateCampaignParams) (Campaign, error) {
@@ -51,11 +214,23 @@ func (q *Queries) CreateCampaign(ctx context.Context, arg CreateCampaignParams)
 		arg.Description,
 		arg.Status,
 		arg.Target,
-		arg.Discount,
+		arg.DiscountType,
+		arg.DiscountValue,
 		arg.EndDate,
 		arg.OwnerID,
 		arg.StationID,
 		arg.CoinReward,
+		arg.NewCustomersOnly,
+		arg.VehicleTypes,
+		arg.Priority,
+		arg.Exclusive,
+		arg.Stackable,
+		arg.StartDate,
+		arg.Timezone,
+		arg.CoinBudget,
+		arg.DiscountBudget,
+		arg.MaxRedemptions,
+		arg.MaxRedemptionsPerUser,
 	)
 	var i Campaign
 	err := row.Scan(
@@ -64,13 +239,70 @@ func (q *Queries) CreateCampaign(ctx context.Context, arg CreateCampaignParams)
 		&i.Description,
 		&i.Status,
 		&i.Target,
-		&i.Discount,
 		&i.EndDate,
 		&i.OwnerID,
 		&i.StationID,
 		&i.CoinReward,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.NewCustomersOnly,
+		&i.VehicleTypes,
+		&i.DiscountType,
+		&i.DiscountValue,
+		&i.Priority,
+		&i.Exclusive,
+		&i.Stackable,
+		&i.StartDate,
+		&i.Timezone,
+		&i.CoinBudget,
+		&i.DiscountBudget,
+		&i.MaxRedemptions,
+		&i.MaxRedemptionsPerUser,
+		&i.CoinsSpent,
+		&i.DiscountSpent,
+		&i.Redemptions,
+		&i.PausedReason,
+	)
+	return i, err
+}
+
+const createCampaignVariant = `-- name: CreateCampaignVariant :one
+INSERT INTO campaign_variants (campaign_id, name, weight, is_control, discount_type, discount_value, coin_reward)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, campaign_id, name, weight, is_control, discount_type, discount_value, coin_reward, created_at
+`
+
+type CreateCampaignVariantParams struct {
+	CampaignID    int32   `json:"campaign_id"`
+	Name          string  `json:"name"`
+	Weight        int32   `json:"weight"`
+	IsControl     bool    `json:"is_control"`
+	DiscountType  string  `json:"discount_type"`
+	DiscountValue float64 `json:"discount_value"`
+	CoinReward    int32   `json:"coin_reward"`
+}
+
+func (q *Queries) CreateCampaignVariant(ctx context.Context, arg CreateCampaignVariantParams) (CampaignVariant, error) {
+	row := q.db.QueryRow(ctx, createCampaignVariant,
+		arg.CampaignID,
+		arg.Name,
+		arg.Weight,
+		arg.IsControl,
+		arg.DiscountType,
+		arg.DiscountValue,
+		arg.CoinReward,
+	)
+	var i CampaignVariant
+	err := row.Scan(
+		&i.ID,
+		&i.CampaignID,
+		&i.Name,
+		&i.Weight,
+		&i.IsControl,
+		&i.DiscountType,
+		&i.DiscountValue,
+		&i.CoinReward,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -84,16 +316,48 @@ func (q *Queries) DeleteCampaign(ctx context.Context, id int32) error {
 	return err
 }
 
+const deleteCampaignVariants = `-- name: DeleteCampaignVariants :exec
+DELETE FROM campaign_variants WHERE campaign_id = $1
+`
+
+func (q *Queries) DeleteCampaignVariants(ctx context.Context, campaignID int32) error {
+	_, err := q.db.Exec(ctx, deleteCampaignVariants, campaignID)
+	return err
+}
+
+const endExpiredCampaigns = `-- name: EndExpiredCampaigns :execrows
+UPDATE campaigns
+SET status = 'ENDED', updated_at = NOW()
+WHERE status IN ('DRAFT', 'ACTIVE', 'PAUSED')
+  AND end_date IS NOT NULL
+  AND end_date < NOW()
+`
+
+func (q *Queries) EndExpiredCampaigns(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, endExpiredCampaigns)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getActiveCampaignsForStation = `-- name: GetActiveCampaignsForStation :many
-SELECT id, title, description, status, target, discount, end_date, owner_id, station_id, coin_reward, created_at, updated_at FROM campaigns
-WHERE status = 'ACTIVE'
-  AND (end_date IS NULL OR end_date >= NOW())
-  AND (station_id = $1 OR station_id IS NULL)
-ORDER BY created_at DESC
+SELECT id, title, description, status, target, end_date, owner_id, station_id, coin_reward, created_at, updated_at, new_customers_only, vehicle_types, discount_type, discount_value, priority, exclusive, stackable, start_date, timezone, coin_budget, discount_budget, max_redemptions, max_redemptions_per_user, coins_spent, discount_spent, redemptions, paused_reason FROM campaigns
+WHERE (status = 'ACTIVE' OR (status = 'DRAFT' AND start_date IS NOT NULL))
+  AND (start_date IS NULL OR start_date < $1)
+  AND (end_date IS NULL OR end_date >= $2)
+  AND (station_id = $3 OR station_id IS NULL)
+ORDER BY priority DESC, (station_id IS NOT NULL) DESC, created_at DESC
 `
 
-func (q *Queries) GetActiveCampaignsForStation(ctx context.Context, stationID pgtype.Int4) ([]Campaign, error) {
-	rows, err := q.db.Query(ctx, getActiveCampaignsForStation, stationID)
+type GetActiveCampaignsForStationParams struct {
+	WindowEnd   pgtype.Timestamptz `json:"window_end"`
+	WindowStart pgtype.Timestamptz `json:"window_start"`
+	StationID   pgtype.Int4        `json:"station_id"`
+}
+
+func (q *Queries) GetActiveCampaignsForStation(ctx context.Context, arg GetActiveCampaignsForStationParams) ([]Campaign, error) {
+	rows, err := q.db.Query(ctx, getActiveCampaignsForStation, arg.WindowEnd, arg.WindowStart, arg.StationID)
 	if err != nil {
 		return nil, err
 	}
@@ -107,13 +371,29 @@ func (q *Queries) GetActiveCampaignsForStation(ctx context.Context, stationID pg
 			&i.Description,
 			&i.Status,
 			&i.Target,
-			&i.Discount,
 			&i.EndDate,
 			&i.OwnerID,
 			&i.StationID,
 			&i.CoinReward,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.NewCustomersOnly,
+			&i.VehicleTypes,
+			&i.DiscountType,
+			&i.DiscountValue,
+			&i.Priority,
+			&i.Exclusive,
+			&i.Stackable,
+			&i.StartDate,
+			&i.Timezone,
+			&i.CoinBudget,
+			&i.DiscountBudget,
+			&i.MaxRedemptions,
+			&i.MaxRedemptionsPerUser,
+			&i.CoinsSpent,
+			&i.DiscountSpent,
+			&i.Redemptions,
+			&i.PausedReason,
 		); err != nil {
 			return nil, err
 		}
@@ -125,8 +405,45 @@ func (q *Queries) GetActiveCampaignsForStation(ctx context.Context, stationID pg
 	return items, nil
 }
 
+const getCampaignAttribution = `-- name: GetCampaignAttribution :one
+SELECT
+    COUNT(*)::int AS reservations,
+    COUNT(*) FILTER (WHERE r.status = 'COMPLETED')::int AS completed_reservations,
+    COUNT(*) FILTER (WHERE r.is_green)::int AS green_reservations,
+    COUNT(DISTINCT r.user_id)::int AS unique_drivers,
+    COALESCE(SUM(rc.coin_bonus), 0)::int AS coin_bonus,
+    COALESCE(SUM(rc.discount), 0)::float8 AS discount
+FROM reservation_campaigns rc
+JOIN reservations r ON r.id = rc.reservation_id
+WHERE rc.campaign_id = $1
+  AND r.status <> 'CANCELLED'
+`
+
+type GetCampaignAttributionRow struct {
+	Reservations          int32   `json:"reservations"`
+	CompletedReservations int32   `json:"completed_reservations"`
+	GreenReservations     int32   `json:"green_reservations"`
+	UniqueDrivers         int32   `json:"unique_drivers"`
+	CoinBonus             int32   `json:"coin_bonus"`
+	Discount              float64 `json:"discount"`
+}
+
+func (q *Queries) GetCampaignAttribution(ctx context.Context, campaignID int32) (GetCampaignAttributionRow, error) {
+	row := q.db.QueryRow(ctx, getCampaignAttribution, campaignID)
+	var i GetCampaignAttributionRow
+	err := row.Scan(
+		&i.Reservations,
+		&i.CompletedReservations,
+		&i.GreenReservations,
+		&i.UniqueDrivers,
+		&i.CoinBonus,
+		&i.Discount,
+	)
+	return i, err
+}
+
 const getCampaignByID = `-- name: GetCampaignByID :one
-SELECT id, title, description, status, target, discount, end_date, owner_id, station_id, coin_reward, created_at, updated_at FROM campaigns WHERE id = $1
+SELECT id, title, description, status, target, end_date, owner_id, station_id, coin_reward, created_at, updated_at, new_customers_only, vehicle_types, discount_type, discount_value, priority, exclusive, stackable, start_date, timezone, coin_budget, discount_budget, max_redemptions, max_redemptions_per_user, coins_spent, discount_spent, redemptions, paused_reason FROM campaigns WHERE id = $1
 `
 
 func (q *Queries) GetCampaignByID(ctx context.Context, id int32) (Campaign, error) {
@@ -138,19 +455,75 @@ func (q *Queries) GetCampaignByID(ctx context.Context, id int32) (Campaign, erro
 		&i.Description,
 		&i.Status,
 		&i.Target,
-		&i.Discount,
 		&i.EndDate,
 		&i.OwnerID,
 		&i.StationID,
 		&i.CoinReward,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.NewCustomersOnly,
+		&i.VehicleTypes,
+		&i.DiscountType,
+		&i.DiscountValue,
+		&i.Priority,
+		&i.Exclusive,
+		&i.Stackable,
+		&i.StartDate,
+		&i.Timezone,
+		&i.CoinBudget,
+		&i.DiscountBudget,
+		&i.MaxRedemptions,
+		&i.MaxRedemptionsPerUser,
+		&i.CoinsSpent,
+		&i.DiscountSpent,
+		&i.Redemptions,
+		&i.PausedReason,
+	)
+	return i, err
+}
+
+const getCampaignForUpdate = `-- name: GetCampaignForUpdate :one
+SELECT id, title, description, status, target, end_date, owner_id, station_id, coin_reward, created_at, updated_at, new_customers_only, vehicle_types, discount_type, discount_value, priority, exclusive, stackable, start_date, timezone, coin_budget, discount_budget, max_redemptions, max_redemptions_per_user, coins_spent, discount_spent, redemptions, paused_reason FROM campaigns WHERE id = $1 FOR UPDATE
+`
+
+func (q *Queries) GetCampaignForUpdate(ctx context.Context, id int32) (Campaign, error) {
+	row := q.db.QueryRow(ctx, getCampaignForUpdate, id)
+	var i Campaign
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.Status,
+		&i.Target,
+		&i.EndDate,
+		&i.OwnerID,
+		&i.StationID,
+		&i.CoinReward,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.NewCustomersOnly,
+		&i.VehicleTypes,
+		&i.DiscountType,
+		&i.DiscountValue,
+		&i.Priority,
+		&i.Exclusive,
+		&i.Stackable,
+		&i.StartDate,
+		&i.Timezone,
+		&i.CoinBudget,
+		&i.DiscountBudget,
+		&i.MaxRedemptions,
+		&i.MaxRedemptionsPerUser,
+		&i.CoinsSpent,
+		&i.DiscountSpent,
+		&i.Redemptions,
+		&i.PausedReason,
 	)
 	return i, err
 }
 
 const getCampaignTargetBadges = `-- name: GetCampaignTargetBadges :many
-SELECT b.id, b.name, b.description, b.icon
+SELECT b.id, b.name, b.description, b.icon, b.icon_url, b.retired_at
 FROM badges b
 JOIN campaign_target_badges ctb ON ctb.badge_id = b.id
 WHERE ctb.campaign_id = $1
@@ -170,6 +543,8 @@ func (q *Queries) GetCampaignTargetBadges(ctx context.Context, campaignID int32)
 			&i.Name,
 			&i.Description,
 			&i.Icon,
+			&i.IconUrl,
+			&i.RetiredAt,
 		); err != nil {
 			return nil, err
 		}
@@ -181,9 +556,48 @@ func (q *Queries) GetCampaignTargetBadges(ctx context.Context, campaignID int32)
 	return items, nil
 }
 
+const getStationActivity = `-- name: GetStationActivity :one
+SELECT
+    COUNT(*)::int AS reservations,
+    COUNT(*) FILTER (WHERE r.is_green)::int AS green_reservations
+FROM reservations r
+JOIN stations s ON s.id = r.station_id
+WHERE ($1::int IS NULL OR r.station_id = $1)
+  AND s.owner_id = $2
+  AND r.status <> 'CANCELLED'
+  AND r.date >= $3
+  AND r.date < $4
+`
+
+type GetStationActivityParams struct {
+	StationID   pgtype.Int4        `json:"station_id"`
+	OwnerID     int32              `json:"owner_id"`
+	PeriodStart pgtype.Timestamptz `json:"period_start"`
+	PeriodEnd   pgtype.Timestamptz `json:"period_end"`
+}
+
+type GetStationActivityRow struct {
+	Reservations      int32 `json:"reservations"`
+	GreenReservations int32 `json:"green_reservations"`
+}
+
+func (q *Queries) GetStationActivity(ctx context.Context, arg GetStationActivityParams) (GetStationActivityRow, error) {
+	row := q.db.QueryRow(ctx, getStationActivity,
+		arg.StationID,
+		arg.OwnerID,
+		arg.PeriodStart,
+		arg.PeriodEnd,
+	)
+	var i GetStationActivityRow
+	err := row.Scan(&i.Reservations, &i.GreenReservations)
+	return i, err
+}
+
 const listActiveCampaigns = `-- name: ListActiveCampaigns :many
-SELECT id, title, description, status, target, discount, end_date, owner_id, station_id, coin_reward, created_at, updated_at FROM campaigns
+SELECT id, title, description, status, target, end_date, owner_id, station_id, coin_reward, created_at, updated_at, new_customers_only, vehicle_types, discount_type, discount_value, priority, exclusive, stackable, start_date, timezone, coin_budget, discount_budget, max_redemptions, max_redemptions_per_user, coins_spent, discount_spent, redemptions, paused_reason FROM campaigns
 WHERE status = 'ACTIVE'
+  AND (start_date IS NULL OR start_date <= NOW())
+  AND (end_date IS NULL OR end_date >= NOW())
 ORDER BY coin_reward DESC
 `
 
@@ -202,13 +616,158 @@ func (q *Queries) ListActiveCampaigns(ctx context.Context) ([]Campaign, error) {
 			&i.Description,
 			&i.Status,
 			&i.Target,
-			&i.Discount,
 			&i.EndDate,
 			&i.OwnerID,
 			&i.StationID,
 			&i.CoinReward,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.NewCustomersOnly,
+			&i.VehicleTypes,
+			&i.DiscountType,
+			&i.DiscountValue,
+			&i.Priority,
+			&i.Exclusive,
+			&i.Stackable,
+			&i.StartDate,
+			&i.Timezone,
+			&i.CoinBudget,
+			&i.DiscountBudget,
+			&i.MaxRedemptions,
+			&i.MaxRedemptionsPerUser,
+			&i.CoinsSpent,
+			&i.DiscountSpent,
+			&i.Redemptions,
+			&i.PausedReason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCampaignVariantStats = `-- name: ListCampaignVariantStats :many
+SELECT
+    v.id, v.name, v.weight, v.is_control, v.discount_type, v.discount_value, v.coin_reward,
+    (SELECT COUNT(*) FROM campaign_exposures e WHERE e.variant_id = v.id)::int AS exposures,
+    COUNT(DISTINCT r.user_id)::int AS converted_users,
+    COUNT(r.id)::int AS reservations,
+    COUNT(r.id) FILTER (WHERE r.is_green)::int AS green_reservations
+FROM campaign_variants v
+LEFT JOIN reservation_campaigns rc ON rc.variant_id = v.id
+LEFT JOIN reservations r ON r.id = rc.reservation_id AND r.status <> 'CANCELLED'
+WHERE v.campaign_id = $1
+GROUP BY v.id
+ORDER BY v.id
+`
+
+type ListCampaignVariantStatsRow struct {
+	ID                int32   `json:"id"`
+	Name              string  `json:"name"`
+	Weight            int32   `json:"weight"`
+	IsControl         bool    `json:"is_control"`
+	DiscountType      string  `json:"discount_type"`
+	DiscountValue     float64 `json:"discount_value"`
+	CoinReward        int32   `json:"coin_reward"`
+	Exposures         int32   `json:"exposures"`
+	ConvertedUsers    int32   `json:"converted_users"`
+	Reservations      int32   `json:"reservations"`
+	GreenReservations int32   `json:"green_reservations"`
+}
+
+func (q *Queries) ListCampaignVariantStats(ctx context.Context, campaignID int32) ([]ListCampaignVariantStatsRow, error) {
+	rows, err := q.db.Query(ctx, listCampaignVariantStats, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListCampaignVariantStatsRow{}
+	for rows.Next() {
+		var i ListCampaignVariantStatsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Weight,
+			&i.IsControl,
+			&i.DiscountType,
+			&i.DiscountValue,
+			&i.CoinReward,
+			&i.Exposures,
+			&i.ConvertedUsers,
+			&i.Reservations,
+			&i.GreenReservations,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCampaignVariants = `-- name: ListCampaignVariants :many
+SELECT id, campaign_id, name, weight, is_control, discount_type, discount_value, coin_reward, created_at FROM campaign_variants
+WHERE campaign_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListCampaignVariants(ctx context.Context, campaignID int32) ([]CampaignVariant, error) {
+	rows, err := q.db.Query(ctx, listCampaignVariants, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CampaignVariant{}
+	for rows.Next() {
+		var i CampaignVariant
+		if err := rows.Scan(
+			&i.ID,
+			&i.CampaignID,
+			&i.Name,
+			&i.Weight,
+			&i.IsControl,
+			&i.DiscountType,
+			&i.DiscountValue,
+			&i.CoinReward,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCampaignWindows = `-- name: ListCampaignWindows :many
+SELECT id, campaign_id, days, start_hour, end_hour FROM campaign_windows
+WHERE campaign_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListCampaignWindows(ctx context.Context, campaignID int32) ([]CampaignWindow, error) {
+	rows, err := q.db.Query(ctx, listCampaignWindows, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CampaignWindow{}
+	for rows.Next() {
+		var i CampaignWindow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CampaignID,
+			&i.Days,
+			&i.StartHour,
+			&i.EndHour,
 		); err != nil {
 			return nil, err
 		}
@@ -221,9 +780,7 @@ func (q *Queries) ListActiveCampaigns(ctx context.Context) ([]Campaign, error) {
 }
 
 const listCampaignsByOwner = `-- name: ListCampaignsByOwner :many
-SELECT c.id, c.title, c.description, c.status, c.target, c.discount,
-       c.end_date, c.owner_id, c.station_id, c.coin_reward, c.created_at, c.updated_at,
-       s.name AS station_name
+SELECT c.id, c.title, c.description, c.status, c.target, c.end_date, c.owner_id, c.station_id, c.coin_reward, c.created_at, c.updated_at, c.new_customers_only, c.vehicle_types, c.discount_type, c.discount_value, c.priority, c.exclusive, c.stackable, c.start_date, c.timezone, c.coin_budget, c.discount_budget, c.max_redemptions, c.max_redemptions_per_user, c.coins_spent, c.discount_spent, c.redemptions, c.paused_reason, s.name AS station_name
 FROM campaigns c
 LEFT JOIN stations s ON s.id = c.station_id
 WHERE c.owner_id = $1
@@ -231,19 +788,35 @@ ORDER BY c.created_at DESC
 `
 
 type ListCampaignsByOwnerRow struct {
-	ID          int32              `json:"id"`
-	Title       string             `json:"title"`
-	Description string             `json:"description"`
-	Status      string             `json:"status"`
-	Target      string             `json:"target"`
-	Discount    string             `json:"discount"`
-	EndDate     pgtype.Timestamptz `json:"end_date"`
-	OwnerID     int32              `json:"owner_id"`
-	StationID   pgtype.Int4        `json:"station_id"`
-	CoinReward  int32              `json:"coin_reward"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
-	StationName pgtype.Text        `json:"station_name"`
+	ID                    int32              `json:"id"`
+	Title                 string             `json:"title"`
+	Description           string             `json:"description"`
+	Status                string             `json:"status"`
+	Target                string             `json:"target"`
+	EndDate               pgtype.Timestamptz `json:"end_date"`
+	OwnerID               int32              `json:"owner_id"`
+	StationID             pgtype.Int4        `json:"station_id"`
+	CoinReward            int32              `json:"coin_reward"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+	NewCustomersOnly      bool               `json:"new_customers_only"`
+	VehicleTypes          []string           `json:"vehicle_types"`
+	DiscountType          string             `json:"discount_type"`
+	DiscountValue         float64            `json:"discount_value"`
+	Priority              int32              `json:"priority"`
+	Exclusive             bool               `json:"exclusive"`
+	Stackable             bool               `json:"stackable"`
+	StartDate             pgtype.Timestamptz `json:"start_date"`
+	Timezone              string             `json:"timezone"`
+	CoinBudget            pgtype.Int4        `json:"coin_budget"`
+	DiscountBudget        pgtype.Float8      `json:"discount_budget"`
+	MaxRedemptions        pgtype.Int4        `json:"max_redemptions"`
+	MaxRedemptionsPerUser pgtype.Int4        `json:"max_redemptions_per_user"`
+	CoinsSpent            int32              `json:"coins_spent"`
+	DiscountSpent         float64            `json:"discount_spent"`
+	Redemptions           int32              `json:"redemptions"`
+	PausedReason          pgtype.Text        `json:"paused_reason"`
+	StationName           pgtype.Text        `json:"station_name"`
 }
 
 func (q *Queries) ListCampaignsByOwner(ctx context.Context, ownerID int32) ([]ListCampaignsByOwnerRow, error) {
@@ -261,13 +834,29 @@ func (q *Queries) ListCampaignsByOwner(ctx context.Context, ownerID int32) ([]Li
 			&i.Description,
 			&i.Status,
 			&i.Target,
-			&i.Discount,
 			&i.EndDate,
 			&i.OwnerID,
 			&i.StationID,
 			&i.CoinReward,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.NewCustomersOnly,
+			&i.VehicleTypes,
+			&i.DiscountType,
+			&i.DiscountValue,
+			&i.Priority,
+			&i.Exclusive,
+			&i.Stackable,
+			&i.StartDate,
+			&i.Timezone,
+			&i.CoinBudget,
+			&i.DiscountBudget,
+			&i.MaxRedemptions,
+			&i.MaxRedemptionsPerUser,
+			&i.CoinsSpent,
+			&i.DiscountSpent,
+			&i.Redemptions,
+			&i.PausedReason,
 			&i.StationName,
 		); err != nil {
 			return nil, err
@@ -280,6 +869,92 @@ func (q *Queries) ListCampaignsByOwner(ctx context.Context, ownerID int32) ([]Li
 	return items, nil
 }
 
+const listUserCampaignUsage = `-- name: ListUserCampaignUsage :many
+SELECT rc.campaign_id, COUNT(*) AS uses
+FROM reservation_campaigns rc
+JOIN reservations r ON r.id = rc.reservation_id
+WHERE r.user_id = $1
+  AND r.status <> 'CANCELLED'
+GROUP BY rc.campaign_id
+`
+
+type ListUserCampaignUsageRow struct {
+	CampaignID int32 `json:"campaign_id"`
+	Uses       int64 `json:"uses"`
+}
+
+func (q *Queries) ListUserCampaignUsage(ctx context.Context, userID int32) ([]ListUserCampaignUsageRow, error) {
+	rows, err := q.db.Query(ctx, listUserCampaignUsage, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUserCampaignUsageRow{}
+	for rows.Next() {
+		var i ListUserCampaignUsageRow
+		if err := rows.Scan(&i.CampaignID, &i.Uses); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const pauseCampaign = `-- name: PauseCampaign :exec
+UPDATE campaigns
+SET status = 'PAUSED', paused_reason = $2, updated_at = NOW()
+WHERE id = $1 AND status IN ('ACTIVE', 'DRAFT')
+`
+
+type PauseCampaignParams struct {
+	ID           int32       `json:"id"`
+	PausedReason pgtype.Text `json:"paused_reason"`
+}
+
+func (q *Queries) PauseCampaign(ctx context.Context, arg PauseCampaignParams) error {
+	_, err := q.db.Exec(ctx, pauseCampaign, arg.ID, arg.PausedReason)
+	return err
+}
+
+const recordCampaignExposure = `-- name: RecordCampaignExposure :exec
+INSERT INTO campaign_exposures (variant_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+type RecordCampaignExposureParams struct {
+	VariantID int32 `json:"variant_id"`
+	UserID    int32 `json:"user_id"`
+}
+
+func (q *Queries) RecordCampaignExposure(ctx context.Context, arg RecordCampaignExposureParams) error {
+	_, err := q.db.Exec(ctx, recordCampaignExposure, arg.VariantID, arg.UserID)
+	return err
+}
+
+const releaseCampaignBudget = `-- name: ReleaseCampaignBudget :exec
+UPDATE campaigns
+SET redemptions = GREATEST(redemptions - 1, 0),
+    coins_spent = GREATEST(coins_spent - $2, 0),
+    discount_spent = GREATEST(discount_spent - $3, 0),
+    updated_at = NOW()
+WHERE id = $1
+`
+
+type ReleaseCampaignBudgetParams struct {
+	ID       int32   `json:"id"`
+	Coins    int32   `json:"coins"`
+	Discount float64 `json:"discount"`
+}
+
+func (q *Queries) ReleaseCampaignBudget(ctx context.Context, arg ReleaseCampaignBudgetParams) error {
+	_, err := q.db.Exec(ctx, releaseCampaignBudget, arg.ID, arg.Coins, arg.Discount)
+	return err
+}
+
 const removeCampaignTargetBadges = `-- name: RemoveCampaignTargetBadges :exec
 DELETE FROM campaign_target_badges WHERE campaign_id = $1
 `
@@ -289,24 +964,51 @@ func (q *Queries) RemoveCampaignTargetBadges(ctx context.Context, campaignID int
 	return err
 }
 
+const removeCampaignWindows = `-- name: RemoveCampaignWindows :exec
+DELETE FROM campaign_windows WHERE campaign_id = $1
+`
+
+func (q *Queries) RemoveCampaignWindows(ctx context.Context, campaignID int32) error {
+	_, err := q.db.Exec(ctx, removeCampaignWindows, campaignID)
+	return err
+}
+
 const updateCampaign = `-- name: UpdateCampaign :one
 UPDATE campaigns
-SET title = $2, description = $3, status = $4, target = $5, discount = $6,
-    end_date = $7, station_id = $8, coin_reward = $9, updated_at = NOW()
+SET title = $2, description = $3, status = $4, target = $5, discount_type = $6, discount_value = $7,
+    end_date = $8, station_id = $9, coin_reward = $10,
+    new_customers_only = $11, vehicle_types = $12,
+    priority = $13, exclusive = $14, stackable = $15,
+    start_date = $16, timezone = $17,
+    coin_budget = $18, discount_budget = $19, max_redemptions = $20, max_redemptions_per_user = $21,
+    paused_reason = CASE WHEN $4 = 'PAUSED' THEN paused_reason END,
+    updated_at = NOW()
 WHERE id = $1
-RETURNING id, title, description, status, target, discount, end_date, owner_id, station_id, coin_reward, created_at, updated_at
+RETURNING id, title, description, status, target, end_date, owner_id, station_id, coin_reward, created_at, updated_at, new_customers_only, vehicle_types, discount_type, discount_value, priority, exclusive, stackable, start_date, timezone, coin_budget, discount_budget, max_redemptions, max_redemptions_per_user, coins_spent, discount_spent, redemptions, paused_reason
 `
 
 type UpdateCampaignParams struct {
-	ID          int32              `json:"id"`
-	Title       string             `json:"title"`
-	Description string             `json:"description"`
-	Status      string             `json:"status"`
-	Target      string             `json:"target"`
-	Discount    string             `json:"discount"`
-	EndDate     pgtype.Timestamptz `json:"end_date"`
-	StationID   pgtype.Int4        `json:"station_id"`
-	CoinReward  int32              `json:"coin_reward"`
+	ID                    int32              `json:"id"`
+	Title                 string             `json:"title"`
+	Description           string             `json:"description"`
+	Status                string             `json:"status"`
+	Target                string             `json:"target"`
+	DiscountType          string             `json:"discount_type"`
+	DiscountValue         float64            `json:"discount_value"`
+	EndDate               pgtype.Timestamptz `json:"end_date"`
+	StationID             pgtype.Int4        `json:"station_id"`
+	CoinReward            int32              `json:"coin_reward"`
+	NewCustomersOnly      bool               `json:"new_customers_only"`
+	VehicleTypes          []string           `json:"vehicle_types"`
+	Priority              int32              `json:"priority"`
+	Exclusive             bool               `json:"exclusive"`
+	Stackable             bool               `json:"stackable"`
+	StartDate             pgtype.Timestamptz `json:"start_date"`
+	Timezone              string             `json:"timezone"`
+	CoinBudget            pgtype.Int4        `json:"coin_budget"`
+	DiscountBudget        pgtype.Float8      `json:"discount_budget"`
+	MaxRedemptions        pgtype.Int4        `json:"max_redemptions"`
+	MaxRedemptionsPerUser pgtype.Int4        `json:"max_redemptions_per_user"`
 }
 
 func (q *Queries) UpdateCampaign(ctx context.Context, arg UpdateCampaignParams) (Campaign, error) {
@@ -316,10 +1018,22 @@ func (q *Queries) UpdateCampaign(ctx context.Context, arg UpdateCampaignParams)
 		arg.Description,
 		arg.Status,
 		arg.Target,
-		arg.Discount,
+		arg.DiscountType,
+		arg.DiscountValue,
 		arg.EndDate,
 		arg.StationID,
 		arg.CoinReward,
+		arg.NewCustomersOnly,
+		arg.VehicleTypes,
+		arg.Priority,
+		arg.Exclusive,
+		arg.Stackable,
+		arg.StartDate,
+		arg.Timezone,
+		arg.CoinBudget,
+		arg.DiscountBudget,
+		arg.MaxRedemptions,
+		arg.MaxRedemptionsPerUser,
 	)
 	var i Campaign
 	err := row.Scan(
@@ -328,13 +1042,29 @@ func (q *Queries) UpdateCampaign(ctx context.Context, arg UpdateCampaignParams)
 		&i.Description,
 		&i.Status,
 		&i.Target,
-		&i.Discount,
 		&i.EndDate,
 		&i.OwnerID,
 		&i.StationID,
 		&i.CoinReward,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.NewCustomersOnly,
+		&i.VehicleTypes,
+		&i.DiscountType,
+		&i.DiscountValue,
+		&i.Priority,
+		&i.Exclusive,
+		&i.Stackable,
+		&i.StartDate,
+		&i.Timezone,
+		&i.CoinBudget,
+		&i.DiscountBudget,
+		&i.MaxRedemptions,
+		&i.MaxRedemptionsPerUser,
+		&i.CoinsSpent,
+		&i.DiscountSpent,
+		&i.Redemptions,
+		&i.PausedReason,
 	)
 	return i, err
 }
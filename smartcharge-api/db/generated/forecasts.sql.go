@@ -11,8 +11,24 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const deleteStationForecastsOutside = `-- name: DeleteStationForecastsOutside :exec
+DELETE FROM station_forecasts
+WHERE station_id = $1 AND (slot_start < $2 OR slot_start >= $3)
+`
+
+type DeleteStationForecastsOutsideParams struct {
+	StationID   int32              `json:"station_id"`
+	WindowStart pgtype.Timestamptz `json:"window_start"`
+	WindowEnd   pgtype.Timestamptz `json:"window_end"`
+}
+
+func (q *Queries) DeleteStationForecastsOutside(ctx context.Context, arg DeleteStationForecastsOutsideParams) error {
+	_, err := q.db.Exec(ctx, deleteStationForecastsOutside, arg.StationID, arg.WindowStart, arg.WindowEnd)
+	return err
+}
+
 const getForecastForStation = `-- name: GetForecastForStation :one
-SELECT predicted_load FROM station_density_forecasts
+SELECT predicted_load, p10_load, p90_load FROM station_density_forecasts
 WHERE station_id = $1 AND day_of_week = $2 AND hour = $3
 `
 
@@ -22,15 +38,21 @@ type GetForecastForStationParams struct {
 	Hour      int32 `json:"hour"`
 }
 
-func (q *Queries) GetForecastForStation(ctx context.Context, arg GetForecastForStationParams) (int32, error) {
+type GetForecastForStationRow struct {
+	PredictedLoad int32 `json:"predicted_load"`
+	P10Load       int32 `json:"p10_load"`
+	P90Load       int32 `json:"p90_load"`
+}
+
+func (q *Queries) GetForecastForStation(ctx context.Context, arg GetForecastForStationParams) (GetForecastForStationRow, error) {
 	row := q.db.QueryRow(ctx, getForecastForStation, arg.StationID, arg.DayOfWeek, arg.Hour)
-	var predicted_load int32
-	err := row.Scan(&predicted_load)
-	return predicted_load, err
+	var i GetForecastForStationRow
+	err := row.Scan(&i.PredictedLoad, &i.P10Load, &i.P90Load)
+	return i, err
 }
 
 const getForecastsByDayHour = `-- name: GetForecastsByDayHour :many
-SELECT f.id, f.station_id, f.day_of_week, f.hour, f.predicted_load,
+SELECT f.id, f.station_id, f.day_of_week, f.hour, f.predicted_load, f.p10_load, f.p90_load,
        s.name AS station_name, s.lat, s.lng, s.price, s.address, s.density_profile
 FROM station_density_forecasts f
 JOIN stations s ON s.id = f.station_id
@@ -49,6 +71,8 @@ type GetForecastsByDayHourRow struct {
 	DayOfWeek      int32       `json:"day_of_week"`
 	Hour           int32       `json:"hour"`
 	PredictedLoad  int32       `json:"predicted_load"`
+	P10Load        int32       `json:"p10_load"`
+	P90Load        int32       `json:"p90_load"`
 	StationName    string      `json:"station_name"`
 	Lat            float64     `json:"lat"`
 	Lng            float64     `json:"lng"`
@@ -72,6 +96,8 @@ func (q *Queries) GetForecastsByDayHour(ctx context.Context, arg GetForecastsByD
 			&i.DayOfWeek,
 			&i.Hour,
 			&i.PredictedLoad,
+			&i.P10Load,
+			&i.P90Load,
 			&i.StationName,
 			&i.Lat,
 			&i.Lng,
@@ -89,18 +115,258 @@ func (q *Queries) GetForecastsByDayHour(ctx context.Context, arg GetForecastsByD
 	return items, nil
 }
 
-const upsertForecast = `-- name: UpsertForecast :exec
-INSERT INTO station_density_forecasts (station_id, day_of_week, hour, predicted_load)
-VALUES ($1, $2, $3, $4)
-ON CONFLICT (station_id, day_of_week, hour)
-DO UPDATE SET predicted_load = $4, updated_at = NOW()
+const listForecastAccuracy = `-- name: ListForecastAccuracy :many
+SELECT a.station_id, s.name AS station_name, s.forecast_model, a.hour, a.model, a.window_start, a.window_end,
+       a.samples, a.mae, a.bias, a.mape, a.mape_samples, a.evaluated_at
+FROM forecast_accuracy a
+JOIN stations s ON s.id = a.station_id
+WHERE ($1::int IS NULL OR s.owner_id = $1)
+  AND ($2::int IS NULL OR a.station_id = $2)
+ORDER BY a.station_id, a.hour
 `
 
-type UpsertForecastParams struct {
-	StationID     int32 `json:"station_id"`
+type ListForecastAccuracyParams struct {
+	OwnerID   pgtype.Int4 `json:"owner_id"`
+	StationID pgtype.Int4 `json:"station_id"`
+}
+
+type ListForecastAccuracyRow struct {
+	StationID     int32              `json:"station_id"`
+	StationName   string             `json:"station_name"`
+	ForecastModel string             `json:"forecast_model"`
+	Hour          int32              `json:"hour"`
+	Model         string             `json:"model"`
+	WindowStart   pgtype.Timestamptz `json:"window_start"`
+	WindowEnd     pgtype.Timestamptz `json:"window_end"`
+	Samples       int32              `json:"samples"`
+	Mae           float64            `json:"mae"`
+	Bias          float64            `json:"bias"`
+	Mape          float64            `json:"mape"`
+	MapeSamples   int32              `json:"mape_samples"`
+	EvaluatedAt   pgtype.Timestamptz `json:"evaluated_at"`
+}
+
+func (q *Queries) ListForecastAccuracy(ctx context.Context, arg ListForecastAccuracyParams) ([]ListForecastAccuracyRow, error) {
+	rows, err := q.db.Query(ctx, listForecastAccuracy, arg.OwnerID, arg.StationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListForecastAccuracyRow{}
+	for rows.Next() {
+		var i ListForecastAccuracyRow
+		if err := rows.Scan(
+			&i.StationID,
+			&i.StationName,
+			&i.ForecastModel,
+			&i.Hour,
+			&i.Model,
+			&i.WindowStart,
+			&i.WindowEnd,
+			&i.Samples,
+			&i.Mae,
+			&i.Bias,
+			&i.Mape,
+			&i.MapeSamples,
+			&i.EvaluatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOccupancy = `-- name: ListOccupancy :many
+SELECT observed_at, load FROM station_occupancy
+WHERE station_id = $1 AND observed_at >= $2
+ORDER BY observed_at
+`
+
+type ListOccupancyParams struct {
+	StationID  int32              `json:"station_id"`
+	ObservedAt pgtype.Timestamptz `json:"observed_at"`
+}
+
+type ListOccupancyRow struct {
+	ObservedAt pgtype.Timestamptz `json:"observed_at"`
+	Load       int32              `json:"load"`
+}
+
+func (q *Queries) ListOccupancy(ctx context.Context, arg ListOccupancyParams) ([]ListOccupancyRow, error) {
+	rows, err := q.db.Query(ctx, listOccupancy, arg.StationID, arg.ObservedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListOccupancyRow{}
+	for rows.Next() {
+		var i ListOccupancyRow
+		if err := rows.Scan(&i.ObservedAt, &i.Load); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReservationHours = `-- name: ListReservationHours :many
+SELECT date, hour, COUNT(*)::int AS reservations
+FROM reservations
+WHERE station_id = $1 AND status <> 'CANCELLED' AND date >= $2
+GROUP BY date, hour
+ORDER BY date, hour
+`
+
+type ListReservationHoursParams struct {
+	StationID int32              `json:"station_id"`
+	Date      pgtype.Timestamptz `json:"date"`
+}
+
+type ListReservationHoursRow struct {
+	Date         pgtype.Timestamptz `json:"date"`
+	Hour         string             `json:"hour"`
+	Reservations int32              `json:"reservations"`
+}
+
+func (q *Queries) ListReservationHours(ctx context.Context, arg ListReservationHoursParams) ([]ListReservationHoursRow, error) {
+	rows, err := q.db.Query(ctx, listReservationHours, arg.StationID, arg.Date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListReservationHoursRow{}
+	for rows.Next() {
+		var i ListReservationHoursRow
+		if err := rows.Scan(&i.Date, &i.Hour, &i.Reservations); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStationForecasts = `-- name: ListStationForecasts :many
+SELECT slot_start, predicted_load, p10_load, p90_load, model, event,
+       temperature, precipitation, temperature_effect, precipitation_effect FROM station_forecasts
+WHERE station_id = $1 AND slot_start >= $2 AND slot_start < $3
+ORDER BY slot_start
+`
+
+type ListStationForecastsParams struct {
+	StationID   int32              `json:"station_id"`
+	WindowStart pgtype.Timestamptz `json:"window_start"`
+	WindowEnd   pgtype.Timestamptz `json:"window_end"`
+}
+
+type ListStationForecastsRow struct {
+	SlotStart           pgtype.Timestamptz `json:"slot_start"`
+	PredictedLoad       int32              `json:"predicted_load"`
+	P10Load             int32              `json:"p10_load"`
+	P90Load             int32              `json:"p90_load"`
+	Model               string             `json:"model"`
+	Event               pgtype.Text        `json:"event"`
+	Temperature         pgtype.Float8      `json:"temperature"`
+	Precipitation       pgtype.Float8      `json:"precipitation"`
+	TemperatureEffect   pgtype.Float8      `json:"temperature_effect"`
+	PrecipitationEffect pgtype.Float8      `json:"precipitation_effect"`
+}
+
+func (q *Queries) ListStationForecasts(ctx context.Context, arg ListStationForecastsParams) ([]ListStationForecastsRow, error) {
+	rows, err := q.db.Query(ctx, listStationForecasts, arg.StationID, arg.WindowStart, arg.WindowEnd)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListStationForecastsRow{}
+	for rows.Next() {
+		var i ListStationForecastsRow
+		if err := rows.Scan(
+			&i.SlotStart,
+			&i.PredictedLoad,
+			&i.P10Load,
+			&i.P90Load,
+			&i.Model,
+			&i.Event,
+			&i.Temperature,
+			&i.Precipitation,
+			&i.TemperatureEffect,
+			&i.PrecipitationEffect,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWeeklyForecastForStation = `-- name: ListWeeklyForecastForStation :many
+SELECT day_of_week, hour, predicted_load, p10_load, p90_load FROM station_density_forecasts
+WHERE station_id = $1
+ORDER BY day_of_week, hour
+`
+
+type ListWeeklyForecastForStationRow struct {
 	DayOfWeek     int32 `json:"day_of_week"`
 	Hour          int32 `json:"hour"`
 	PredictedLoad int32 `json:"predicted_load"`
+	P10Load       int32 `json:"p10_load"`
+	P90Load       int32 `json:"p90_load"`
+}
+
+func (q *Queries) ListWeeklyForecastForStation(ctx context.Context, stationID int32) ([]ListWeeklyForecastForStationRow, error) {
+	rows, err := q.db.Query(ctx, listWeeklyForecastForStation, stationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListWeeklyForecastForStationRow{}
+	for rows.Next() {
+		var i ListWeeklyForecastForStationRow
+		if err := rows.Scan(
+			&i.DayOfWeek,
+			&i.Hour,
+			&i.PredictedLoad,
+			&i.P10Load,
+			&i.P90Load,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertForecast = `-- name: UpsertForecast :exec
+INSERT INTO station_density_forecasts (station_id, day_of_week, hour, predicted_load, p90_load, model, p10_load)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (station_id, day_of_week, hour)
+DO UPDATE SET predicted_load = $4, p90_load = $5, model = $6, p10_load = $7, updated_at = NOW()
+`
+
+type UpsertForecastParams struct {
+	StationID     int32  `json:"station_id"`
+	DayOfWeek     int32  `json:"day_of_week"`
+	Hour          int32  `json:"hour"`
+	PredictedLoad int32  `json:"predicted_load"`
+	P90Load       int32  `json:"p90_load"`
+	Model         string `json:"model"`
+	P10Load       int32  `json:"p10_load"`
 }
 
 func (q *Queries) UpsertForecast(ctx context.Context, arg UpsertForecastParams) error {
@@ -109,6 +375,123 @@ func (q *Queries) UpsertForecast(ctx context.Context, arg UpsertForecastParams)
 		arg.DayOfWeek,
 		arg.Hour,
 		arg.PredictedLoad,
+		arg.P90Load,
+		arg.Model,
+		arg.P10Load,
+	)
+	return err
+}
+
+const upsertForecastAccuracy = `-- name: UpsertForecastAccuracy :exec
+INSERT INTO forecast_accuracy (station_id, hour, model, window_start, window_end, samples, mae, bias, mape, mape_samples)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (station_id, hour)
+DO UPDATE SET model = $3, window_start = $4, window_end = $5, samples = $6,
+              mae = $7, bias = $8, mape = $9, mape_samples = $10, evaluated_at = NOW()
+`
+
+type UpsertForecastAccuracyParams struct {
+	StationID   int32              `json:"station_id"`
+	Hour        int32              `json:"hour"`
+	Model       string             `json:"model"`
+	WindowStart pgtype.Timestamptz `json:"window_start"`
+	WindowEnd   pgtype.Timestamptz `json:"window_end"`
+	Samples     int32              `json:"samples"`
+	Mae         float64            `json:"mae"`
+	Bias        float64            `json:"bias"`
+	Mape        float64            `json:"mape"`
+	MapeSamples int32              `json:"mape_samples"`
+}
+
+func (q *Queries) UpsertForecastAccuracy(ctx context.Context, arg UpsertForecastAccuracyParams) error {
+	_, err := q.db.Exec(ctx, upsertForecastAccuracy,
+		arg.StationID,
+		arg.Hour,
+		arg.Model,
+		arg.WindowStart,
+		arg.WindowEnd,
+		arg.Samples,
+		arg.Mae,
+		arg.Bias,
+		arg.Mape,
+		arg.MapeSamples,
+	)
+	return err
+}
+
+const upsertOccupancy = `-- name: UpsertOccupancy :exec
+INSERT INTO station_occupancy (station_id, observed_at, load, source)
+SELECT $1, unnest($2::timestamptz[]), unnest($3::int[]), $4
+ON CONFLICT (station_id, observed_at)
+DO UPDATE SET load = EXCLUDED.load, source = EXCLUDED.source
+`
+
+type UpsertOccupancyParams struct {
+	StationID  int32                `json:"station_id"`
+	ObservedAt []pgtype.Timestamptz `json:"observed_at"`
+	Loads      []int32              `json:"loads"`
+	Source     string               `json:"source"`
+}
+
+func (q *Queries) UpsertOccupancy(ctx context.Context, arg UpsertOccupancyParams) error {
+	_, err := q.db.Exec(ctx, upsertOccupancy,
+		arg.StationID,
+		arg.ObservedAt,
+		arg.Loads,
+		arg.Source,
+	)
+	return err
+}
+
+const upsertStationForecasts = `-- name: UpsertStationForecasts :exec
+INSERT INTO station_forecasts (station_id, slot_start, predicted_load, p10_load, p90_load, model, event,
+                               temperature, precipitation, temperature_effect, precipitation_effect)
+SELECT $1, f.slot_start, f.predicted_load, f.p10_load, f.p90_load, $2, NULLIF(f.event, ''),
+       CASE WHEN f.has_weather THEN f.temperature END, CASE WHEN f.has_weather THEN f.precipitation END,
+       CASE WHEN f.has_weather THEN f.temperature_effect END, CASE WHEN f.has_weather THEN f.precipitation_effect END
+FROM unnest($3::timestamptz[], $4::int[], $5::int[],
+            $6::int[], $7::text[], $8::bool[],
+            $9::float8[], $10::float8[],
+            $11::float8[], $12::float8[])
+     AS f(slot_start, predicted_load, p10_load, p90_load, event, has_weather,
+          temperature, precipitation, temperature_effect, precipitation_effect)
+ON CONFLICT (station_id, slot_start)
+DO UPDATE SET predicted_load = EXCLUDED.predicted_load, p10_load = EXCLUDED.p10_load, p90_load = EXCLUDED.p90_load,
+              model = EXCLUDED.model, event = EXCLUDED.event,
+              temperature = EXCLUDED.temperature, precipitation = EXCLUDED.precipitation,
+              temperature_effect = EXCLUDED.temperature_effect, precipitation_effect = EXCLUDED.precipitation_effect,
+              updated_at = NOW()
+`
+
+type UpsertStationForecastsParams struct {
+	StationID            int32                `json:"station_id"`
+	Model                string               `json:"model"`
+	SlotStarts           []pgtype.Timestamptz `json:"slot_starts"`
+	PredictedLoads       []int32              `json:"predicted_loads"`
+	P10Loads             []int32              `json:"p10_loads"`
+	P90Loads             []int32              `json:"p90_loads"`
+	Events               []string             `json:"events"`
+	HasWeather           []bool               `json:"has_weather"`
+	Temperatures         []float64            `json:"temperatures"`
+	Precipitations       []float64            `json:"precipitations"`
+	TemperatureEffects   []float64            `json:"temperature_effects"`
+	PrecipitationEffects []float64            `json:"precipitation_effects"`
+}
+
+func (q *Queries) UpsertStationForecasts(ctx context.Context, arg UpsertStationForecastsParams) error {
+	_, err := q.db.Exec(ctx, upsertStationForecasts,
+		arg.StationID,
+		arg.Model,
+		arg.SlotStarts,
+		arg.PredictedLoads,
+		arg.P10Loads,
+		arg.P90Loads,
+		arg.Events,
+		arg.HasWeather,
+		arg.Temperatures,
+		arg.Precipitations,
+		arg.TemperatureEffects,
+		arg.PrecipitationEffects,
 	)
 	return err
 }
@@ -9,25 +9,76 @@ import (
 )
 
 type Badge struct {
-	ID          int32  `json:"id"`
+	ID          int32              `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Icon        string             `json:"icon"`
+	IconUrl     pgtype.Text        `json:"icon_url"`
+	RetiredAt   pgtype.Timestamptz `json:"retired_at"`
+}
+
+type BadgeProgress struct {
+	UserID       int32              `json:"user_id"`
+	BadgeID      int32              `json:"badge_id"`
+	CurrentValue float64            `json:"current_value"`
+	Target       float64            `json:"target"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
+
+type BadgeRule struct {
+	BadgeID   int32   `json:"badge_id"`
+	Metric    string  `json:"metric"`
+	Threshold float64 `json:"threshold"`
+}
+
+type BadgeTranslation struct {
+	BadgeID     int32  `json:"badge_id"`
+	Locale      string `json:"locale"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
-	Icon        string `json:"icon"`
+}
+
+type CalendarEvent struct {
+	ID        int32              `json:"id"`
+	Name      string             `json:"name"`
+	Kind      string             `json:"kind"`
+	StartsAt  pgtype.Timestamptz `json:"starts_at"`
+	EndsAt    pgtype.Timestamptz `json:"ends_at"`
+	StationID pgtype.Int4        `json:"station_id"`
+	Impact    pgtype.Int4        `json:"impact"`
+	CreatedBy pgtype.Int4        `json:"created_by"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
 type Campaign struct {
-	ID          int32              `json:"id"`
-	Title       string             `json:"title"`
-	Description string             `json:"description"`
-	Status      string             `json:"status"`
-	Target      string             `json:"target"`
-	Discount    string             `json:"discount"`
-	EndDate     pgtype.Timestamptz `json:"end_date"`
-	OwnerID     int32              `json:"owner_id"`
-	StationID   pgtype.Int4        `json:"station_id"`
-	CoinReward  int32              `json:"coin_reward"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	ID                    int32              `json:"id"`
+	Title                 string             `json:"title"`
+	Description           string             `json:"description"`
+	Status                string             `json:"status"`
+	Target                string             `json:"target"`
+	EndDate               pgtype.Timestamptz `json:"end_date"`
+	OwnerID               int32              `json:"owner_id"`
+	StationID             pgtype.Int4        `json:"station_id"`
+	CoinReward            int32              `json:"coin_reward"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+	NewCustomersOnly      bool               `json:"new_customers_only"`
+	VehicleTypes          []string           `json:"vehicle_types"`
+	DiscountType          string             `json:"discount_type"`
+	DiscountValue         float64            `json:"discount_value"`
+	Priority              int32              `json:"priority"`
+	Exclusive             bool               `json:"exclusive"`
+	Stackable             bool               `json:"stackable"`
+	StartDate             pgtype.Timestamptz `json:"start_date"`
+	Timezone              string             `json:"timezone"`
+	CoinBudget            pgtype.Int4        `json:"coin_budget"`
+	DiscountBudget        pgtype.Float8      `json:"discount_budget"`
+	MaxRedemptions        pgtype.Int4        `json:"max_redemptions"`
+	MaxRedemptionsPerUser pgtype.Int4        `json:"max_redemptions_per_user"`
+	CoinsSpent            int32              `json:"coins_spent"`
+	DiscountSpent         float64            `json:"discount_spent"`
+	Redemptions           int32              `json:"redemptions"`
+	PausedReason          pgtype.Text        `json:"paused_reason"`
 }
 
 type CampaignTargetBadge struct {
@@ -35,16 +86,149 @@ type CampaignTargetBadge struct {
 	BadgeID    int32 `json:"badge_id"`
 }
 
-type Reservation struct {
-	ID          int32              `json:"id"`
-	UserID      int32              `json:"user_id"`
+type CampaignVariant struct {
+	ID            int32              `json:"id"`
+	CampaignID    int32              `json:"campaign_id"`
+	Name          string             `json:"name"`
+	Weight        int32              `json:"weight"`
+	IsControl     bool               `json:"is_control"`
+	DiscountType  string             `json:"discount_type"`
+	DiscountValue float64            `json:"discount_value"`
+	CoinReward    int32              `json:"coin_reward"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
+type CampaignWindow struct {
+	ID         int32   `json:"id"`
+	CampaignID int32   `json:"campaign_id"`
+	Days       []int32 `json:"days"`
+	StartHour  int32   `json:"start_hour"`
+	EndHour    int32   `json:"end_hour"`
+}
+
+type CoinTransaction struct {
+	ID            int32              `json:"id"`
+	UserID        int32              `json:"user_id"`
+	Type          string             `json:"type"`
+	Coins         int32              `json:"coins"`
+	Xp            int32              `json:"xp"`
+	ReservationID pgtype.Int4        `json:"reservation_id"`
+	CampaignID    pgtype.Int4        `json:"campaign_id"`
+	Description   string             `json:"description"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
+type ExternalForecast struct {
+	VersionID     int32              `json:"version_id"`
+	StationID     int32              `json:"station_id"`
+	SlotStart     pgtype.Timestamptz `json:"slot_start"`
+	PredictedLoad int32              `json:"predicted_load"`
+	P10Load       int32              `json:"p10_load"`
+	P90Load       int32              `json:"p90_load"`
+}
+
+type ForecastAccuracy struct {
 	StationID   int32              `json:"station_id"`
-	Date        pgtype.Timestamptz `json:"date"`
-	Hour        string             `json:"hour"`
-	IsGreen     bool               `json:"is_green"`
-	EarnedCoins int32              `json:"earned_coins"`
-	SavedCo2    float64            `json:"saved_co2"`
-	Status      string             `json:"status"`
+	Hour        int32              `json:"hour"`
+	Model       string             `json:"model"`
+	WindowStart pgtype.Timestamptz `json:"window_start"`
+	WindowEnd   pgtype.Timestamptz `json:"window_end"`
+	Samples     int32              `json:"samples"`
+	Mae         float64            `json:"mae"`
+	Bias        float64            `json:"bias"`
+	Mape        float64            `json:"mape"`
+	MapeSamples int32              `json:"mape_samples"`
+	EvaluatedAt pgtype.Timestamptz `json:"evaluated_at"`
+}
+
+type ForecastVersion struct {
+	ID           int32              `json:"id"`
+	Model        string             `json:"model"`
+	Version      string             `json:"version"`
+	TrainedAt    pgtype.Timestamptz `json:"trained_at"`
+	Notes        string             `json:"notes"`
+	CreatedBy    pgtype.Int4        `json:"created_by"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+	RolledBackAt pgtype.Timestamptz `json:"rolled_back_at"`
+}
+
+type Notification struct {
+	ID        int32              `json:"id"`
+	UserID    int32              `json:"user_id"`
+	Type      string             `json:"type"`
+	Title     string             `json:"title"`
+	Body      string             `json:"body"`
+	DedupeKey pgtype.Text        `json:"dedupe_key"`
+	ReadAt    pgtype.Timestamptz `json:"read_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type OperatorSetting struct {
+	OperatorID            int32              `json:"operator_id"`
+	CoinValue             float64            `json:"coin_value"`
+	MaxCoinShare          float64            `json:"max_coin_share"`
+	RefundWindowHours     int32              `json:"refund_window_hours"`
+	LateCancelRefundShare float64            `json:"late_cancel_refund_share"`
+	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+	MaxCombinedDiscount   float64            `json:"max_combined_discount"`
+}
+
+type Reservation struct {
+	ID            int32              `json:"id"`
+	UserID        int32              `json:"user_id"`
+	StationID     int32              `json:"station_id"`
+	Date          pgtype.Timestamptz `json:"date"`
+	Hour          string             `json:"hour"`
+	IsGreen       bool               `json:"is_green"`
+	EarnedCoins   int32              `json:"earned_coins"`
+	SavedCo2      float64            `json:"saved_co2"`
+	Status        string             `json:"status"`
+	CampaignID    pgtype.Int4        `json:"campaign_id"`
+	Price         float64            `json:"price"`
+	RedeemedCoins int32              `json:"redeemed_coins"`
+	CoinDiscount  float64            `json:"coin_discount"`
+	SessionAmount float64            `json:"session_amount"`
+}
+
+type ReservationCampaign struct {
+	ReservationID int32       `json:"reservation_id"`
+	CampaignID    int32       `json:"campaign_id"`
+	Position      int32       `json:"position"`
+	Discount      float64     `json:"discount"`
+	CoinBonus     int32       `json:"coin_bonus"`
+	VariantID     pgtype.Int4 `json:"variant_id"`
+}
+
+type Reward struct {
+	ID                  int32              `json:"id"`
+	OperatorID          int32              `json:"operator_id"`
+	Title               string             `json:"title"`
+	Description         string             `json:"description"`
+	Category            string             `json:"category"`
+	PartnerName         pgtype.Text        `json:"partner_name"`
+	CostCoins           int32              `json:"cost_coins"`
+	Stock               pgtype.Int4        `json:"stock"`
+	PerUserLimit        pgtype.Int4        `json:"per_user_limit"`
+	ValidFrom           pgtype.Timestamptz `json:"valid_from"`
+	ValidUntil          pgtype.Timestamptz `json:"valid_until"`
+	VoucherValidityDays int32              `json:"voucher_validity_days"`
+	IsActive            bool               `json:"is_active"`
+	CreatedAt           pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
+}
+
+type RewardVoucher struct {
+	ID         int32              `json:"id"`
+	RewardID   int32              `json:"reward_id"`
+	UserID     int32              `json:"user_id"`
+	Code       string             `json:"code"`
+	Coins      int32              `json:"coins"`
+	Status     string             `json:"status"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	ConsumedAt pgtype.Timestamptz `json:"consumed_at"`
+	ConsumedBy pgtype.Int4        `json:"consumed_by"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
 }
 
 type Station struct {
@@ -57,6 +241,7 @@ type Station struct {
 	Density        int32       `json:"density"`
 	OwnerID        pgtype.Int4 `json:"owner_id"`
 	DensityProfile string      `json:"density_profile"`
+	ForecastModel  string      `json:"forecast_model"`
 }
 
 type StationDensityForecast struct {
@@ -66,22 +251,60 @@ type StationDensityForecast struct {
 	Hour          int32              `json:"hour"`
 	PredictedLoad int32              `json:"predicted_load"`
 	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	P90Load       int32              `json:"p90_load"`
+	Model         string             `json:"model"`
+	P10Load       int32              `json:"p10_load"`
+}
+
+type StationForecast struct {
+	StationID           int32              `json:"station_id"`
+	SlotStart           pgtype.Timestamptz `json:"slot_start"`
+	PredictedLoad       int32              `json:"predicted_load"`
+	P90Load             int32              `json:"p90_load"`
+	Model               string             `json:"model"`
+	Event               pgtype.Text        `json:"event"`
+	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
+	P10Load             int32              `json:"p10_load"`
+	Temperature         pgtype.Float8      `json:"temperature"`
+	Precipitation       pgtype.Float8      `json:"precipitation"`
+	TemperatureEffect   pgtype.Float8      `json:"temperature_effect"`
+	PrecipitationEffect pgtype.Float8      `json:"precipitation_effect"`
+}
+
+type StationOccupancy struct {
+	StationID  int32              `json:"station_id"`
+	ObservedAt pgtype.Timestamptz `json:"observed_at"`
+	Load       int32              `json:"load"`
+	Source     string             `json:"source"`
 }
 
 type User struct {
-	ID        int32              `json:"id"`
-	Name      string             `json:"name"`
-	Email     string             `json:"email"`
-	Password  string             `json:"password"`
-	Role      string             `json:"role"`
-	Coins     int32              `json:"coins"`
-	Co2Saved  float64            `json:"co2_saved"`
-	Xp        int32              `json:"xp"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	ID          int32              `json:"id"`
+	Name        string             `json:"name"`
+	Email       string             `json:"email"`
+	Password    string             `json:"password"`
+	Role        string             `json:"role"`
+	Coins       int32              `json:"coins"`
+	Co2Saved    float64            `json:"co2_saved"`
+	Xp          int32              `json:"xp"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	VehicleType pgtype.Text        `json:"vehicle_type"`
 }
 
 type UserBadge struct {
-	UserID  int32 `json:"user_id"`
-	BadgeID int32 `json:"badge_id"`
+	UserID   int32              `json:"user_id"`
+	BadgeID  int32              `json:"badge_id"`
+	EarnedAt pgtype.Timestamptz `json:"earned_at"`
+}
+
+type WeatherHour struct {
+	ID            int32              `json:"id"`
+	StationID     pgtype.Int4        `json:"station_id"`
+	ObservedAt    pgtype.Timestamptz `json:"observed_at"`
+	Temperature   float64            `json:"temperature"`
+	Precipitation float64            `json:"precipitation"`
+	IsForecast    bool               `json:"is_forecast"`
+	Source        string             `json:"source"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
 }
@@ -11,20 +11,50 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const cancelReservation = `-- name: CancelReservation :one
+UPDATE reservations
+SET status = 'CANCELLED'
+WHERE id = $1 AND status NOT IN ('CANCELLED', 'COMPLETED')
+RETURNING id, user_id, station_id, date, hour, is_green, earned_coins, saved_co2, status, campaign_id, price, redeemed_coins, coin_discount, session_amount
+`
+
+func (q *Queries) CancelReservation(ctx context.Context, id int32) (Reservation, error) {
+	row := q.db.QueryRow(ctx, cancelReservation, id)
+	var i Reservation
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.StationID,
+		&i.Date,
+		&i.Hour,
+		&i.IsGreen,
+		&i.EarnedCoins,
+		&i.SavedCo2,
+		&i.Status,
+		&i.CampaignID,
+		&i.Price,
+		&i.RedeemedCoins,
+		&i.CoinDiscount,
+		&i.SessionAmount,
+	)
+	return i, err
+}
+
 const completeReservation = `-- name: CompleteReservation :one
 UPDATE reservations
-SET status = 'COMPLETED', earned_coins = $2
-WHERE id = $1
-RETURNING id, user_id, station_id, date, hour, is_green, earned_coins, saved_co2, status
+SET status = 'COMPLETED', earned_coins = $2, saved_co2 = $3
+WHERE id = $1 AND status NOT IN ('COMPLETED', 'CANCELLED')
+RETURNING id, user_id, station_id, date, hour, is_green, earned_coins, saved_co2, status, campaign_id, price, redeemed_coins, coin_discount, session_amount
 `
 
 type CompleteReservationParams struct {
-	ID          int32 `json:"id"`
-	EarnedCoins int32 `json:"earned_coins"`
+	ID          int32   `json:"id"`
+	EarnedCoins int32   `json:"earned_coins"`
+	SavedCo2    float64 `json:"saved_co2"`
 }
 
 func (q *Queries) CompleteReservation(ctx context.Context, arg CompleteReservationParams) (Reservation, error) {
-	row := q.db.QueryRow(ctx, completeReservation, arg.ID, arg.EarnedCoins)
+	row := q.db.QueryRow(ctx, completeReservation, arg.ID, arg.EarnedCoins, arg.SavedCo2)
 	var i Reservation
 	err := row.Scan(
 		&i.ID,
@@ -36,23 +66,34 @@ func (q *Queries) CompleteReservation(ctx context.Context, arg CompleteReservati
 		&i.EarnedCoins,
 		&i.SavedCo2,
 		&i.Status,
+		&i.CampaignID,
+		&i.Price,
+		&i.RedeemedCoins,
+		&i.CoinDiscount,
+		&i.SessionAmount,
 	)
 	return i, err
 }
 
 const createReservation = `-- name: CreateReservation :one
-INSERT INTO reservations (user_id, station_id, date, hour, is_green, earned_coins, status)
-VALUES ($1, $2, $3, $4, $5, $6, 'PENDING')
-RETURNING id, user_id, station_id, date, hour, is_green, earned_coins, saved_co2, status
+INSERT INTO reservations (user_id, station_id, date, hour, is_green, earned_coins, campaign_id,
+                          price, redeemed_coins, coin_discount, session_amount, status)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, 'PENDING')
+RETURNING id, user_id, station_id, date, hour, is_green, earned_coins, saved_co2, status, campaign_id, price, redeemed_coins, coin_discount, session_amount
 `
 
 type CreateReservationParams struct {
-	UserID      int32              `json:"user_id"`
-	StationID   int32              `json:"station_id"`
-	Date        pgtype.Timestamptz `json:"date"`
-	Hour        string             `json:"hour"`
-	IsGreen     bool               `json:"is_green"`
-	EarnedCoins int32              `json:"earned_coins"`
+	UserID        int32              `json:"user_id"`
+	StationID     int32              `json:"station_id"`
+	Date          pgtype.Timestamptz `json:"date"`
+	Hour          string             `json:"hour"`
+	IsGreen       bool               `json:"is_green"`
+	EarnedCoins   int32              `json:"earned_coins"`
+	CampaignID    pgtype.Int4        `json:"campaign_id"`
+	Price         float64            `json:"price"`
+	RedeemedCoins int32              `json:"redeemed_coins"`
+	CoinDiscount  float64            `json:"coin_discount"`
+	SessionAmount float64            `json:"session_amount"`
 }
 
 func (q *Queries) CreateReservation(ctx context.Context, arg CreateReservationParams) (Reservation, error) {
@@ -63,6 +104,11 @@ func (q *Queries) CreateReservation(ctx context.Context, arg CreateReservationPa
 		arg.Hour,
 		arg.IsGreen,
 		arg.EarnedCoins,
+		arg.CampaignID,
+		arg.Price,
+		arg.RedeemedCoins,
+		arg.CoinDiscount,
+		arg.SessionAmount,
 	)
 	var i Reservation
 	err := row.Scan(
@@ -75,12 +121,17 @@ func (q *Queries) CreateReservation(ctx context.Context, arg CreateReservationPa
 		&i.EarnedCoins,
 		&i.SavedCo2,
 		&i.Status,
+		&i.CampaignID,
+		&i.Price,
+		&i.RedeemedCoins,
+		&i.CoinDiscount,
+		&i.SessionAmount,
 	)
 	return i, err
 }
 
 const getReservationByID = `-- name: GetReservationByID :one
-SELECT id, user_id, station_id, date, hour, is_green, earned_coins, saved_co2, status FROM reservations WHERE id = $1
+SELECT id, user_id, station_id, date, hour, is_green, earned_coins, saved_co2, status, campaign_id, price, redeemed_coins, coin_discount, session_amount FROM reservations WHERE id = $1
 `
 
 func (q *Queries) GetReservationByID(ctx context.Context, id int32) (Reservation, error) {
@@ -96,6 +147,11 @@ func (q *Queries) GetReservationByID(ctx context.Context, id int32) (Reservation
 		&i.EarnedCoins,
 		&i.SavedCo2,
 		&i.Status,
+		&i.CampaignID,
+		&i.Price,
+		&i.RedeemedCoins,
+		&i.CoinDiscount,
+		&i.SessionAmount,
 	)
 	return i, err
 }
@@ -136,8 +192,52 @@ func (q *Queries) GetStationRevenue(ctx context.Context, stationID int32) (float
 	return revenue, err
 }
 
+const getUserPeriodSummary = `-- name: GetUserPeriodSummary :one
+SELECT
+    COUNT(*)::int AS sessions,
+    COUNT(*) FILTER (WHERE r.is_green = true)::int AS green_sessions,
+    COALESCE(SUM(r.earned_coins), 0)::int AS coins_earned,
+    COALESCE(SUM(r.saved_co2), 0)::double precision AS co2_saved,
+    COALESCE(SUM(r.session_amount - r.coin_discount), 0)::double precision AS amount_spent,
+    COALESCE(SUM(r.redeemed_coins), 0)::int AS coins_redeemed
+FROM reservations r
+WHERE r.user_id = $1
+  AND r.status = 'COMPLETED'
+  AND r.date >= $2
+  AND r.date < $3
+`
+
+type GetUserPeriodSummaryParams struct {
+	UserID      int32              `json:"user_id"`
+	PeriodStart pgtype.Timestamptz `json:"period_start"`
+	PeriodEnd   pgtype.Timestamptz `json:"period_end"`
+}
+
+type GetUserPeriodSummaryRow struct {
+	Sessions      int32   `json:"sessions"`
+	GreenSessions int32   `json:"green_sessions"`
+	CoinsEarned   int32   `json:"coins_earned"`
+	Co2Saved      float64 `json:"co2_saved"`
+	AmountSpent   float64 `json:"amount_spent"`
+	CoinsRedeemed int32   `json:"coins_redeemed"`
+}
+
+func (q *Queries) GetUserPeriodSummary(ctx context.Context, arg GetUserPeriodSummaryParams) (GetUserPeriodSummaryRow, error) {
+	row := q.db.QueryRow(ctx, getUserPeriodSummary, arg.UserID, arg.PeriodStart, arg.PeriodEnd)
+	var i GetUserPeriodSummaryRow
+	err := row.Scan(
+		&i.Sessions,
+		&i.GreenSessions,
+		&i.CoinsEarned,
+		&i.Co2Saved,
+		&i.AmountSpent,
+		&i.CoinsRedeemed,
+	)
+	return i, err
+}
+
 const listReservationsByStation = `-- name: ListReservationsByStation :many
-SELECT id, user_id, station_id, date, hour, is_green, earned_coins, saved_co2, status FROM reservations
+SELECT id, user_id, station_id, date, hour, is_green, earned_coins, saved_co2, status, campaign_id, price, redeemed_coins, coin_discount, session_amount FROM reservations
 WHERE station_id = $1
 ORDER BY id DESC
 `
@@ -161,6 +261,11 @@ func (q *Queries) ListReservationsByStation(ctx context.Context, stationID int32
 			&i.EarnedCoins,
 			&i.SavedCo2,
 			&i.Status,
+			&i.CampaignID,
+			&i.Price,
+			&i.RedeemedCoins,
+			&i.CoinDiscount,
+			&i.SessionAmount,
 		); err != nil {
 			return nil, err
 		}
@@ -172,31 +277,69 @@ func (q *Queries) ListReservationsByStation(ctx context.Context, stationID int32
 	return items, nil
 }
 
-const updateReservationStatus = `-- name: UpdateReservationStatus :one
-UPDATE reservations
-SET status = $2
-WHERE id = $1
-RETURNING id, user_id, station_id, date, hour, is_green, earned_coins, saved_co2, status
+const listUserCompletedReservations = `-- name: ListUserCompletedReservations :many
+SELECT r.id, r.date, r.hour, r.is_green, r.earned_coins, r.saved_co2,
+       r.price, r.redeemed_coins, r.coin_discount, r.session_amount,
+       s.id AS station_id, s.name AS station_name
+FROM reservations r
+JOIN stations s ON s.id = r.station_id
+WHERE r.user_id = $1
+  AND r.status = 'COMPLETED'
+  AND r.date >= $2
+  AND r.date < $3
+ORDER BY r.date ASC, r.id ASC
 `
 
-type UpdateReservationStatusParams struct {
-	ID     int32  `json:"id"`
-	Status string `json:"status"`
+type ListUserCompletedReservationsParams struct {
+	UserID      int32              `json:"user_id"`
+	PeriodStart pgtype.Timestamptz `json:"period_start"`
+	PeriodEnd   pgtype.Timestamptz `json:"period_end"`
+}
+
+type ListUserCompletedReservationsRow struct {
+	ID            int32              `json:"id"`
+	Date          pgtype.Timestamptz `json:"date"`
+	Hour          string             `json:"hour"`
+	IsGreen       bool               `json:"is_green"`
+	EarnedCoins   int32              `json:"earned_coins"`
+	SavedCo2      float64            `json:"saved_co2"`
+	Price         float64            `json:"price"`
+	RedeemedCoins int32              `json:"redeemed_coins"`
+	CoinDiscount  float64            `json:"coin_discount"`
+	SessionAmount float64            `json:"session_amount"`
+	StationID     int32              `json:"station_id"`
+	StationName   string             `json:"station_name"`
 }
 
-func (q *Queries) UpdateReservationStatus(ctx context.Context, arg UpdateReservationStatusParams) (Reservation, error) {
-	row := q.db.QueryRow(ctx, updateReservationStatus, arg.ID, arg.Status)
-	var i Reservation
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.StationID,
-		&i.Date,
-		&i.Hour,
-		&i.IsGreen,
-		&i.EarnedCoins,
-		&i.SavedCo2,
-		&i.Status,
-	)
-	return i, err
+func (q *Queries) ListUserCompletedReservations(ctx context.Context, arg ListUserCompletedReservationsParams) ([]ListUserCompletedReservationsRow, error) {
+	rows, err := q.db.Query(ctx, listUserCompletedReservations, arg.UserID, arg.PeriodStart, arg.PeriodEnd)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUserCompletedReservationsRow{}
+	for rows.Next() {
+		var i ListUserCompletedReservationsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Date,
+			&i.Hour,
+			&i.IsGreen,
+			&i.EarnedCoins,
+			&i.SavedCo2,
+			&i.Price,
+			&i.RedeemedCoins,
+			&i.CoinDiscount,
+			&i.SessionAmount,
+			&i.StationID,
+			&i.StationName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
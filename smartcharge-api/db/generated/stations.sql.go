@@ -14,7 +14,7 @@ import (
 const createStation = `-- name: CreateStation :one
 INSERT INTO stations (name, lat, lng, address, price, owner_id, density_profile)
 VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, name, lat, lng, address, price, density, owner_id, density_profile
+RETURNING id, name, lat, lng, address, price, density, owner_id, density_profile, forecast_model
 `
 
 type CreateStationParams struct {
@@ -48,6 +48,7 @@ func (q *Queries) CreateStation(ctx context.Context, arg CreateStationParams) (S
 		&i.Density,
 		&i.OwnerID,
 		&i.DensityProfile,
+		&i.ForecastModel,
 	)
 	return i, err
 }
@@ -62,7 +63,7 @@ func (q *Queries) DeleteStation(ctx context.Context, id int32) error {
 }
 
 const getStationByID = `-- name: GetStationByID :one
-SELECT id, name, lat, lng, address, price, density, owner_id, density_profile FROM stations WHERE id = $1
+SELECT id, name, lat, lng, address, price, density, owner_id, density_profile, forecast_model FROM stations WHERE id = $1
 `
 
 func (q *Queries) GetStationByID(ctx context.Context, id int32) (Station, error) {
@@ -78,13 +79,14 @@ func (q *Queries) GetStationByID(ctx context.Context, id int32) (Station, error)
 		&i.Density,
 		&i.OwnerID,
 		&i.DensityProfile,
+		&i.ForecastModel,
 	)
 	return i, err
 }
 
 const listStations = `-- name: ListStations :many
 SELECT s.id, s.name, s.lat, s.lng, s.price, s.density, s.owner_id, s.address, s.density_profile,
-       u.name AS owner_name
+       s.forecast_model, u.name AS owner_name
 FROM stations s
 LEFT JOIN users u ON u.id = s.owner_id
 ORDER BY s.id ASC
@@ -100,6 +102,7 @@ type ListStationsRow struct {
 	OwnerID        pgtype.Int4 `json:"owner_id"`
 	Address        pgtype.Text `json:"address"`
 	DensityProfile string      `json:"density_profile"`
+	ForecastModel  string      `json:"forecast_model"`
 	OwnerName      pgtype.Text `json:"owner_name"`
 }
 
@@ -122,6 +125,7 @@ func (q *Queries) ListStations(ctx context.Context) ([]ListStationsRow, error) {
 			&i.OwnerID,
 			&i.Address,
 			&i.DensityProfile,
+			&i.ForecastModel,
 			&i.OwnerName,
 		); err != nil {
 			return nil, err
@@ -135,7 +139,7 @@ func (q *Queries) ListStations(ctx context.Context) ([]ListStationsRow, error) {
 }
 
 const listStationsByOwner = `-- name: ListStationsByOwner :many
-SELECT s.id, s.name, s.lat, s.lng, s.address, s.price, s.density, s.density_profile, s.owner_id
+SELECT s.id, s.name, s.lat, s.lng, s.address, s.price, s.density, s.density_profile, s.owner_id, s.forecast_model
 FROM stations s
 WHERE s.owner_id = $1
 ORDER BY s.id ASC
@@ -151,6 +155,7 @@ type ListStationsByOwnerRow struct {
 	Density        int32       `json:"density"`
 	DensityProfile string      `json:"density_profile"`
 	OwnerID        pgtype.Int4 `json:"owner_id"`
+	ForecastModel  string      `json:"forecast_model"`
 }
 
 func (q *Queries) ListStationsByOwner(ctx context.Context, ownerID pgtype.Int4) ([]ListStationsByOwnerRow, error) {
@@ -172,6 +177,7 @@ func (q *Queries) ListStationsByOwner(ctx context.Context, ownerID pgtype.Int4)
 			&i.Density,
 			&i.DensityProfile,
 			&i.OwnerID,
+			&i.ForecastModel,
 		); err != nil {
 			return nil, err
 		}
@@ -191,7 +197,7 @@ SET name = COALESCE($2, name),
     address = COALESCE($5, address),
     price = COALESCE($6, price)
 WHERE id = $1
-RETURNING id, name, lat, lng, address, price, density, owner_id, density_profile
+RETURNING id, name, lat, lng, address, price, density, owner_id, density_profile, forecast_model
 `
 
 type UpdateStationParams struct {
@@ -223,6 +229,7 @@ func (q *Queries) UpdateStation(ctx context.Context, arg UpdateStationParams) (S
 		&i.Density,
 		&i.OwnerID,
 		&i.DensityProfile,
+		&i.ForecastModel,
 	)
 	return i, err
 }
@@ -240,3 +247,17 @@ func (q *Queries) UpdateStationDensity(ctx context.Context, arg UpdateStationDen
 	_, err := q.db.Exec(ctx, updateStationDensity, arg.ID, arg.Density)
 	return err
 }
+
+const updateStationForecastModel = `-- name: UpdateStationForecastModel :exec
+UPDATE stations SET forecast_model = $2 WHERE id = $1
+`
+
+type UpdateStationForecastModelParams struct {
+	ID            int32  `json:"id"`
+	ForecastModel string `json:"forecast_model"`
+}
+
+func (q *Queries) UpdateStationForecastModel(ctx context.Context, arg UpdateStationForecastModelParams) error {
+	_, err := q.db.Exec(ctx, updateStationForecastModel, arg.ID, arg.ForecastModel)
+	return err
+}
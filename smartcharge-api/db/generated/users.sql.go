@@ -27,10 +27,52 @@ func (q *Queries) AddUserBadge(ctx context.Context, arg AddUserBadgeParams) erro
 	return err
 }
 
+const addUserCo2Saved = `-- name: AddUserCo2Saved :one
+UPDATE users
+SET co2_saved = co2_saved + $2, updated_at = NOW()
+WHERE id = $1
+RETURNING id, name, email, password, role, coins, co2_saved, xp, created_at, updated_at, vehicle_type
+`
+
+type AddUserCo2SavedParams struct {
+	ID       int32   `json:"id"`
+	Co2Saved float64 `json:"co2_saved"`
+}
+
+func (q *Queries) AddUserCo2Saved(ctx context.Context, arg AddUserCo2SavedParams) (User, error) {
+	row := q.db.QueryRow(ctx, addUserCo2Saved, arg.ID, arg.Co2Saved)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.Password,
+		&i.Role,
+		&i.Coins,
+		&i.Co2Saved,
+		&i.Xp,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.VehicleType,
+	)
+	return i, err
+}
+
+const countUserCompletedReservations = `-- name: CountUserCompletedReservations :one
+SELECT COUNT(*) FROM reservations WHERE user_id = $1 AND status = 'COMPLETED'
+`
+
+func (q *Queries) CountUserCompletedReservations(ctx context.Context, userID int32) (int64, error) {
+	row := q.db.QueryRow(ctx, countUserCompletedReservations, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (name, email, password, role)
 VALUES ($1, $2, $3, $4)
-RETURNING id, name, email, password, role, coins, co2_saved, xp, created_at, updated_at
+RETURNING id, name, email, password, role, coins, co2_saved, xp, created_at, updated_at, vehicle_type
 `
 
 type CreateUserParams struct {
@@ -59,6 +101,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Xp,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VehicleType,
 	)
 	return i, err
 }
@@ -120,7 +163,7 @@ func (q *Queries) GetLeaderboard(ctx context.Context, limit int32) ([]GetLeaderb
 }
 
 const getUserBadges = `-- name: GetUserBadges :many
-SELECT b.id, b.name, b.description, b.icon
+SELECT b.id, b.name, b.description, b.icon, b.icon_url, b.retired_at
 FROM badges b
 JOIN user_badges ub ON ub.badge_id = b.id
 WHERE ub.user_id = $1
@@ -141,6 +184,8 @@ func (q *Queries) GetUserBadges(ctx context.Context, userID int32) ([]Badge, err
 			&i.Name,
 			&i.Description,
 			&i.Icon,
+			&i.IconUrl,
+			&i.RetiredAt,
 		); err != nil {
 			return nil, err
 		}
@@ -153,7 +198,7 @@ func (q *Queries) GetUserBadges(ctx context.Context, userID int32) ([]Badge, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, name, email, password, role, coins, co2_saved, xp, created_at, updated_at FROM users WHERE email = $1
+SELECT id, name, email, password, role, coins, co2_saved, xp, created_at, updated_at, vehicle_type FROM users WHERE email = $1
 `
 
 func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
@@ -170,12 +215,13 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.Xp,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VehicleType,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, name, email, password, role, coins, co2_saved, xp, created_at, updated_at FROM users WHERE id = $1
+SELECT id, name, email, password, role, coins, co2_saved, xp, created_at, updated_at, vehicle_type FROM users WHERE id = $1
 `
 
 func (q *Queries) GetUserByID(ctx context.Context, id int32) (User, error) {
@@ -192,6 +238,7 @@ func (q *Queries) GetUserByID(ctx context.Context, id int32) (User, error) {
 		&i.Xp,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VehicleType,
 	)
 	return i, err
 }
@@ -296,55 +343,26 @@ func (q *Queries) GetUserStations(ctx context.Context, ownerID pgtype.Int4) ([]G
 
 const updateUserProfile = `-- name: UpdateUserProfile :one
 UPDATE users
-SET name = $2, email = $3, updated_at = NOW()
+SET name = $2, email = $3,
+    vehicle_type = COALESCE($4, vehicle_type),
+    updated_at = NOW()
 WHERE id = $1
-RETURNING id, name, email, password, role, coins, co2_saved, xp, created_at, updated_at
+RETURNING id, name, email, password, role, coins, co2_saved, xp, created_at, updated_at, vehicle_type
 `
 
 type UpdateUserProfileParams struct {
-	ID    int32  `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	ID          int32       `json:"id"`
+	Name        string      `json:"name"`
+	Email       string      `json:"email"`
+	VehicleType pgtype.Text `json:"vehicle_type"`
 }
 
 func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (User, error) {
-	row := q.db.QueryRow(ctx, updateUserProfile, arg.ID, arg.Name, arg.Email)
-	var i User
-	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.Email,
-		&i.Password,
-		&i.Role,
-		&i.Coins,
-		&i.Co2Saved,
-		&i.Xp,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
-}
-
-const updateUserStats = `-- name: UpdateUserStats :one
-UPDATE users
-SET coins = coins + $2, co2_saved = co2_saved + $3, xp = xp + $4, updated_at = NOW()
-WHERE id = $1
-RETURNING id, name, email, password, role, coins, co2_saved, xp, created_at, updated_at
-`
-
-type UpdateUserStatsParams struct {
-	ID       int32   `json:"id"`
-	Coins    int32   `json:"coins"`
-	Co2Saved float64 `json:"co2_saved"`
-	Xp       int32   `json:"xp"`
-}
-
-func (q *Queries) UpdateUserStats(ctx context.Context, arg UpdateUserStatsParams) (User, error) {
-	row := q.db.QueryRow(ctx, updateUserStats,
+	row := q.db.QueryRow(ctx, updateUserProfile,
 		arg.ID,
-		arg.Coins,
-		arg.Co2Saved,
-		arg.Xp,
+		arg.Name,
+		arg.Email,
+		arg.VehicleType,
 	)
 	var i User
 	err := row.Scan(
@@ -358,6 +376,7 @@ func (q *Queries) UpdateUserStats(ctx context.Context, arg UpdateUserStatsParams
 		&i.Xp,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VehicleType,
 	)
 	return i, err
 }
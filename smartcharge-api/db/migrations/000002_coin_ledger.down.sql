@@ -0,0 +1,9 @@
+-- 000002_coin_ledger.down.sql
+-- Rollback: Drop the coin ledger (users.coins / users.xp keep their last derived values)
+
+DROP INDEX IF EXISTS idx_reservations_campaign_id;
+DROP INDEX IF EXISTS idx_coin_transactions_user_id;
+
+DROP TABLE IF EXISTS coin_transactions;
+
+ALTER TABLE reservations DROP COLUMN IF EXISTS campaign_id;
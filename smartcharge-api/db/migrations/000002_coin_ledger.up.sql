@@ -0,0 +1,26 @@
+-- 000002_coin_ledger.up.sql
+-- Append-only SmartCoin/XP ledger. users.coins and users.xp are derived from it.
+
+ALTER TABLE reservations
+    ADD COLUMN IF NOT EXISTS campaign_id INT REFERENCES campaigns(id) ON DELETE SET NULL;
+
+CREATE TABLE IF NOT EXISTS coin_transactions (
+    id             SERIAL PRIMARY KEY,
+    user_id        INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type           VARCHAR(20) NOT NULL,
+    coins          INT NOT NULL DEFAULT 0,
+    xp             INT NOT NULL DEFAULT 0,
+    reservation_id INT REFERENCES reservations(id) ON DELETE SET NULL,
+    campaign_id    INT REFERENCES campaigns(id) ON DELETE SET NULL,
+    description    TEXT NOT NULL DEFAULT '',
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_coin_transactions_user_id ON coin_transactions(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_reservations_campaign_id ON reservations(campaign_id);
+
+-- Opening balances, so existing users keep their coins/XP once balances are derived from the ledger
+INSERT INTO coin_transactions (user_id, type, coins, xp, description)
+SELECT id, 'ADJUSTMENT', coins, xp, 'Opening balance'
+FROM users
+WHERE coins <> 0 OR xp <> 0;
@@ -0,0 +1,9 @@
+-- 000003_coin_redemption.down.sql
+-- Rollback: Drop coin redemption settings and reservation pricing columns
+
+ALTER TABLE reservations
+    DROP COLUMN IF EXISTS coin_discount,
+    DROP COLUMN IF EXISTS redeemed_coins,
+    DROP COLUMN IF EXISTS price;
+
+DROP TABLE IF EXISTS operator_settings;
@@ -0,0 +1,22 @@
+-- 000003_coin_redemption.up.sql
+-- SmartCoin redemption at checkout: per-operator coin policy + reservation pricing columns
+
+CREATE TABLE IF NOT EXISTS operator_settings (
+    operator_id              INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    coin_value               DOUBLE PRECISION NOT NULL DEFAULT 0.1 CHECK (coin_value > 0),
+    max_coin_share           DOUBLE PRECISION NOT NULL DEFAULT 0.5 CHECK (max_coin_share >= 0 AND max_coin_share <= 1),
+    refund_window_hours      INT NOT NULL DEFAULT 2 CHECK (refund_window_hours >= 0),
+    late_cancel_refund_share DOUBLE PRECISION NOT NULL DEFAULT 0.5 CHECK (late_cancel_refund_share >= 0 AND late_cancel_refund_share <= 1),
+    updated_at               TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE reservations
+    ADD COLUMN IF NOT EXISTS price          DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS redeemed_coins INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS coin_discount  DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+-- Backfill prices for existing reservations using the green-hour rule
+UPDATE reservations r
+SET price = CASE WHEN r.is_green THEN s.price * 0.8 ELSE s.price END
+FROM stations s
+WHERE s.id = r.station_id;
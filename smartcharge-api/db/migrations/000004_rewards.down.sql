@@ -0,0 +1,5 @@
+-- 000004_rewards.down.sql
+-- Rollback: Drop rewards catalog and vouchers
+
+DROP TABLE IF EXISTS reward_vouchers;
+DROP TABLE IF EXISTS rewards;
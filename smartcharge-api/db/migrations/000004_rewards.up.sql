@@ -0,0 +1,38 @@
+-- 000004_rewards.up.sql
+-- Rewards catalog purchasable with SmartCoins, and the vouchers issued for them
+
+CREATE TABLE IF NOT EXISTS rewards (
+    id                    SERIAL PRIMARY KEY,
+    operator_id           INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title                 VARCHAR(255) NOT NULL,
+    description           TEXT NOT NULL DEFAULT '',
+    category              VARCHAR(20) NOT NULL DEFAULT 'OTHER', -- FREE_SESSION, PARTNER, MERCH, OTHER
+    partner_name          VARCHAR(255),
+    cost_coins            INT NOT NULL CHECK (cost_coins > 0),
+    stock                 INT CHECK (stock >= 0),               -- NULL = unlimited
+    per_user_limit        INT CHECK (per_user_limit > 0),       -- NULL = unlimited
+    valid_from            TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    valid_until           TIMESTAMPTZ,
+    voucher_validity_days INT NOT NULL DEFAULT 30 CHECK (voucher_validity_days > 0),
+    is_active             BOOLEAN NOT NULL DEFAULT true,
+    created_at            TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at            TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_rewards_operator ON rewards(operator_id);
+
+CREATE TABLE IF NOT EXISTS reward_vouchers (
+    id          SERIAL PRIMARY KEY,
+    reward_id   INT NOT NULL REFERENCES rewards(id) ON DELETE CASCADE,
+    user_id     INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code        VARCHAR(32) NOT NULL UNIQUE,
+    coins       INT NOT NULL,
+    status      VARCHAR(20) NOT NULL DEFAULT 'ISSUED', -- ISSUED, CONSUMED
+    expires_at  TIMESTAMPTZ NOT NULL,
+    consumed_at TIMESTAMPTZ,
+    consumed_by INT REFERENCES users(id) ON DELETE SET NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_reward_vouchers_user ON reward_vouchers(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_reward_vouchers_reward_user ON reward_vouchers(reward_id, user_id);
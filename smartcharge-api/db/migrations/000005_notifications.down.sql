@@ -0,0 +1,4 @@
+-- 000005_notifications.down.sql
+-- Rollback: Drop notifications
+
+DROP TABLE IF EXISTS notifications;
@@ -0,0 +1,15 @@
+-- 000005_notifications.up.sql
+-- In-app notifications (coin expiry warnings etc.). dedupe_key keeps scheduled jobs from repeating a message.
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id         SERIAL PRIMARY KEY,
+    user_id    INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type       VARCHAR(40) NOT NULL,
+    title      VARCHAR(255) NOT NULL,
+    body       TEXT NOT NULL DEFAULT '',
+    dedupe_key VARCHAR(255) UNIQUE,
+    read_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user ON notifications(user_id, created_at DESC);
@@ -0,0 +1,7 @@
+-- 000006_badge_rules.down.sql
+-- Rollback: Drop badge rules and earned_at
+
+ALTER TABLE user_badges
+    DROP COLUMN IF EXISTS earned_at;
+
+DROP TABLE IF EXISTS badge_rules;
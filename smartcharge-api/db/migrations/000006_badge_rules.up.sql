@@ -0,0 +1,24 @@
+-- 000006_badge_rules.up.sql
+-- Machine-readable badge rules (criteria) for the awarding engine, and when each badge was earned
+
+CREATE TABLE IF NOT EXISTS badge_rules (
+    badge_id  INT PRIMARY KEY REFERENCES badges(id) ON DELETE CASCADE,
+    metric    VARCHAR(40) NOT NULL,
+    threshold DOUBLE PRECISION NOT NULL CHECK (threshold > 0)
+);
+
+ALTER TABLE user_badges
+    ADD COLUMN IF NOT EXISTS earned_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+-- Rules for the seeded badges
+INSERT INTO badge_rules (badge_id, metric, threshold)
+SELECT b.id, c.metric, c.threshold
+FROM badges b
+JOIN (VALUES
+    ('Gece Kuşu', 'NIGHT_SESSIONS', 5),
+    ('Eco Şampiyonu', 'CO2_SAVED', 50),
+    ('Hafta Sonu Savaşçısı', 'WEEKEND_SESSIONS', 5),
+    ('Erken Kalkan', 'MORNING_SESSIONS', 5),
+    ('Uzun Yolcu', 'OUTSKIRT_SESSIONS', 3)
+) AS c(name, metric, threshold) ON c.name = b.name
+ON CONFLICT (badge_id) DO NOTHING;
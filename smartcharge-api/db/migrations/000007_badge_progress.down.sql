@@ -0,0 +1,4 @@
+-- 000007_badge_progress.down.sql
+-- Rollback: Drop badge progress
+
+DROP TABLE IF EXISTS badge_progress;
@@ -0,0 +1,11 @@
+-- 000007_badge_progress.up.sql
+-- Per-user progress towards each badge rule, refreshed by the badge engine
+
+CREATE TABLE IF NOT EXISTS badge_progress (
+    user_id       INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    badge_id      INT NOT NULL REFERENCES badges(id) ON DELETE CASCADE,
+    current_value DOUBLE PRECISION NOT NULL DEFAULT 0,
+    target        DOUBLE PRECISION NOT NULL,
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, badge_id)
+);
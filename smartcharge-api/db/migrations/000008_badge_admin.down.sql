@@ -0,0 +1,8 @@
+-- 000008_badge_admin.down.sql
+-- Rollback: Drop badge translations and admin columns
+
+DROP TABLE IF EXISTS badge_translations;
+
+ALTER TABLE badges
+    DROP COLUMN IF EXISTS retired_at,
+    DROP COLUMN IF EXISTS icon_url;
@@ -0,0 +1,14 @@
+-- 000008_badge_admin.up.sql
+-- Admin-managed badges: uploaded icons, retirement and localized copy
+
+ALTER TABLE badges
+    ADD COLUMN IF NOT EXISTS icon_url   VARCHAR(500),
+    ADD COLUMN IF NOT EXISTS retired_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS badge_translations (
+    badge_id    INT          NOT NULL REFERENCES badges(id) ON DELETE CASCADE,
+    locale      VARCHAR(10)  NOT NULL,
+    name        VARCHAR(255) NOT NULL,
+    description TEXT         NOT NULL,
+    PRIMARY KEY (badge_id, locale)
+);
@@ -0,0 +1,9 @@
+-- 000009_campaign_eligibility.down.sql
+-- Rollback: Drop campaign eligibility columns
+
+ALTER TABLE campaigns
+    DROP COLUMN IF EXISTS vehicle_types,
+    DROP COLUMN IF EXISTS new_customers_only;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS vehicle_type;
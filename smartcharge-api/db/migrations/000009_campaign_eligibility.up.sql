@@ -0,0 +1,9 @@
+-- 000009_campaign_eligibility.up.sql
+-- Campaign eligibility beyond badges: vehicle type and new-customer targeting
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS vehicle_type VARCHAR(30);
+
+ALTER TABLE campaigns
+    ADD COLUMN IF NOT EXISTS new_customers_only BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS vehicle_types      TEXT[]  NOT NULL DEFAULT '{}';
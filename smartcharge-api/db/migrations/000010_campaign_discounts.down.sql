@@ -0,0 +1,21 @@
+-- 000010_campaign_discounts.down.sql
+-- Rollback: Restore free-text campaign discounts from the typed model
+
+ALTER TABLE campaigns
+    DROP CONSTRAINT IF EXISTS campaigns_discount_value_check,
+    DROP CONSTRAINT IF EXISTS campaigns_discount_type_check,
+    ADD COLUMN IF NOT EXISTS discount VARCHAR(100) NOT NULL DEFAULT '';
+
+UPDATE campaigns
+SET discount = CASE discount_type
+    WHEN 'PERCENTAGE'      THEN '%' || discount_value::text
+    WHEN 'PER_KWH'         THEN '₺' || discount_value::text || '/kWh'
+    WHEN 'PER_SESSION'     THEN '₺' || discount_value::text
+    WHEN 'FREE_HOURS'      THEN 'İlk ' || discount_value::text || ' saat ücretsiz'
+    WHEN 'COIN_MULTIPLIER' THEN discount_value::text || 'x Coin'
+    ELSE ''
+END;
+
+ALTER TABLE campaigns
+    DROP COLUMN IF EXISTS discount_value,
+    DROP COLUMN IF EXISTS discount_type;
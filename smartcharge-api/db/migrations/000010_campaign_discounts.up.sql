@@ -0,0 +1,55 @@
+-- 000010_campaign_discounts.up.sql
+-- Replace free-text campaign discounts ("%20", "2x Coin") with a typed discount model
+
+ALTER TABLE campaigns
+    ADD COLUMN IF NOT EXISTS discount_type  VARCHAR(20)      NOT NULL DEFAULT 'NONE',
+    ADD COLUMN IF NOT EXISTS discount_value DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+-- Percentages: "%20", "20%", "% 20"
+UPDATE campaigns
+SET discount_type = 'PERCENTAGE',
+    discount_value = replace(substring(discount FROM '[0-9]+(?:[.,][0-9]+)?'), ',', '.')::double precision
+WHERE discount ~ '^\s*%\s*[0-9]+([.,][0-9]+)?\s*$'
+   OR discount ~ '^\s*[0-9]+([.,][0-9]+)?\s*%\s*$';
+
+-- Coin multipliers: "2x Coin", "3X"
+UPDATE campaigns
+SET discount_type = 'COIN_MULTIPLIER',
+    discount_value = replace(substring(discount FROM '[0-9]+(?:[.,][0-9]+)?'), ',', '.')::double precision
+WHERE discount ~* '^\s*[0-9]+([.,][0-9]+)?\s*x(\s|$)';
+
+-- Fixed amount per kWh: "₺2/kWh", "2 TL/kWh"
+UPDATE campaigns
+SET discount_type = 'PER_KWH',
+    discount_value = replace(substring(discount FROM '[0-9]+(?:[.,][0-9]+)?'), ',', '.')::double precision
+WHERE discount ~* '^\s*₺?\s*[0-9]+([.,][0-9]+)?\s*(₺|tl)?\s*/\s*kwh\s*$';
+
+-- Free hours: "İlk saat ücretsiz", "İlk 2 saat ücretsiz", "first hour free"
+UPDATE campaigns
+SET discount_type = 'FREE_HOURS',
+    discount_value = COALESCE(substring(discount FROM '[0-9]+')::double precision, 1)
+WHERE discount_type = 'NONE'
+  AND (discount ~* 'ücretsiz' OR discount ~* 'free');
+
+-- Anything else would be lost with the free-text column, so stop and name it instead
+DO $$
+DECLARE
+    unparsed TEXT;
+BEGIN
+    SELECT string_agg(format('#%s %L', id, discount), ', ' ORDER BY id)
+    INTO unparsed
+    FROM campaigns
+    WHERE discount_type = 'NONE' AND btrim(discount) <> '';
+
+    IF unparsed IS NOT NULL THEN
+        RAISE EXCEPTION 'campaign discounts that could not be converted: %', unparsed
+            USING HINT = 'Rewrite them as e.g. "%20", "2x Coin", "₺2/kWh" or "İlk saat ücretsiz", or clear them, then rerun the migration.';
+    END IF;
+END $$;
+
+ALTER TABLE campaigns
+    DROP COLUMN IF EXISTS discount,
+    ADD CONSTRAINT campaigns_discount_type_check
+        CHECK (discount_type IN ('NONE', 'PERCENTAGE', 'PER_KWH', 'PER_SESSION', 'FREE_HOURS', 'COIN_MULTIPLIER')),
+    ADD CONSTRAINT campaigns_discount_value_check
+        CHECK (discount_value >= 0);
@@ -0,0 +1,12 @@
+-- 000011_campaign_stacking.down.sql
+-- Rollback: Drop campaign stacking
+
+DROP TABLE IF EXISTS reservation_campaigns;
+
+ALTER TABLE operator_settings
+    DROP COLUMN IF EXISTS max_combined_discount;
+
+ALTER TABLE campaigns
+    DROP COLUMN IF EXISTS stackable,
+    DROP COLUMN IF EXISTS exclusive,
+    DROP COLUMN IF EXISTS priority;
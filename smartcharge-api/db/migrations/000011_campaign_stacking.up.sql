@@ -0,0 +1,31 @@
+-- 000011_campaign_stacking.up.sql
+-- Campaign priority, exclusivity and stacking, with a per-operator cap on the combined discount
+
+ALTER TABLE campaigns
+    ADD COLUMN IF NOT EXISTS priority  INT     NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS exclusive BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS stackable BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE operator_settings
+    ADD COLUMN IF NOT EXISTS max_combined_discount DOUBLE PRECISION NOT NULL DEFAULT 0.4
+        CHECK (max_combined_discount >= 0 AND max_combined_discount <= 1);
+
+-- Every campaign applied to a reservation, with what it contributed
+CREATE TABLE IF NOT EXISTS reservation_campaigns (
+    reservation_id INT NOT NULL REFERENCES reservations(id) ON DELETE CASCADE,
+    campaign_id    INT NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    position       INT NOT NULL DEFAULT 0,
+    discount       DOUBLE PRECISION NOT NULL DEFAULT 0,
+    coin_bonus     INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (reservation_id, campaign_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reservation_campaigns_campaign ON reservation_campaigns(campaign_id);
+
+-- Backfill from the single campaign recorded on existing reservations
+INSERT INTO reservation_campaigns (reservation_id, campaign_id, position, discount, coin_bonus)
+SELECT r.id, r.campaign_id, 0, 0,
+       GREATEST(r.earned_coins - CASE WHEN r.is_green THEN 50 ELSE 10 END, 0)
+FROM reservations r
+WHERE r.campaign_id IS NOT NULL
+ON CONFLICT DO NOTHING;
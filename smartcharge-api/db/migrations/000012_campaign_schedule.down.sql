@@ -0,0 +1,8 @@
+-- 000012_campaign_schedule.down.sql
+-- Rollback: Drop campaign scheduling
+
+DROP TABLE IF EXISTS campaign_windows;
+
+ALTER TABLE campaigns
+    DROP COLUMN IF EXISTS timezone,
+    DROP COLUMN IF EXISTS start_date;
@@ -0,0 +1,25 @@
+-- 000012_campaign_schedule.up.sql
+-- Campaign start dates, recurring weekly windows and time zones
+
+ALTER TABLE campaigns
+    ADD COLUMN IF NOT EXISTS start_date TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS timezone   VARCHAR(64) NOT NULL DEFAULT 'Europe/Istanbul';
+
+-- Weekly hours a campaign applies in, evaluated in the campaign's time zone.
+-- A campaign without windows applies around the clock.
+CREATE TABLE IF NOT EXISTS campaign_windows (
+    id          SERIAL PRIMARY KEY,
+    campaign_id INT NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    -- 0=Monday … 6=Sunday, like station_density_forecasts; empty means every day
+    days        INT[] NOT NULL DEFAULT '{}',
+    start_hour  INT NOT NULL CHECK (start_hour >= 0 AND start_hour <= 23),
+    -- Exclusive; a window ending before it starts runs past midnight
+    end_hour    INT NOT NULL CHECK (end_hour >= 1 AND end_hour <= 24),
+    CHECK (start_hour <> end_hour)
+);
+
+CREATE INDEX IF NOT EXISTS idx_campaign_windows_campaign ON campaign_windows(campaign_id);
+
+-- Campaigns that already ran out stop showing as active
+UPDATE campaigns SET status = 'ENDED', updated_at = NOW()
+WHERE status = 'ACTIVE' AND end_date IS NOT NULL AND end_date < NOW();
@@ -0,0 +1,14 @@
+-- 000013_campaign_budgets.down.sql
+-- Rollback: Drop campaign budgets
+
+UPDATE campaigns SET status = 'ACTIVE' WHERE status = 'PAUSED';
+
+ALTER TABLE campaigns
+    DROP COLUMN IF EXISTS paused_reason,
+    DROP COLUMN IF EXISTS redemptions,
+    DROP COLUMN IF EXISTS discount_spent,
+    DROP COLUMN IF EXISTS coins_spent,
+    DROP COLUMN IF EXISTS max_redemptions_per_user,
+    DROP COLUMN IF EXISTS max_redemptions,
+    DROP COLUMN IF EXISTS discount_budget,
+    DROP COLUMN IF EXISTS coin_budget;
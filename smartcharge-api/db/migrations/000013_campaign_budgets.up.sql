@@ -0,0 +1,28 @@
+-- 000013_campaign_budgets.up.sql
+-- Per-campaign budgets and redemption caps, with running totals kept alongside
+
+ALTER TABLE campaigns
+    -- Caps; NULL means unlimited
+    ADD COLUMN IF NOT EXISTS coin_budget              INT CHECK (coin_budget >= 0),
+    ADD COLUMN IF NOT EXISTS discount_budget          DOUBLE PRECISION CHECK (discount_budget >= 0),
+    ADD COLUMN IF NOT EXISTS max_redemptions          INT CHECK (max_redemptions >= 0),
+    ADD COLUMN IF NOT EXISTS max_redemptions_per_user INT CHECK (max_redemptions_per_user >= 0),
+    -- Consumed by non-cancelled reservations
+    ADD COLUMN IF NOT EXISTS coins_spent    INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS discount_spent DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS redemptions    INT NOT NULL DEFAULT 0,
+    -- Why a PAUSED campaign was paused automatically
+    ADD COLUMN IF NOT EXISTS paused_reason VARCHAR(30);
+
+-- Start the running totals from what existing reservations already used.
+-- reservation_campaigns.discount is per kWh; the discount budget is in ₺ per 20 kWh session (pricing.SessionEnergyKWh)
+UPDATE campaigns c
+SET coins_spent = t.coins, discount_spent = t.discount, redemptions = t.uses
+FROM (
+    SELECT rc.campaign_id, SUM(rc.coin_bonus)::int AS coins, SUM(rc.discount) * 20 AS discount, COUNT(*)::int AS uses
+    FROM reservation_campaigns rc
+    JOIN reservations r ON r.id = rc.reservation_id
+    WHERE r.status <> 'CANCELLED'
+    GROUP BY rc.campaign_id
+) t
+WHERE t.campaign_id = c.id;
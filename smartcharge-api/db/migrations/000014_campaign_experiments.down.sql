@@ -0,0 +1,8 @@
+-- 000014_campaign_experiments.down.sql
+-- Rollback: Drop campaign experiments
+
+ALTER TABLE reservation_campaigns
+    DROP COLUMN IF EXISTS variant_id;
+
+DROP TABLE IF EXISTS campaign_exposures;
+DROP TABLE IF EXISTS campaign_variants;
@@ -0,0 +1,31 @@
+-- 000014_campaign_experiments.up.sql
+-- A/B variants of a campaign, with exposure and conversion tracking
+
+-- Each logged-in driver is assigned one variant of the campaign, by hash, in proportion to weight.
+-- A variant replaces the campaign's discount and coin reward for the drivers assigned to it.
+CREATE TABLE IF NOT EXISTS campaign_variants (
+    id             SERIAL PRIMARY KEY,
+    campaign_id    INT NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    name           VARCHAR(50) NOT NULL,
+    weight         INT NOT NULL DEFAULT 1 CHECK (weight > 0),
+    is_control     BOOLEAN NOT NULL DEFAULT FALSE,
+    discount_type  VARCHAR(20) NOT NULL DEFAULT 'NONE',
+    discount_value DOUBLE PRECISION NOT NULL DEFAULT 0,
+    coin_reward    INT NOT NULL DEFAULT 0,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (campaign_id, name)
+);
+
+-- First time a driver was shown their variant
+CREATE TABLE IF NOT EXISTS campaign_exposures (
+    variant_id INT NOT NULL REFERENCES campaign_variants(id) ON DELETE CASCADE,
+    user_id    INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    exposed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (variant_id, user_id)
+);
+
+-- Conversions: the variant a booking was priced with
+ALTER TABLE reservation_campaigns
+    ADD COLUMN IF NOT EXISTS variant_id INT REFERENCES campaign_variants(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_reservation_campaigns_variant ON reservation_campaigns(variant_id);
@@ -0,0 +1,3 @@
+-- 000015_station_occupancy.down.sql
+
+DROP TABLE IF EXISTS station_occupancy;
@@ -0,0 +1,11 @@
+-- 000015_station_occupancy.up.sql
+-- Observed station occupancy, the history forecasts are trained on
+
+-- One reading per station per hour; observed_at is the start of the hour
+CREATE TABLE IF NOT EXISTS station_occupancy (
+    station_id  INT NOT NULL REFERENCES stations(id) ON DELETE CASCADE,
+    observed_at TIMESTAMPTZ NOT NULL,
+    load        INT NOT NULL CHECK (load >= 0 AND load <= 100),
+    source      VARCHAR(20) NOT NULL DEFAULT 'OPERATOR',
+    PRIMARY KEY (station_id, observed_at)
+);
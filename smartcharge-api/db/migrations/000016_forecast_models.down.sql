@@ -0,0 +1,7 @@
+-- 000016_forecast_models.down.sql
+
+ALTER TABLE station_density_forecasts
+    DROP COLUMN IF EXISTS model,
+    DROP COLUMN IF EXISTS p90_load;
+
+ALTER TABLE stations DROP COLUMN IF EXISTS forecast_model;
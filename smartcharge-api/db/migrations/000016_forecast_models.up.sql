@@ -0,0 +1,12 @@
+-- 000016_forecast_models.up.sql
+-- Per-station choice of forecasting model, and a P90 alongside each forecast
+
+-- One of the models in internal/forecast: linear, holt-winters or quantile
+ALTER TABLE stations ADD COLUMN IF NOT EXISTS forecast_model VARCHAR(20) NOT NULL DEFAULT 'linear';
+
+-- predicted_load is the median (P50); p90_load is the load exceeded only one week in ten
+ALTER TABLE station_density_forecasts
+    ADD COLUMN IF NOT EXISTS p90_load INT NOT NULL DEFAULT 0 CHECK (p90_load >= 0 AND p90_load <= 100),
+    ADD COLUMN IF NOT EXISTS model    VARCHAR(20) NOT NULL DEFAULT 'linear';
+
+UPDATE station_density_forecasts SET p90_load = predicted_load;
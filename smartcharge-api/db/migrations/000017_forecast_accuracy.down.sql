@@ -0,0 +1,3 @@
+-- 000017_forecast_accuracy.down.sql
+
+DROP TABLE IF EXISTS forecast_accuracy;
@@ -0,0 +1,20 @@
+-- 000017_forecast_accuracy.up.sql
+-- How well each station's forecast did on its last week, per hour of day
+
+-- Refreshed by the forecast-accuracy job: the station's model is trained on the history before
+-- window_start and its P50 scored against the actual load from window_start to window_end.
+-- Errors are in load points; mape only covers the mape_samples hours with a non-zero load.
+CREATE TABLE IF NOT EXISTS forecast_accuracy (
+    station_id   INT NOT NULL REFERENCES stations(id) ON DELETE CASCADE,
+    hour         INT NOT NULL CHECK (hour >= 0 AND hour <= 23),
+    model        VARCHAR(20) NOT NULL,
+    window_start TIMESTAMPTZ NOT NULL,
+    window_end   TIMESTAMPTZ NOT NULL,
+    samples      INT NOT NULL DEFAULT 0,
+    mae          DOUBLE PRECISION NOT NULL DEFAULT 0,
+    bias         DOUBLE PRECISION NOT NULL DEFAULT 0,
+    mape         DOUBLE PRECISION NOT NULL DEFAULT 0,
+    mape_samples INT NOT NULL DEFAULT 0,
+    evaluated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (station_id, hour)
+);
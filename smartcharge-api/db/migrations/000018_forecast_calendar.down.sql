@@ -0,0 +1,4 @@
+-- 000018_forecast_calendar.down.sql
+
+DROP TABLE IF EXISTS station_forecasts;
+DROP TABLE IF EXISTS calendar_events;
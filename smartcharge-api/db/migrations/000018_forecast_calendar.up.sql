@@ -0,0 +1,36 @@
+-- 000018_forecast_calendar.up.sql
+-- Forecasts for concrete dates, and the holiday and event calendar they take into account
+
+-- Holidays and special events. A NULL station_id applies to every station (public holidays);
+-- otherwise it is an operator's annotation for their station, such as a concert next door.
+-- impact is the expected change in load, in points; NULL lets forecasts learn it from past events.
+CREATE TABLE IF NOT EXISTS calendar_events (
+    id         SERIAL PRIMARY KEY,
+    name       VARCHAR(100) NOT NULL,
+    kind       VARCHAR(20) NOT NULL DEFAULT 'EVENT' CHECK (kind IN ('HOLIDAY', 'EVENT')),
+    starts_at  TIMESTAMPTZ NOT NULL,
+    ends_at    TIMESTAMPTZ NOT NULL,
+    station_id INT REFERENCES stations(id) ON DELETE CASCADE,
+    impact     INT CHECK (impact >= -100 AND impact <= 100),
+    created_by INT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_calendar_events_time ON calendar_events(starts_at, ends_at);
+
+-- Re-importing a calendar updates its events instead of duplicating them
+CREATE UNIQUE INDEX IF NOT EXISTS idx_calendar_events_global ON calendar_events(name, starts_at) WHERE station_id IS NULL;
+
+-- Predicted load per station for each hour of the rolling forecast horizon.
+-- event names the calendar event the hour was adjusted for, if any.
+CREATE TABLE IF NOT EXISTS station_forecasts (
+    station_id     INT NOT NULL REFERENCES stations(id) ON DELETE CASCADE,
+    slot_start     TIMESTAMPTZ NOT NULL,
+    predicted_load INT NOT NULL CHECK (predicted_load >= 0 AND predicted_load <= 100),
+    p90_load       INT NOT NULL CHECK (p90_load >= 0 AND p90_load <= 100),
+    model          VARCHAR(20) NOT NULL,
+    event          VARCHAR(100),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (station_id, slot_start)
+);
@@ -0,0 +1,4 @@
+-- 000019_forecast_p10.down.sql
+
+ALTER TABLE station_forecasts DROP COLUMN IF EXISTS p10_load;
+ALTER TABLE station_density_forecasts DROP COLUMN IF EXISTS p10_load;
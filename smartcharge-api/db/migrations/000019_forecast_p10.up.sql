@@ -0,0 +1,11 @@
+-- 000019_forecast_p10.up.sql
+-- A P10 alongside each forecast, so P10–P90 bounds the load eight weeks in ten
+
+ALTER TABLE station_density_forecasts
+    ADD COLUMN IF NOT EXISTS p10_load INT NOT NULL DEFAULT 0 CHECK (p10_load >= 0 AND p10_load <= 100);
+
+ALTER TABLE station_forecasts
+    ADD COLUMN IF NOT EXISTS p10_load INT NOT NULL DEFAULT 0 CHECK (p10_load >= 0 AND p10_load <= 100);
+
+UPDATE station_density_forecasts SET p10_load = predicted_load;
+UPDATE station_forecasts SET p10_load = predicted_load;
@@ -0,0 +1,9 @@
+-- 000020_weather.down.sql
+
+ALTER TABLE station_forecasts
+    DROP COLUMN IF EXISTS precipitation_effect,
+    DROP COLUMN IF EXISTS temperature_effect,
+    DROP COLUMN IF EXISTS precipitation,
+    DROP COLUMN IF EXISTS temperature;
+
+DROP TABLE IF EXISTS weather_hours;
@@ -0,0 +1,26 @@
+-- 000020_weather.up.sql
+-- Hourly weather that load forecasts use as regressors, and its effect on each dated forecast
+
+-- Observed and forecast weather per hour. A NULL station_id is the area-wide weather every station uses;
+-- a station's own rows, where present, take precedence. Forecast rows are replaced by observations
+-- of the same hour, never the other way round.
+CREATE TABLE IF NOT EXISTS weather_hours (
+    id            SERIAL PRIMARY KEY,
+    station_id    INT REFERENCES stations(id) ON DELETE CASCADE,
+    observed_at   TIMESTAMPTZ NOT NULL,
+    temperature   DOUBLE PRECISION NOT NULL,
+    precipitation DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (precipitation >= 0),
+    is_forecast   BOOLEAN NOT NULL DEFAULT FALSE,
+    source        VARCHAR(20) NOT NULL,
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_weather_hours_area ON weather_hours(observed_at) WHERE station_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_weather_hours_station ON weather_hours(station_id, observed_at) WHERE station_id IS NOT NULL;
+
+-- The weather a dated forecast was adjusted for and its effect in load points; NULL when it was unknown
+ALTER TABLE station_forecasts
+    ADD COLUMN IF NOT EXISTS temperature DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS precipitation DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS temperature_effect DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS precipitation_effect DOUBLE PRECISION;
@@ -0,0 +1,4 @@
+-- 000021_forecast_versions.down.sql
+
+DROP TABLE IF EXISTS external_forecasts;
+DROP TABLE IF EXISTS forecast_versions;
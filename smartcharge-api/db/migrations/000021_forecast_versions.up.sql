@@ -0,0 +1,31 @@
+-- 000021_forecast_versions.up.sql
+-- Forecasts trained outside the API (by the data science team) and pushed back in, kept per model version
+
+-- One upload of externally trained forecasts. Uploading the same model and version again adds to it.
+-- A rolled back version is kept but ignored, so the version before it applies again.
+CREATE TABLE IF NOT EXISTS forecast_versions (
+    id             SERIAL PRIMARY KEY,
+    model          VARCHAR(50) NOT NULL,
+    version        VARCHAR(50) NOT NULL,
+    trained_at     TIMESTAMPTZ,
+    notes          TEXT NOT NULL DEFAULT '',
+    created_by     INT REFERENCES users(id) ON DELETE SET NULL,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    rolled_back_at TIMESTAMPTZ,
+    UNIQUE (model, version)
+);
+
+-- Hourly predictions of a version. Where several versions cover an hour, the latest uploaded
+-- that isn't rolled back takes precedence over the API's own dated forecast.
+CREATE TABLE IF NOT EXISTS external_forecasts (
+    version_id     INT NOT NULL REFERENCES forecast_versions(id) ON DELETE CASCADE,
+    station_id     INT NOT NULL REFERENCES stations(id) ON DELETE CASCADE,
+    slot_start     TIMESTAMPTZ NOT NULL,
+    predicted_load INT NOT NULL CHECK (predicted_load >= 0 AND predicted_load <= 100),
+    p10_load       INT NOT NULL CHECK (p10_load >= 0 AND p10_load <= predicted_load),
+    p90_load       INT NOT NULL CHECK (p90_load >= predicted_load AND p90_load <= 100),
+    PRIMARY KEY (version_id, station_id, slot_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_external_forecasts_station ON external_forecasts(station_id, slot_start);
@@ -0,0 +1,3 @@
+-- 000022_reservation_session_amount.down.sql
+
+ALTER TABLE reservations DROP COLUMN IF EXISTS session_amount;
@@ -0,0 +1,8 @@
+-- 000022_reservation_session_amount.up.sql
+-- What the booked session cost, fixed at booking time so statements don't depend on today's rates
+
+ALTER TABLE reservations
+    ADD COLUMN IF NOT EXISTS session_amount DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+-- Backfill from the booked per-kWh rate over an average 20 kWh session (pricing.SessionEnergyKWh)
+UPDATE reservations SET session_amount = ROUND((price * 20)::numeric, 2);
@@ -0,0 +1,3 @@
+-- 000023_coin_transactions_campaign.down.sql
+
+DROP INDEX IF EXISTS idx_coin_transactions_campaign_id;
@@ -0,0 +1,5 @@
+-- 000023_coin_transactions_campaign.up.sql
+-- Finds the holders of a campaign's grants without scanning the whole ledger
+
+CREATE INDEX IF NOT EXISTS idx_coin_transactions_campaign_id
+    ON coin_transactions(campaign_id) WHERE campaign_id IS NOT NULL;
@@ -1,5 +1,8 @@
 -- name: ListBadges :many
-SELECT * FROM badges ORDER BY name ASC;
+SELECT * FROM badges WHERE retired_at IS NULL ORDER BY name ASC;
+
+-- name: ListAllBadges :many
+SELECT * FROM badges ORDER BY id ASC;
 
 -- name: GetBadgeByID :one
 SELECT * FROM badges WHERE id = $1;
@@ -8,3 +11,108 @@ SELECT * FROM badges WHERE id = $1;
 INSERT INTO badges (name, description, icon)
 VALUES ($1, $2, $3)
 RETURNING *;
+
+-- name: UpdateBadge :one
+UPDATE badges
+SET name = $2, description = $3, icon = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: SetBadgeIconURL :one
+UPDATE badges SET icon_url = $2 WHERE id = $1
+RETURNING *;
+
+-- name: RetireBadge :one
+UPDATE badges SET retired_at = COALESCE(retired_at, NOW())
+WHERE id = $1
+RETURNING *;
+
+-- name: ListBadgeRules :many
+SELECT b.id, b.name, b.description, b.icon, bc.metric, bc.threshold
+FROM badge_rules bc
+JOIN badges b ON b.id = bc.badge_id
+WHERE b.retired_at IS NULL
+ORDER BY b.id;
+
+-- name: ListAllBadgeRules :many
+SELECT * FROM badge_rules ORDER BY badge_id;
+
+-- name: GetBadgeRule :one
+SELECT * FROM badge_rules WHERE badge_id = $1;
+
+-- name: DeleteBadgeRule :exec
+DELETE FROM badge_rules WHERE badge_id = $1;
+
+-- name: ListUserBadgeIDs :many
+SELECT badge_id FROM user_badges WHERE user_id = $1;
+
+-- name: AwardUserBadge :execrows
+INSERT INTO user_badges (user_id, badge_id, earned_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT DO NOTHING;
+
+-- name: GetUserBadgeMetrics :one
+SELECT
+    COUNT(r.id)::int AS completed_sessions,
+    COUNT(r.id) FILTER (WHERE r.is_green)::int AS green_sessions,
+    COUNT(r.id) FILTER (
+        WHERE split_part(r.hour, ':', 1)::int >= 23 OR split_part(r.hour, ':', 1)::int <= 6
+    )::int AS night_sessions,
+    COUNT(r.id) FILTER (
+        WHERE split_part(r.hour, ':', 1)::int BETWEEN 6 AND 8
+    )::int AS morning_sessions,
+    COUNT(r.id) FILTER (WHERE EXTRACT(ISODOW FROM r.date) >= 6)::int AS weekend_sessions,
+    COUNT(r.id) FILTER (WHERE s.density_profile = 'outskirt')::int AS outskirt_sessions,
+    COUNT(DISTINCT r.station_id)::int AS distinct_stations,
+    (SELECT u.co2_saved FROM users u WHERE u.id = $1)::double precision AS co2_saved
+FROM reservations r
+JOIN stations s ON s.id = r.station_id
+WHERE r.user_id = $1
+  AND r.status = 'COMPLETED';
+
+-- name: ListUserIDsWithCompletedReservations :many
+SELECT DISTINCT user_id FROM reservations WHERE status = 'COMPLETED' ORDER BY user_id;
+
+-- name: UpsertBadgeRule :one
+INSERT INTO badge_rules (badge_id, metric, threshold)
+VALUES ($1, $2, $3)
+ON CONFLICT (badge_id) DO UPDATE
+SET metric = EXCLUDED.metric, threshold = EXCLUDED.threshold
+RETURNING *;
+
+-- name: UpsertBadgeProgress :exec
+INSERT INTO badge_progress (user_id, badge_id, current_value, target, updated_at)
+VALUES ($1, $2, $3, $4, NOW())
+ON CONFLICT (user_id, badge_id) DO UPDATE
+SET current_value = EXCLUDED.current_value,
+    target = EXCLUDED.target,
+    updated_at = NOW();
+
+-- name: ListUserBadgeProgress :many
+SELECT b.id AS badge_id, b.name, b.description, b.icon, b.icon_url, br.metric, br.threshold,
+       bp.current_value, bp.updated_at AS progress_updated_at, ub.earned_at
+FROM badge_rules br
+JOIN badges b ON b.id = br.badge_id
+LEFT JOIN badge_progress bp ON bp.badge_id = br.badge_id AND bp.user_id = $1
+LEFT JOIN user_badges ub ON ub.badge_id = br.badge_id AND ub.user_id = $1
+WHERE b.retired_at IS NULL
+ORDER BY b.name ASC;
+
+-- name: ListBadgeTranslations :many
+SELECT * FROM badge_translations ORDER BY badge_id, locale;
+
+-- name: ListBadgeTranslationsByBadge :many
+SELECT * FROM badge_translations WHERE badge_id = $1 ORDER BY locale;
+
+-- name: ListBadgeTranslationsByLocale :many
+SELECT * FROM badge_translations WHERE locale = $1;
+
+-- name: UpsertBadgeTranslation :one
+INSERT INTO badge_translations (badge_id, locale, name, description)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (badge_id, locale) DO UPDATE
+SET name = EXCLUDED.name, description = EXCLUDED.description
+RETURNING *;
+
+-- name: DeleteBadgeTranslation :execrows
+DELETE FROM badge_translations WHERE badge_id = $1 AND locale = $2;
@@ -0,0 +1,24 @@
+-- name: ListCalendarEvents :many
+SELECT * FROM calendar_events
+WHERE (station_id IS NULL OR station_id = sqlc.narg(station_id))
+  AND ends_at > sqlc.arg(window_start)
+  AND starts_at < sqlc.arg(window_end)
+ORDER BY starts_at, id;
+
+-- name: GetCalendarEvent :one
+SELECT * FROM calendar_events WHERE id = $1;
+
+-- name: CreateCalendarEvent :one
+INSERT INTO calendar_events (name, kind, starts_at, ends_at, station_id, impact, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: ImportCalendarEvents :execrows
+INSERT INTO calendar_events (name, kind, starts_at, ends_at, created_by)
+SELECT unnest(sqlc.arg(names)::text[]), sqlc.arg(kind), unnest(sqlc.arg(starts_at)::timestamptz[]),
+       unnest(sqlc.arg(ends_at)::timestamptz[]), sqlc.narg(created_by)
+ON CONFLICT (name, starts_at) WHERE station_id IS NULL
+DO UPDATE SET kind = EXCLUDED.kind, ends_at = EXCLUDED.ends_at;
+
+-- name: DeleteCalendarEvent :exec
+DELETE FROM calendar_events WHERE id = $1;
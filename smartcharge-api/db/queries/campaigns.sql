@@ -1,7 +1,5 @@
 -- name: ListCampaignsByOwner :many
-SELECT c.id, c.title, c.description, c.status, c.target, c.discount,
-       c.end_date, c.owner_id, c.station_id, c.coin_reward, c.created_at, c.updated_at,
-       s.name AS station_name
+SELECT c.*, s.name AS station_name
 FROM campaigns c
 LEFT JOIN stations s ON s.id = c.station_id
 WHERE c.owner_id = $1
@@ -10,15 +8,26 @@ ORDER BY c.created_at DESC;
 -- name: GetCampaignByID :one
 SELECT * FROM campaigns WHERE id = $1;
 
+-- name: GetCampaignForUpdate :one
+SELECT * FROM campaigns WHERE id = $1 FOR UPDATE;
+
 -- name: CreateCampaign :one
-INSERT INTO campaigns (title, description, status, target, discount, end_date, owner_id, station_id, coin_reward)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+INSERT INTO campaigns (title, description, status, target, discount_type, discount_value, end_date, owner_id,
+                       station_id, coin_reward, new_customers_only, vehicle_types, priority, exclusive, stackable,
+                       start_date, timezone, coin_budget, discount_budget, max_redemptions, max_redemptions_per_user)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
 RETURNING *;
 
 -- name: UpdateCampaign :one
 UPDATE campaigns
-SET title = $2, description = $3, status = $4, target = $5, discount = $6,
-    end_date = $7, station_id = $8, coin_reward = $9, updated_at = NOW()
+SET title = $2, description = $3, status = $4, target = $5, discount_type = $6, discount_value = $7,
+    end_date = $8, station_id = $9, coin_reward = $10,
+    new_customers_only = $11, vehicle_types = $12,
+    priority = $13, exclusive = $14, stackable = $15,
+    start_date = $16, timezone = $17,
+    coin_budget = $18, discount_budget = $19, max_redemptions = $20, max_redemptions_per_user = $21,
+    paused_reason = CASE WHEN $4 = 'PAUSED' THEN paused_reason END,
+    updated_at = NOW()
 WHERE id = $1
 RETURNING *;
 
@@ -27,18 +36,21 @@ DELETE FROM campaigns WHERE id = $1;
 
 -- name: GetActiveCampaignsForStation :many
 SELECT * FROM campaigns
-WHERE status = 'ACTIVE'
-  AND (end_date IS NULL OR end_date >= NOW())
-  AND (station_id = $1 OR station_id IS NULL)
-ORDER BY created_at DESC;
+WHERE (status = 'ACTIVE' OR (status = 'DRAFT' AND start_date IS NOT NULL))
+  AND (start_date IS NULL OR start_date < sqlc.arg(window_end))
+  AND (end_date IS NULL OR end_date >= sqlc.arg(window_start))
+  AND (station_id = sqlc.arg(station_id) OR station_id IS NULL)
+ORDER BY priority DESC, (station_id IS NOT NULL) DESC, created_at DESC;
 
 -- name: ListActiveCampaigns :many
 SELECT * FROM campaigns
 WHERE status = 'ACTIVE'
+  AND (start_date IS NULL OR start_date <= NOW())
+  AND (end_date IS NULL OR end_date >= NOW())
 ORDER BY coin_reward DESC;
 
 -- name: GetCampaignTargetBadges :many
-SELECT b.id, b.name, b.description, b.icon
+SELECT b.id, b.name, b.description, b.icon, b.icon_url, b.retired_at
 FROM badges b
 JOIN campaign_target_badges ctb ON ctb.badge_id = b.id
 WHERE ctb.campaign_id = $1;
@@ -48,5 +60,138 @@ INSERT INTO campaign_target_badges (campaign_id, badge_id)
 VALUES ($1, $2)
 ON CONFLICT DO NOTHING;
 
+-- name: AddCampaignTargetBadges :many
+INSERT INTO campaign_target_badges (campaign_id, badge_id)
+SELECT sqlc.arg(campaign_id), b.id
+FROM badges b
+WHERE b.id = ANY(sqlc.arg(badge_ids)::int[])
+ON CONFLICT DO NOTHING
+RETURNING badge_id;
+
 -- name: RemoveCampaignTargetBadges :exec
 DELETE FROM campaign_target_badges WHERE campaign_id = $1;
+
+-- name: ConsumeCampaignBudget :one
+UPDATE campaigns
+SET redemptions = redemptions + 1,
+    coins_spent = coins_spent + sqlc.arg(coins),
+    discount_spent = discount_spent + sqlc.arg(discount),
+    updated_at = NOW()
+WHERE id = $1
+  AND (status = 'ACTIVE' OR (status = 'DRAFT' AND start_date IS NOT NULL))
+  AND (max_redemptions IS NULL OR redemptions < max_redemptions)
+  AND (coin_budget IS NULL OR coins_spent + sqlc.arg(coins) <= coin_budget)
+  AND (discount_budget IS NULL OR discount_spent + sqlc.arg(discount) <= discount_budget)
+RETURNING *;
+
+-- name: ReleaseCampaignBudget :exec
+UPDATE campaigns
+SET redemptions = GREATEST(redemptions - 1, 0),
+    coins_spent = GREATEST(coins_spent - sqlc.arg(coins), 0),
+    discount_spent = GREATEST(discount_spent - sqlc.arg(discount), 0),
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: PauseCampaign :exec
+UPDATE campaigns
+SET status = 'PAUSED', paused_reason = $2, updated_at = NOW()
+WHERE id = $1 AND status IN ('ACTIVE', 'DRAFT');
+
+-- name: CountUserCampaignRedemptions :one
+SELECT COUNT(*) FROM reservation_campaigns rc
+JOIN reservations r ON r.id = rc.reservation_id
+WHERE r.user_id = $1
+  AND rc.campaign_id = $2
+  AND r.status <> 'CANCELLED';
+
+-- name: ListUserCampaignUsage :many
+SELECT rc.campaign_id, COUNT(*) AS uses
+FROM reservation_campaigns rc
+JOIN reservations r ON r.id = rc.reservation_id
+WHERE r.user_id = $1
+  AND r.status <> 'CANCELLED'
+GROUP BY rc.campaign_id;
+
+-- name: ListCampaignWindows :many
+SELECT * FROM campaign_windows
+WHERE campaign_id = $1
+ORDER BY id;
+
+-- name: AddCampaignWindow :exec
+INSERT INTO campaign_windows (campaign_id, days, start_hour, end_hour)
+VALUES ($1, $2, $3, $4);
+
+-- name: RemoveCampaignWindows :exec
+DELETE FROM campaign_windows WHERE campaign_id = $1;
+
+-- name: ActivateScheduledCampaigns :execrows
+UPDATE campaigns
+SET status = 'ACTIVE', updated_at = NOW()
+WHERE status = 'DRAFT'
+  AND start_date IS NOT NULL
+  AND start_date <= NOW()
+  AND (end_date IS NULL OR end_date >= NOW());
+
+-- name: EndExpiredCampaigns :execrows
+UPDATE campaigns
+SET status = 'ENDED', updated_at = NOW()
+WHERE status IN ('DRAFT', 'ACTIVE', 'PAUSED')
+  AND end_date IS NOT NULL
+  AND end_date < NOW();
+
+-- name: GetCampaignAttribution :one
+SELECT
+    COUNT(*)::int AS reservations,
+    COUNT(*) FILTER (WHERE r.status = 'COMPLETED')::int AS completed_reservations,
+    COUNT(*) FILTER (WHERE r.is_green)::int AS green_reservations,
+    COUNT(DISTINCT r.user_id)::int AS unique_drivers,
+    COALESCE(SUM(rc.coin_bonus), 0)::int AS coin_bonus,
+    COALESCE(SUM(rc.discount), 0)::float8 AS discount
+FROM reservation_campaigns rc
+JOIN reservations r ON r.id = rc.reservation_id
+WHERE rc.campaign_id = $1
+  AND r.status <> 'CANCELLED';
+
+-- name: GetStationActivity :one
+SELECT
+    COUNT(*)::int AS reservations,
+    COUNT(*) FILTER (WHERE r.is_green)::int AS green_reservations
+FROM reservations r
+JOIN stations s ON s.id = r.station_id
+WHERE (sqlc.narg(station_id)::int IS NULL OR r.station_id = sqlc.narg(station_id))
+  AND s.owner_id = sqlc.arg(owner_id)
+  AND r.status <> 'CANCELLED'
+  AND r.date >= sqlc.arg(period_start)
+  AND r.date < sqlc.arg(period_end);
+
+-- name: ListCampaignVariants :many
+SELECT * FROM campaign_variants
+WHERE campaign_id = $1
+ORDER BY id;
+
+-- name: CreateCampaignVariant :one
+INSERT INTO campaign_variants (campaign_id, name, weight, is_control, discount_type, discount_value, coin_reward)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: DeleteCampaignVariants :exec
+DELETE FROM campaign_variants WHERE campaign_id = $1;
+
+-- name: RecordCampaignExposure :exec
+INSERT INTO campaign_exposures (variant_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: ListCampaignVariantStats :many
+SELECT
+    v.id, v.name, v.weight, v.is_control, v.discount_type, v.discount_value, v.coin_reward,
+    (SELECT COUNT(*) FROM campaign_exposures e WHERE e.variant_id = v.id)::int AS exposures,
+    COUNT(DISTINCT r.user_id)::int AS converted_users,
+    COUNT(r.id)::int AS reservations,
+    COUNT(r.id) FILTER (WHERE r.is_green)::int AS green_reservations
+FROM campaign_variants v
+LEFT JOIN reservation_campaigns rc ON rc.variant_id = v.id
+LEFT JOIN reservations r ON r.id = rc.reservation_id AND r.status <> 'CANCELLED'
+WHERE v.campaign_id = $1
+GROUP BY v.id
+ORDER BY v.id;
@@ -0,0 +1,72 @@
+-- name: CreateCoinTransaction :one
+INSERT INTO coin_transactions (user_id, type, coins, xp, reservation_id, campaign_id, description)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: ListCoinTransactionsByUser :many
+SELECT * FROM coin_transactions
+WHERE user_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountCoinTransactionsByUser :one
+SELECT COUNT(*)::int FROM coin_transactions WHERE user_id = $1;
+
+-- name: GetUserForUpdate :one
+SELECT * FROM users WHERE id = $1 FOR UPDATE;
+
+-- name: SyncUserBalance :one
+UPDATE users
+SET coins = COALESCE((SELECT SUM(ct.coins) FROM coin_transactions ct WHERE ct.user_id = $1), 0),
+    xp = COALESCE((SELECT SUM(ct.xp) FROM coin_transactions ct WHERE ct.user_id = $1), 0),
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: ListUserLedger :many
+SELECT * FROM coin_transactions
+WHERE user_id = $1
+ORDER BY created_at ASC, id ASC;
+
+-- name: ListCampaignCoinLiability :many
+WITH holders AS (
+    SELECT DISTINCT ct.user_id
+    FROM coin_transactions ct
+    JOIN campaigns c ON c.id = ct.campaign_id
+    WHERE c.owner_id = sqlc.arg(operator_id) AND ct.coins > 0
+),
+ledger AS (
+    SELECT ct.user_id, ct.campaign_id, ct.coins, ct.created_at,
+           SUM(ct.coins) OVER (PARTITION BY ct.user_id ORDER BY ct.created_at, ct.id) AS running,
+           COALESCE(SUM(GREATEST(ct.coins, 0)) OVER (
+               PARTITION BY ct.user_id ORDER BY ct.created_at DESC, ct.id DESC
+               ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING
+           ), 0) AS granted_after
+    FROM coin_transactions ct
+    JOIN holders h ON h.user_id = ct.user_id
+),
+balances AS (
+    SELECT user_id, SUM(coins) - LEAST(MIN(running), 0) AS open_coins
+    FROM ledger
+    GROUP BY user_id
+),
+lots AS (
+    SELECT l.campaign_id,
+           GREATEST(LEAST(l.coins, b.open_coins - l.granted_after), 0) AS coins,
+           CASE WHEN sqlc.arg(expiry_months)::int > 0
+                THEN l.created_at + make_interval(months => sqlc.arg(expiry_months)::int)
+           END AS expires_at
+    FROM ledger l
+    JOIN balances b ON b.user_id = l.user_id
+    JOIN campaigns c ON c.id = l.campaign_id
+    WHERE c.owner_id = sqlc.arg(operator_id) AND l.coins > 0
+)
+SELECT campaign_id,
+       COALESCE(SUM(coins) FILTER (WHERE expires_at IS NULL OR expires_at > sqlc.arg(as_of)), 0)::int AS outstanding_coins,
+       COALESCE(SUM(coins) FILTER (WHERE expires_at > sqlc.arg(as_of) AND expires_at < sqlc.arg(warn_until)), 0)::int AS expiring_coins
+FROM lots
+GROUP BY campaign_id
+ORDER BY campaign_id;
+
+-- name: ListUserIDsWithCoins :many
+SELECT id FROM users WHERE coins > 0 ORDER BY id;
@@ -0,0 +1,41 @@
+-- name: UpsertForecastVersion :one
+INSERT INTO forecast_versions (model, version, trained_at, notes, created_by)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (model, version)
+DO UPDATE SET trained_at = COALESCE(EXCLUDED.trained_at, forecast_versions.trained_at),
+              notes = CASE WHEN EXCLUDED.notes = '' THEN forecast_versions.notes ELSE EXCLUDED.notes END,
+              updated_at = NOW(), rolled_back_at = NULL
+RETURNING *;
+
+-- name: GetForecastVersion :one
+SELECT * FROM forecast_versions WHERE id = $1;
+
+-- name: ListForecastVersions :many
+SELECT v.id, v.model, v.version, v.trained_at, v.notes, v.created_by, v.created_at, v.updated_at, v.rolled_back_at,
+       COUNT(f.slot_start)::int AS hours, COUNT(DISTINCT f.station_id)::int AS stations,
+       MIN(f.slot_start)::timestamptz AS first_slot, MAX(f.slot_start)::timestamptz AS last_slot
+FROM forecast_versions v
+LEFT JOIN external_forecasts f ON f.version_id = v.id
+WHERE sqlc.narg(id)::int IS NULL OR v.id = sqlc.narg(id)
+GROUP BY v.id
+ORDER BY v.updated_at DESC, v.id DESC;
+
+-- name: SetForecastVersionRolledBack :one
+UPDATE forecast_versions SET rolled_back_at = sqlc.narg(rolled_back_at)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: UpsertExternalForecasts :execrows
+INSERT INTO external_forecasts (version_id, station_id, slot_start, predicted_load, p10_load, p90_load)
+SELECT sqlc.arg(version_id), unnest(sqlc.arg(station_ids)::int[]), unnest(sqlc.arg(slot_starts)::timestamptz[]),
+       unnest(sqlc.arg(predicted_loads)::int[]), unnest(sqlc.arg(p10_loads)::int[]), unnest(sqlc.arg(p90_loads)::int[])
+ON CONFLICT (version_id, station_id, slot_start)
+DO UPDATE SET predicted_load = EXCLUDED.predicted_load, p10_load = EXCLUDED.p10_load, p90_load = EXCLUDED.p90_load;
+
+-- name: ListExternalForecasts :many
+SELECT DISTINCT ON (f.slot_start) f.slot_start, f.predicted_load, f.p10_load, f.p90_load, v.model, v.version
+FROM external_forecasts f
+JOIN forecast_versions v ON v.id = f.version_id
+WHERE f.station_id = sqlc.arg(station_id) AND v.rolled_back_at IS NULL
+  AND f.slot_start >= sqlc.arg(window_start) AND f.slot_start < sqlc.arg(window_end)
+ORDER BY f.slot_start, v.updated_at DESC, v.id DESC;
@@ -1,5 +1,5 @@
 -- name: GetForecastsByDayHour :many
-SELECT f.id, f.station_id, f.day_of_week, f.hour, f.predicted_load,
+SELECT f.id, f.station_id, f.day_of_week, f.hour, f.predicted_load, f.p10_load, f.p90_load,
        s.name AS station_name, s.lat, s.lng, s.price, s.address, s.density_profile
 FROM station_density_forecasts f
 JOIN stations s ON s.id = f.station_id
@@ -7,11 +7,79 @@ WHERE f.day_of_week = $1 AND f.hour = $2
 ORDER BY f.predicted_load ASC;
 
 -- name: GetForecastForStation :one
-SELECT predicted_load FROM station_density_forecasts
+SELECT predicted_load, p10_load, p90_load FROM station_density_forecasts
 WHERE station_id = $1 AND day_of_week = $2 AND hour = $3;
 
+-- name: ListWeeklyForecastForStation :many
+SELECT day_of_week, hour, predicted_load, p10_load, p90_load FROM station_density_forecasts
+WHERE station_id = $1
+ORDER BY day_of_week, hour;
+
 -- name: UpsertForecast :exec
-INSERT INTO station_density_forecasts (station_id, day_of_week, hour, predicted_load)
-VALUES ($1, $2, $3, $4)
+INSERT INTO station_density_forecasts (station_id, day_of_week, hour, predicted_load, p90_load, model, p10_load)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 ON CONFLICT (station_id, day_of_week, hour)
-DO UPDATE SET predicted_load = $4, updated_at = NOW();
+DO UPDATE SET predicted_load = $4, p90_load = $5, model = $6, p10_load = $7, updated_at = NOW();
+
+-- name: UpsertOccupancy :exec
+INSERT INTO station_occupancy (station_id, observed_at, load, source)
+SELECT sqlc.arg(station_id), unnest(sqlc.arg(observed_at)::timestamptz[]), unnest(sqlc.arg(loads)::int[]), sqlc.arg(source)
+ON CONFLICT (station_id, observed_at)
+DO UPDATE SET load = EXCLUDED.load, source = EXCLUDED.source;
+
+-- name: ListOccupancy :many
+SELECT observed_at, load FROM station_occupancy
+WHERE station_id = $1 AND observed_at >= $2
+ORDER BY observed_at;
+
+-- name: ListReservationHours :many
+SELECT date, hour, COUNT(*)::int AS reservations
+FROM reservations
+WHERE station_id = $1 AND status <> 'CANCELLED' AND date >= $2
+GROUP BY date, hour
+ORDER BY date, hour;
+
+-- name: UpsertForecastAccuracy :exec
+INSERT INTO forecast_accuracy (station_id, hour, model, window_start, window_end, samples, mae, bias, mape, mape_samples)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (station_id, hour)
+DO UPDATE SET model = $3, window_start = $4, window_end = $5, samples = $6,
+              mae = $7, bias = $8, mape = $9, mape_samples = $10, evaluated_at = NOW();
+
+-- name: ListForecastAccuracy :many
+SELECT a.station_id, s.name AS station_name, s.forecast_model, a.hour, a.model, a.window_start, a.window_end,
+       a.samples, a.mae, a.bias, a.mape, a.mape_samples, a.evaluated_at
+FROM forecast_accuracy a
+JOIN stations s ON s.id = a.station_id
+WHERE (sqlc.narg(owner_id)::int IS NULL OR s.owner_id = sqlc.narg(owner_id))
+  AND (sqlc.narg(station_id)::int IS NULL OR a.station_id = sqlc.narg(station_id))
+ORDER BY a.station_id, a.hour;
+
+-- name: UpsertStationForecasts :exec
+INSERT INTO station_forecasts (station_id, slot_start, predicted_load, p10_load, p90_load, model, event,
+                               temperature, precipitation, temperature_effect, precipitation_effect)
+SELECT sqlc.arg(station_id), f.slot_start, f.predicted_load, f.p10_load, f.p90_load, sqlc.arg(model), NULLIF(f.event, ''),
+       CASE WHEN f.has_weather THEN f.temperature END, CASE WHEN f.has_weather THEN f.precipitation END,
+       CASE WHEN f.has_weather THEN f.temperature_effect END, CASE WHEN f.has_weather THEN f.precipitation_effect END
+FROM unnest(sqlc.arg(slot_starts)::timestamptz[], sqlc.arg(predicted_loads)::int[], sqlc.arg(p10_loads)::int[],
+            sqlc.arg(p90_loads)::int[], sqlc.arg(events)::text[], sqlc.arg(has_weather)::bool[],
+            sqlc.arg(temperatures)::float8[], sqlc.arg(precipitations)::float8[],
+            sqlc.arg(temperature_effects)::float8[], sqlc.arg(precipitation_effects)::float8[])
+     AS f(slot_start, predicted_load, p10_load, p90_load, event, has_weather,
+          temperature, precipitation, temperature_effect, precipitation_effect)
+ON CONFLICT (station_id, slot_start)
+DO UPDATE SET predicted_load = EXCLUDED.predicted_load, p10_load = EXCLUDED.p10_load, p90_load = EXCLUDED.p90_load,
+              model = EXCLUDED.model, event = EXCLUDED.event,
+              temperature = EXCLUDED.temperature, precipitation = EXCLUDED.precipitation,
+              temperature_effect = EXCLUDED.temperature_effect, precipitation_effect = EXCLUDED.precipitation_effect,
+              updated_at = NOW();
+
+-- name: DeleteStationForecastsOutside :exec
+DELETE FROM station_forecasts
+WHERE station_id = sqlc.arg(station_id) AND (slot_start < sqlc.arg(window_start) OR slot_start >= sqlc.arg(window_end));
+
+-- name: ListStationForecasts :many
+SELECT slot_start, predicted_load, p10_load, p90_load, model, event,
+       temperature, precipitation, temperature_effect, precipitation_effect FROM station_forecasts
+WHERE station_id = sqlc.arg(station_id) AND slot_start >= sqlc.arg(window_start) AND slot_start < sqlc.arg(window_end)
+ORDER BY slot_start;
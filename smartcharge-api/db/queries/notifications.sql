@@ -0,0 +1,25 @@
+-- name: CreateNotification :exec
+INSERT INTO notifications (user_id, type, title, body, dedupe_key)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (dedupe_key) DO NOTHING;
+
+-- name: ListNotificationsByUser :many
+SELECT * FROM notifications
+WHERE user_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountNotificationsByUser :one
+SELECT COUNT(*)::int FROM notifications WHERE user_id = $1;
+
+-- name: CountUnreadNotifications :one
+SELECT COUNT(*)::int FROM notifications WHERE user_id = $1 AND read_at IS NULL;
+
+-- name: MarkNotificationRead :one
+UPDATE notifications
+SET read_at = COALESCE(read_at, NOW())
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: GetNotificationByID :one
+SELECT * FROM notifications WHERE id = $1;
@@ -0,0 +1,15 @@
+-- name: GetOperatorSettings :one
+SELECT * FROM operator_settings WHERE operator_id = $1;
+
+-- name: UpsertOperatorSettings :one
+INSERT INTO operator_settings (operator_id, coin_value, max_coin_share, refund_window_hours, late_cancel_refund_share,
+                               max_combined_discount)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (operator_id) DO UPDATE
+SET coin_value = EXCLUDED.coin_value,
+    max_coin_share = EXCLUDED.max_coin_share,
+    refund_window_hours = EXCLUDED.refund_window_hours,
+    late_cancel_refund_share = EXCLUDED.late_cancel_refund_share,
+    max_combined_discount = EXCLUDED.max_combined_discount,
+    updated_at = NOW()
+RETURNING *;
@@ -0,0 +1,8 @@
+-- name: AddReservationCampaign :exec
+INSERT INTO reservation_campaigns (reservation_id, campaign_id, position, discount, coin_bonus, variant_id)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ListReservationCampaigns :many
+SELECT * FROM reservation_campaigns
+WHERE reservation_id = $1
+ORDER BY position;
@@ -1,21 +1,22 @@
 -- name: CreateReservation :one
-INSERT INTO reservations (user_id, station_id, date, hour, is_green, earned_coins, status)
-VALUES ($1, $2, $3, $4, $5, $6, 'PENDING')
+INSERT INTO reservations (user_id, station_id, date, hour, is_green, earned_coins, campaign_id,
+                          price, redeemed_coins, coin_discount, session_amount, status)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, 'PENDING')
 RETURNING *;
 
 -- name: GetReservationByID :one
 SELECT * FROM reservations WHERE id = $1;
 
--- name: UpdateReservationStatus :one
+-- name: CancelReservation :one
 UPDATE reservations
-SET status = $2
-WHERE id = $1
+SET status = 'CANCELLED'
+WHERE id = $1 AND status NOT IN ('CANCELLED', 'COMPLETED')
 RETURNING *;
 
 -- name: CompleteReservation :one
 UPDATE reservations
-SET status = 'COMPLETED', earned_coins = $2
-WHERE id = $1
+SET status = 'COMPLETED', earned_coins = $2, saved_co2 = $3
+WHERE id = $1 AND status NOT IN ('COMPLETED', 'CANCELLED')
 RETURNING *;
 
 -- name: ListReservationsByStation :many
@@ -37,3 +38,29 @@ SELECT COALESCE(SUM(
 FROM reservations r
 JOIN stations s ON s.id = r.station_id
 WHERE r.station_id = $1;
+
+-- name: GetUserPeriodSummary :one
+SELECT
+    COUNT(*)::int AS sessions,
+    COUNT(*) FILTER (WHERE r.is_green = true)::int AS green_sessions,
+    COALESCE(SUM(r.earned_coins), 0)::int AS coins_earned,
+    COALESCE(SUM(r.saved_co2), 0)::double precision AS co2_saved,
+    COALESCE(SUM(r.session_amount - r.coin_discount), 0)::double precision AS amount_spent,
+    COALESCE(SUM(r.redeemed_coins), 0)::int AS coins_redeemed
+FROM reservations r
+WHERE r.user_id = $1
+  AND r.status = 'COMPLETED'
+  AND r.date >= sqlc.arg(period_start)
+  AND r.date < sqlc.arg(period_end);
+
+-- name: ListUserCompletedReservations :many
+SELECT r.id, r.date, r.hour, r.is_green, r.earned_coins, r.saved_co2,
+       r.price, r.redeemed_coins, r.coin_discount, r.session_amount,
+       s.id AS station_id, s.name AS station_name
+FROM reservations r
+JOIN stations s ON s.id = r.station_id
+WHERE r.user_id = $1
+  AND r.status = 'COMPLETED'
+  AND r.date >= sqlc.arg(period_start)
+  AND r.date < sqlc.arg(period_end)
+ORDER BY r.date ASC, r.id ASC;
@@ -0,0 +1,66 @@
+-- name: ListAvailableRewards :many
+SELECT * FROM rewards
+WHERE is_active = true
+  AND valid_from <= NOW()
+  AND (valid_until IS NULL OR valid_until > NOW())
+  AND (stock IS NULL OR stock > 0)
+ORDER BY cost_coins ASC, id ASC;
+
+-- name: ListRewardsByOperator :many
+SELECT * FROM rewards
+WHERE operator_id = $1
+ORDER BY created_at DESC;
+
+-- name: GetRewardByID :one
+SELECT * FROM rewards WHERE id = $1;
+
+-- name: GetRewardForUpdate :one
+SELECT * FROM rewards WHERE id = $1 FOR UPDATE;
+
+-- name: CreateReward :one
+INSERT INTO rewards (operator_id, title, description, category, partner_name, cost_coins,
+                     stock, per_user_limit, valid_from, valid_until, voucher_validity_days, is_active)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING *;
+
+-- name: UpdateReward :one
+UPDATE rewards
+SET title = $2, description = $3, category = $4, partner_name = $5, cost_coins = $6,
+    stock = $7, per_user_limit = $8, valid_from = $9, valid_until = $10,
+    voucher_validity_days = $11, is_active = $12, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DecrementRewardStock :exec
+UPDATE rewards
+SET stock = stock - 1, updated_at = NOW()
+WHERE id = $1 AND stock IS NOT NULL;
+
+-- name: CountUserVouchersForReward :one
+SELECT COUNT(*)::int FROM reward_vouchers
+WHERE reward_id = $1 AND user_id = $2;
+
+-- name: CreateRewardVoucher :one
+INSERT INTO reward_vouchers (reward_id, user_id, code, coins, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetVoucherByCodeForUpdate :one
+SELECT * FROM reward_vouchers WHERE code = $1 FOR UPDATE;
+
+-- name: ConsumeVoucher :one
+UPDATE reward_vouchers
+SET status = 'CONSUMED', consumed_at = NOW(), consumed_by = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: ListVouchersByUser :many
+SELECT v.id, v.code, v.coins, v.status, v.expires_at, v.consumed_at, v.created_at,
+       r.id AS reward_id, r.title AS reward_title, r.category AS reward_category, r.partner_name
+FROM reward_vouchers v
+JOIN rewards r ON r.id = v.reward_id
+WHERE v.user_id = $1
+ORDER BY v.created_at DESC;
+
+-- name: GetVoucherByCode :one
+SELECT * FROM reward_vouchers WHERE code = $1;
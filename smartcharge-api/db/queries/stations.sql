@@ -1,6 +1,6 @@
 -- name: ListStations :many
 SELECT s.id, s.name, s.lat, s.lng, s.price, s.density, s.owner_id, s.address, s.density_profile,
-       u.name AS owner_name
+       s.forecast_model, u.name AS owner_name
 FROM stations s
 LEFT JOIN users u ON u.id = s.owner_id
 ORDER BY s.id ASC;
@@ -27,10 +27,13 @@ RETURNING *;
 DELETE FROM stations WHERE id = $1;
 
 -- name: ListStationsByOwner :many
-SELECT s.id, s.name, s.lat, s.lng, s.address, s.price, s.density, s.density_profile, s.owner_id
+SELECT s.id, s.name, s.lat, s.lng, s.address, s.price, s.density, s.density_profile, s.owner_id, s.forecast_model
 FROM stations s
 WHERE s.owner_id = $1
 ORDER BY s.id ASC;
 
 -- name: UpdateStationDensity :exec
 UPDATE stations SET density = $2 WHERE id = $1;
+
+-- name: UpdateStationForecastModel :exec
+UPDATE stations SET forecast_model = $2 WHERE id = $1;
@@ -11,13 +11,15 @@ RETURNING *;
 
 -- name: UpdateUserProfile :one
 UPDATE users
-SET name = $2, email = $3, updated_at = NOW()
+SET name = $2, email = $3,
+    vehicle_type = COALESCE(sqlc.narg(vehicle_type), vehicle_type),
+    updated_at = NOW()
 WHERE id = $1
 RETURNING *;
 
--- name: UpdateUserStats :one
+-- name: AddUserCo2Saved :one
 UPDATE users
-SET coins = coins + $2, co2_saved = co2_saved + $3, xp = xp + $4, updated_at = NOW()
+SET co2_saved = co2_saved + $2, updated_at = NOW()
 WHERE id = $1
 RETURNING *;
 
@@ -28,7 +30,7 @@ ORDER BY xp DESC
 LIMIT $1;
 
 -- name: GetUserBadges :many
-SELECT b.id, b.name, b.description, b.icon
+SELECT b.id, b.name, b.description, b.icon, b.icon_url, b.retired_at
 FROM badges b
 JOIN user_badges ub ON ub.badge_id = b.id
 WHERE ub.user_id = $1
@@ -54,5 +56,8 @@ WHERE r.user_id = $1
 ORDER BY r.id DESC
 LIMIT $2;
 
+-- name: CountUserCompletedReservations :one
+SELECT COUNT(*) FROM reservations WHERE user_id = $1 AND status = 'COMPLETED';
+
 -- name: GetDemoUser :one
 SELECT id, name, email, role FROM users WHERE email = 'driver@test.com';
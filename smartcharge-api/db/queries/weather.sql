@@ -0,0 +1,24 @@
+-- name: ListWeather :many
+SELECT * FROM weather_hours
+WHERE (station_id IS NULL OR station_id = sqlc.narg(station_id))
+  AND observed_at >= sqlc.arg(window_start)
+  AND observed_at < sqlc.arg(window_end)
+ORDER BY observed_at, station_id NULLS FIRST;
+
+-- name: ImportAreaWeather :execrows
+INSERT INTO weather_hours (observed_at, temperature, precipitation, is_forecast, source)
+SELECT unnest(sqlc.arg(observed_at)::timestamptz[]), unnest(sqlc.arg(temperatures)::float8[]),
+       unnest(sqlc.arg(precipitations)::float8[]), unnest(sqlc.arg(is_forecast)::bool[]), sqlc.arg(source)
+ON CONFLICT (observed_at) WHERE station_id IS NULL
+DO UPDATE SET temperature = EXCLUDED.temperature, precipitation = EXCLUDED.precipitation,
+              is_forecast = EXCLUDED.is_forecast, source = EXCLUDED.source, updated_at = NOW()
+WHERE weather_hours.is_forecast OR NOT EXCLUDED.is_forecast;
+
+-- name: ImportStationWeather :execrows
+INSERT INTO weather_hours (station_id, observed_at, temperature, precipitation, is_forecast, source)
+SELECT sqlc.arg(station_id), unnest(sqlc.arg(observed_at)::timestamptz[]), unnest(sqlc.arg(temperatures)::float8[]),
+       unnest(sqlc.arg(precipitations)::float8[]), unnest(sqlc.arg(is_forecast)::bool[]), sqlc.arg(source)
+ON CONFLICT (station_id, observed_at) WHERE station_id IS NOT NULL
+DO UPDATE SET temperature = EXCLUDED.temperature, precipitation = EXCLUDED.precipitation,
+              is_forecast = EXCLUDED.is_forecast, source = EXCLUDED.source, updated_at = NOW()
+WHERE weather_hours.is_forecast OR NOT EXCLUDED.is_forecast;
@@ -0,0 +1,165 @@
+// Package apitest runs handlers against an in-memory database so route tests need no Postgres.
+package apitest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// queryName matches the header sqlc puts on every generated query.
+var queryName = regexp.MustCompile(`^-- name: (\w+) :`)
+
+// DB stands in for the pool and its transactions. Queries are answered by their sqlc name:
+// one given rows with On returns them, any other :one query returns a row of zero values
+// and any other :many query returns no rows. Exec always reports one affected row.
+type DB struct {
+	mu    sync.Mutex
+	rows  map[string][]any
+	calls []string
+}
+
+// NewDB returns an empty database.
+func NewDB() *DB {
+	return &DB{rows: map[string][]any{}}
+}
+
+// On makes the named query return rows: generated model or row structs, or single values
+// for one-column queries. With no rows, :one queries fail with pgx.ErrNoRows.
+func (db *DB) On(query string, rows ...any) *DB {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.rows[query] = rows
+	return db
+}
+
+// Called reports whether the named query ran.
+func (db *DB) Called(query string) bool {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	for _, c := range db.calls {
+		if c == query {
+			return true
+		}
+	}
+	return false
+}
+
+// Exec implements generated.DBTX.
+func (db *DB) Exec(_ context.Context, sql string, _ ...interface{}) (pgconn.CommandTag, error) {
+	db.lookup(sql)
+	return pgconn.NewCommandTag("UPDATE 1"), nil
+}
+
+// Query implements generated.DBTX.
+func (db *DB) Query(_ context.Context, sql string, _ ...interface{}) (pgx.Rows, error) {
+	rows, _ := db.lookup(sql)
+	return &resultRows{rows: rows, index: -1}, nil
+}
+
+// QueryRow implements generated.DBTX.
+func (db *DB) QueryRow(_ context.Context, sql string, _ ...interface{}) pgx.Row {
+	rows, ok := db.lookup(sql)
+	if !ok {
+		return zeroRow{}
+	}
+	if len(rows) == 0 {
+		return zeroRow{err: pgx.ErrNoRows}
+	}
+	return valueRow{rows[0]}
+}
+
+// BeginTx starts a transaction whose queries go to the same database; commits and rollbacks do nothing.
+func (db *DB) BeginTx(context.Context, pgx.TxOptions) (pgx.Tx, error) {
+	return &tx{db: db}, nil
+}
+
+func (db *DB) lookup(sql string) ([]any, bool) {
+	name := ""
+	if m := queryName.FindStringSubmatch(sql); m != nil {
+		name = m[1]
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.calls = append(db.calls, name)
+	rows, ok := db.rows[name]
+	return rows, ok
+}
+
+// tx is a transaction on DB. Savepoints are the transaction itself.
+type tx struct {
+	pgx.Tx
+	db *DB
+}
+
+func (t *tx) Begin(context.Context) (pgx.Tx, error) { return t, nil }
+func (t *tx) Commit(context.Context) error          { return nil }
+func (t *tx) Rollback(context.Context) error        { return nil }
+
+func (t *tx) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return t.db.Exec(ctx, sql, args...)
+}
+
+func (t *tx) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return t.db.Query(ctx, sql, args...)
+}
+
+func (t *tx) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return t.db.QueryRow(ctx, sql, args...)
+}
+
+// zeroRow leaves the scan targets at their zero values, or fails with err.
+type zeroRow struct{ err error }
+
+func (r zeroRow) Scan(...any) error { return r.err }
+
+// valueRow scans a canned row.
+type valueRow struct{ value any }
+
+func (r valueRow) Scan(dest ...any) error { return scan(r.value, dest) }
+
+// resultRows iterates over canned rows.
+type resultRows struct {
+	pgx.Rows
+	rows  []any
+	index int
+}
+
+func (r *resultRows) Next() bool {
+	r.index++
+	return r.index < len(r.rows)
+}
+
+func (r *resultRows) Scan(dest ...any) error        { return scan(r.rows[r.index], dest) }
+func (r *resultRows) Close()                        {}
+func (r *resultRows) Err() error                    { return nil }
+func (r *resultRows) CommandTag() pgconn.CommandTag { return pgconn.NewCommandTag("SELECT") }
+
+// scan copies value into dest: a single value into a single target, or a struct's fields in order,
+// which is the order sqlc scans columns into its generated structs.
+func scan(value any, dest []any) error {
+	v := reflect.ValueOf(value)
+	if len(dest) == 1 {
+		target := reflect.ValueOf(dest[0]).Elem()
+		if v.Type().AssignableTo(target.Type()) {
+			target.Set(v)
+			return nil
+		}
+	}
+	if v.Kind() != reflect.Struct || v.NumField() != len(dest) {
+		return fmt.Errorf("apitest: can't scan %T into %d columns", value, len(dest))
+	}
+	for i := range dest {
+		target := reflect.ValueOf(dest[i]).Elem()
+		if !v.Field(i).Type().AssignableTo(target.Type()) {
+			return fmt.Errorf("apitest: column %d of %T is %s, scanned into %s", i, value, v.Field(i).Type(), target.Type())
+		}
+		target.Set(v.Field(i))
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package apitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+
+	"smartcharge-api/internal/middleware"
+	"smartcharge-api/internal/policy"
+)
+
+// Route is a request to a mutating endpoint.
+type Route struct {
+	Method string
+	Path   string
+	Body   any
+	// Files, if set, are sent as a multipart form instead of Body, keyed by field name.
+	Files map[string][]byte
+}
+
+// Router returns a router whose auth middleware authenticates every request as actor,
+// the way middleware.AuthRequired does for a valid token.
+func Router(actor policy.Actor, register func(v1 *gin.RouterGroup, auth gin.HandlerFunc)) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	register(r.Group("/v1"), func(c *gin.Context) {
+		c.Set(string(middleware.ContextUserID), actor.UserID)
+		c.Set(string(middleware.ContextUserRole), actor.Role)
+		c.Next()
+	})
+	return r
+}
+
+// Do sends the request to h with Body encoded as JSON, or with Files as a multipart form.
+func Do(h http.Handler, route Route) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	contentType := "application/json"
+	if route.Files != nil {
+		form := multipart.NewWriter(&body)
+		for field, data := range route.Files {
+			part, err := form.CreateFormFile(field, field)
+			if err != nil {
+				panic(err)
+			}
+			part.Write(data)
+		}
+		form.Close()
+		contentType = form.FormDataContentType()
+	} else if route.Body != nil {
+		if err := json.NewEncoder(&body).Encode(route.Body); err != nil {
+			panic(err)
+		}
+	}
+	req := httptest.NewRequest(route.Method, route.Path, &body)
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+// The actors authorization tests run as. Test stations and campaigns belong to Owner,
+// test reservations to Driver.
+var (
+	Driver        = policy.Actor{UserID: 1, Role: policy.RoleDriver}
+	OtherDriver   = policy.Actor{UserID: 5, Role: policy.RoleDriver}
+	Owner         = policy.Actor{UserID: 2, Role: policy.RoleOperator}
+	OtherOperator = policy.Actor{UserID: 3, Role: policy.RoleOperator}
+	Admin         = policy.Actor{UserID: 4, Role: policy.RoleAdmin}
+)
+
+// Actors names the test actors for subtests and expectation tables.
+var Actors = map[string]policy.Actor{
+	"driver":          Driver,
+	"other driver":    OtherDriver,
+	"owning operator": Owner,
+	"other operator":  OtherOperator,
+	"admin":           Admin,
+}
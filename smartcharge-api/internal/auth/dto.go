@@ -11,7 +11,7 @@ type RegisterRequest struct {
 	Name     string `json:"name" binding:"required"`
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=6"`
-	Role     string `json:"role,omitempty"`
+	Role     string `json:"role,omitempty" binding:"omitempty,oneof=DRIVER OPERATOR"`
 }
 
 // AuthResponse is the response for login and register endpoints.
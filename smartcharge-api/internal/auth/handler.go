@@ -49,7 +49,7 @@ func (h *Handler) Login(c *gin.Context) {
 func (h *Handler) Register(c *gin.Context) {
 	var req RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.Err(c, 400, "VALIDATION_ERROR", "Name, email, and password (min 6 chars) are required")
+		response.Err(c, 400, "VALIDATION_ERROR", "Name, email, and password (min 6 chars) are required; role must be DRIVER or OPERATOR")
 		return
 	}
 
@@ -0,0 +1,341 @@
+package badge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"smartcharge-api/db/generated"
+	apperrors "smartcharge-api/internal/errors"
+)
+
+// DefaultLocale is the language badges are authored in; other locales are stored as translations.
+const DefaultLocale = "tr"
+
+var localePattern = regexp.MustCompile(`^[a-z]{2}$`)
+
+// NormalizeLocale reduces a locale such as "en-US" to its lower-case language code.
+// It returns "" when no usable language code is present.
+func NormalizeLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, "-_"); i >= 0 {
+		locale = locale[:i]
+	}
+	if !localePattern.MatchString(locale) {
+		return ""
+	}
+	return locale
+}
+
+// IsKnownMetric reports whether the engine can evaluate a rule on metric.
+func IsKnownMetric(metric string) bool {
+	_, ok := MetricValue(generated.GetUserBadgeMetricsRow{}, metric)
+	return ok
+}
+
+// AdminList returns every badge, including retired ones, with its rule and translations.
+func (s *Service) AdminList(ctx context.Context) ([]AdminBadgeResponse, error) {
+	badges, err := s.queries.ListAllBadges(ctx)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	rules, err := s.queries.ListAllBadgeRules(ctx)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	translations, err := s.queries.ListBadgeTranslations(ctx)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	rulesByBadge := make(map[int32]generated.BadgeRule, len(rules))
+	for _, r := range rules {
+		rulesByBadge[r.BadgeID] = r
+	}
+	translationsByBadge := make(map[int32][]generated.BadgeTranslation)
+	for _, t := range translations {
+		translationsByBadge[t.BadgeID] = append(translationsByBadge[t.BadgeID], t)
+	}
+
+	result := make([]AdminBadgeResponse, len(badges))
+	for i, b := range badges {
+		var rule *generated.BadgeRule
+		if r, ok := rulesByBadge[b.ID]; ok {
+			rule = &r
+		}
+		result[i] = toAdminResponse(b, rule, translationsByBadge[b.ID])
+	}
+	return result, nil
+}
+
+// AdminGet returns a single badge with its rule and translations.
+func (s *Service) AdminGet(ctx context.Context, badgeID int32) (*AdminBadgeResponse, error) {
+	return s.adminBadge(ctx, s.queries, badgeID)
+}
+
+// Create creates a badge together with its optional rule and translations.
+func (s *Service) Create(ctx context.Context, req CreateBadgeRequest) (*AdminBadgeResponse, error) {
+	if req.Rule != nil && !IsKnownMetric(req.Rule.Metric) {
+		return nil, unknownMetricError(req.Rule.Metric)
+	}
+	for locale := range req.Translations {
+		if NormalizeLocale(locale) != locale {
+			return nil, apperrors.NewValidationError(fmt.Sprintf("Invalid locale %q, expected a two-letter language code", locale))
+		}
+	}
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+	qtx := s.queries.WithTx(tx)
+
+	badge, err := qtx.CreateBadge(ctx, generated.CreateBadgeParams{
+		Name:        req.Name,
+		Description: req.Description,
+		Icon:        req.Icon,
+	})
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	if req.Rule != nil {
+		if _, err := qtx.UpsertBadgeRule(ctx, generated.UpsertBadgeRuleParams{
+			BadgeID:   badge.ID,
+			Metric:    req.Rule.Metric,
+			Threshold: req.Rule.Threshold,
+		}); err != nil {
+			return nil, apperrors.ErrInternal
+		}
+	}
+
+	for locale, t := range req.Translations {
+		if _, err := qtx.UpsertBadgeTranslation(ctx, generated.UpsertBadgeTranslationParams{
+			BadgeID:     badge.ID,
+			Locale:      locale,
+			Name:        t.Name,
+			Description: t.Description,
+		}); err != nil {
+			return nil, apperrors.ErrInternal
+		}
+	}
+
+	resp, err := s.adminBadge(ctx, qtx, badge.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	return resp, nil
+}
+
+// Update changes a badge's default-locale copy and icon name.
+func (s *Service) Update(ctx context.Context, badgeID int32, req UpdateBadgeRequest) (*AdminBadgeResponse, error) {
+	badge, err := s.queries.GetBadgeByID(ctx, badgeID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("Badge")
+	}
+
+	params := generated.UpdateBadgeParams{
+		ID:          badge.ID,
+		Name:        badge.Name,
+		Description: badge.Description,
+		Icon:        badge.Icon,
+	}
+	if req.Name != nil {
+		params.Name = *req.Name
+	}
+	if req.Description != nil {
+		params.Description = *req.Description
+	}
+	if req.Icon != nil {
+		params.Icon = *req.Icon
+	}
+	if params.Name == "" || params.Description == "" || params.Icon == "" {
+		return nil, apperrors.NewValidationError("name, description and icon cannot be empty")
+	}
+
+	if _, err := s.queries.UpdateBadge(ctx, params); err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	return s.adminBadge(ctx, s.queries, badgeID)
+}
+
+// SetRule sets the criteria the engine uses to award the badge.
+// Existing holders keep the badge; the new rule applies from the next evaluation.
+func (s *Service) SetRule(ctx context.Context, badgeID int32, req RuleRequest) (*AdminBadgeResponse, error) {
+	if !IsKnownMetric(req.Metric) {
+		return nil, unknownMetricError(req.Metric)
+	}
+	if _, err := s.queries.GetBadgeByID(ctx, badgeID); err != nil {
+		return nil, apperrors.NewNotFoundError("Badge")
+	}
+
+	if _, err := s.queries.UpsertBadgeRule(ctx, generated.UpsertBadgeRuleParams{
+		BadgeID:   badgeID,
+		Metric:    req.Metric,
+		Threshold: req.Threshold,
+	}); err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	return s.adminBadge(ctx, s.queries, badgeID)
+}
+
+// DeleteRule removes the badge's criteria so it can no longer be earned automatically.
+func (s *Service) DeleteRule(ctx context.Context, badgeID int32) (*AdminBadgeResponse, error) {
+	if _, err := s.queries.GetBadgeByID(ctx, badgeID); err != nil {
+		return nil, apperrors.NewNotFoundError("Badge")
+	}
+	if err := s.queries.DeleteBadgeRule(ctx, badgeID); err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	return s.adminBadge(ctx, s.queries, badgeID)
+}
+
+// Retire hides the badge from the catalog and stops it being awarded.
+// Users who already earned it keep it on their profile. Retiring twice is a no-op.
+func (s *Service) Retire(ctx context.Context, badgeID int32) (*AdminBadgeResponse, error) {
+	if _, err := s.queries.RetireBadge(ctx, badgeID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NewNotFoundError("Badge")
+		}
+		return nil, apperrors.ErrInternal
+	}
+	return s.adminBadge(ctx, s.queries, badgeID)
+}
+
+// SetTranslation creates or replaces the badge's copy for a locale.
+func (s *Service) SetTranslation(ctx context.Context, badgeID int32, locale string, req TranslationRequest) (*AdminBadgeResponse, error) {
+	if NormalizeLocale(locale) != locale || locale == DefaultLocale {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("Invalid locale %q, expected a two-letter language code other than %q", locale, DefaultLocale))
+	}
+	if _, err := s.queries.GetBadgeByID(ctx, badgeID); err != nil {
+		return nil, apperrors.NewNotFoundError("Badge")
+	}
+
+	if _, err := s.queries.UpsertBadgeTranslation(ctx, generated.UpsertBadgeTranslationParams{
+		BadgeID:     badgeID,
+		Locale:      locale,
+		Name:        req.Name,
+		Description: req.Description,
+	}); err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	return s.adminBadge(ctx, s.queries, badgeID)
+}
+
+// DeleteTranslation removes the badge's copy for a locale.
+func (s *Service) DeleteTranslation(ctx context.Context, badgeID int32, locale string) (*AdminBadgeResponse, error) {
+	n, err := s.queries.DeleteBadgeTranslation(ctx, generated.DeleteBadgeTranslationParams{
+		BadgeID: badgeID,
+		Locale:  locale,
+	})
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	if n == 0 {
+		return nil, apperrors.NewNotFoundError("Badge translation")
+	}
+	return s.adminBadge(ctx, s.queries, badgeID)
+}
+
+// UploadIcon stores a new icon image for the badge and replaces the previous upload.
+func (s *Service) UploadIcon(ctx context.Context, badgeID int32, data []byte) (*AdminBadgeResponse, error) {
+	badge, err := s.queries.GetBadgeByID(ctx, badgeID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("Badge")
+	}
+
+	url, err := s.icons.Save(badgeID, data)
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			return nil, appErr
+		}
+		return nil, apperrors.ErrInternal
+	}
+
+	if _, err := s.queries.SetBadgeIconURL(ctx, generated.SetBadgeIconURLParams{
+		ID:      badgeID,
+		IconUrl: pgtype.Text{String: url, Valid: true},
+	}); err != nil {
+		s.icons.Remove(url)
+		return nil, apperrors.ErrInternal
+	}
+	if badge.IconUrl.Valid {
+		s.icons.Remove(badge.IconUrl.String)
+	}
+	return s.adminBadge(ctx, s.queries, badgeID)
+}
+
+// DeleteIcon removes the uploaded icon so clients fall back to the named icon.
+func (s *Service) DeleteIcon(ctx context.Context, badgeID int32) (*AdminBadgeResponse, error) {
+	badge, err := s.queries.GetBadgeByID(ctx, badgeID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("Badge")
+	}
+	if !badge.IconUrl.Valid {
+		return s.adminBadge(ctx, s.queries, badgeID)
+	}
+
+	if _, err := s.queries.SetBadgeIconURL(ctx, generated.SetBadgeIconURLParams{ID: badgeID}); err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	s.icons.Remove(badge.IconUrl.String)
+	return s.adminBadge(ctx, s.queries, badgeID)
+}
+
+// adminBadge loads a badge with its rule and translations using q, which may be a transaction.
+func (s *Service) adminBadge(ctx context.Context, q *generated.Queries, badgeID int32) (*AdminBadgeResponse, error) {
+	badge, err := q.GetBadgeByID(ctx, badgeID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("Badge")
+	}
+
+	var rule *generated.BadgeRule
+	r, err := q.GetBadgeRule(ctx, badgeID)
+	switch {
+	case err == nil:
+		rule = &r
+	case !errors.Is(err, pgx.ErrNoRows):
+		return nil, apperrors.ErrInternal
+	}
+
+	translations, err := q.ListBadgeTranslationsByBadge(ctx, badgeID)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	resp := toAdminResponse(badge, rule, translations)
+	return &resp, nil
+}
+
+func toAdminResponse(b generated.Badge, rule *generated.BadgeRule, translations []generated.BadgeTranslation) AdminBadgeResponse {
+	resp := AdminBadgeResponse{
+		ID:           b.ID,
+		Name:         b.Name,
+		Description:  b.Description,
+		Icon:         b.Icon,
+		IconURL:      optionalText(b.IconUrl),
+		Translations: make(map[string]TranslationResponse, len(translations)),
+		Retired:      b.RetiredAt.Valid,
+		RetiredAt:    optionalTime(b.RetiredAt),
+	}
+	if rule != nil {
+		resp.Rule = &RuleResponse{Metric: rule.Metric, Threshold: rule.Threshold}
+	}
+	for _, t := range translations {
+		resp.Translations[t.Locale] = TranslationResponse{Name: t.Name, Description: t.Description}
+	}
+	return resp
+}
+
+func unknownMetricError(metric string) error {
+	return apperrors.NewValidationError(fmt.Sprintf("Unknown metric %q", metric))
+}
@@ -0,0 +1,48 @@
+package badge
+
+import (
+	"context"
+	"log"
+)
+
+// BackfillJob re-evaluates badge rules for every driver with completed reservations,
+// catching up on awards missed at completion time or enabled by new rules. It implements scheduler.Job.
+type BackfillJob struct {
+	engine *Engine
+}
+
+// NewBackfillJob creates the badge backfill job.
+func NewBackfillJob(engine *Engine) *BackfillJob {
+	return &BackfillJob{engine: engine}
+}
+
+// Name implements scheduler.Job.
+func (j *BackfillJob) Name() string {
+	return "badge-backfill"
+}
+
+// Run implements scheduler.Job.
+func (j *BackfillJob) Run(ctx context.Context) error {
+	userIDs, err := j.engine.queries.ListUserIDsWithCompletedReservations(ctx)
+	if err != nil {
+		return err
+	}
+
+	awarded := 0
+	for _, id := range userIDs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		badges, err := j.engine.Evaluate(ctx, id)
+		if err != nil {
+			log.Printf("badge-backfill: user %d: %v", id, err)
+			continue
+		}
+		awarded += len(badges)
+	}
+
+	if awarded > 0 {
+		log.Printf("badge-backfill: awarded %d badges", awarded)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package badge
+
+// --- Request DTOs ---
+
+// RuleRequest is the machine-readable criteria used by the engine to award a badge.
+type RuleRequest struct {
+	Metric    string  `json:"metric" binding:"required"`
+	Threshold float64 `json:"threshold" binding:"required,gt=0"`
+}
+
+// TranslationRequest is the localized copy of a badge.
+type TranslationRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description" binding:"required"`
+}
+
+// CreateBadgeRequest is the request body for POST /v1/admin/badges.
+// Translations are keyed by locale (e.g. "en").
+type CreateBadgeRequest struct {
+	Name         string                        `json:"name" binding:"required"`
+	Description  string                        `json:"description" binding:"required"`
+	Icon         string                        `json:"icon" binding:"required,max=50"`
+	Rule         *RuleRequest                  `json:"rule,omitempty"`
+	Translations map[string]TranslationRequest `json:"translations,omitempty"`
+}
+
+// UpdateBadgeRequest is the request body for PUT /v1/admin/badges/:id.
+// Omitted fields keep their current value.
+type UpdateBadgeRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Icon        *string `json:"icon,omitempty" binding:"omitempty,max=50"`
+}
+
+// --- Response DTOs ---
+
+// BadgeResponse is the response DTO for a badge.
+type BadgeResponse struct {
+	ID          int32   `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Icon        string  `json:"icon"`
+	IconURL     *string `json:"iconUrl"`
+}
+
+// ProgressResponse is a user's progress towards one badge.
+type ProgressResponse struct {
+	BadgeID     int32   `json:"badgeId"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Icon        string  `json:"icon"`
+	IconURL     *string `json:"iconUrl"`
+	Metric      string  `json:"metric"`
+	Current     float64 `json:"current"`
+	Target      float64 `json:"target"`
+	Percentage  float64 `json:"percentage"`
+	Earned      bool    `json:"earned"`
+	EarnedAt    *string `json:"earnedAt"`
+	UpdatedAt   *string `json:"updatedAt"`
+}
+
+// RuleResponse is the awarding criteria of a badge.
+type RuleResponse struct {
+	Metric    string  `json:"metric"`
+	Threshold float64 `json:"threshold"`
+}
+
+// TranslationResponse is the localized copy of a badge.
+type TranslationResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// AdminBadgeResponse is the full admin view of a badge, including retired ones.
+type AdminBadgeResponse struct {
+	ID           int32                          `json:"id"`
+	Name         string                         `json:"name"`
+	Description  string                         `json:"description"`
+	Icon         string                         `json:"icon"`
+	IconURL      *string                        `json:"iconUrl"`
+	Rule         *RuleResponse                  `json:"rule"`
+	Translations map[string]TranslationResponse `json:"translations"`
+	Retired      bool                           `json:"retired"`
+	RetiredAt    *string                        `json:"retiredAt"`
+}
@@ -0,0 +1,149 @@
+package badge
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"smartcharge-api/db/generated"
+)
+
+// Metrics a badge rule can be evaluated against.
+const (
+	MetricCompletedSessions = "COMPLETED_SESSIONS"
+	MetricGreenSessions     = "GREEN_SESSIONS"
+	MetricNightSessions     = "NIGHT_SESSIONS"
+	MetricMorningSessions   = "MORNING_SESSIONS"
+	MetricWeekendSessions   = "WEEKEND_SESSIONS"
+	MetricOutskirtSessions  = "OUTSKIRT_SESSIONS"
+	MetricDistinctStations  = "DISTINCT_STATIONS"
+	MetricCo2Saved          = "CO2_SAVED"
+)
+
+// AwardEvent is emitted once when a user earns a badge.
+type AwardEvent struct {
+	UserID    int32
+	Badge     generated.Badge
+	AwardedAt time.Time
+}
+
+// Listener is notified of newly awarded badges.
+type Listener func(ctx context.Context, ev AwardEvent)
+
+// Engine evaluates badge rules against a user's completed reservations and awards badges.
+type Engine struct {
+	queries   *generated.Queries
+	listeners []Listener
+}
+
+// NewEngine creates a badge rule engine.
+func NewEngine(queries *generated.Queries) *Engine {
+	return &Engine{queries: queries}
+}
+
+// OnAward registers a listener for newly awarded badges.
+func (e *Engine) OnAward(l Listener) {
+	e.listeners = append(e.listeners, l)
+}
+
+// Evaluate checks every badge rule for the user, records their progress and awards the ones newly met.
+// Awarding is idempotent: a badge the user already has is never awarded or announced again.
+func (e *Engine) Evaluate(ctx context.Context, userID int32) ([]generated.Badge, error) {
+	rules, err := e.queries.ListBadgeRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	ownedIDs, err := e.queries.ListUserBadgeIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	owned := make(map[int32]bool, len(ownedIDs))
+	for _, id := range ownedIDs {
+		owned[id] = true
+	}
+
+	metrics, err := e.queries.GetUserBadgeMetrics(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var awarded []generated.Badge
+	for _, rule := range rules {
+		value, ok := MetricValue(metrics, rule.Metric)
+		if !ok {
+			continue
+		}
+
+		if err := e.queries.UpsertBadgeProgress(ctx, generated.UpsertBadgeProgressParams{
+			UserID:       userID,
+			BadgeID:      rule.ID,
+			CurrentValue: value,
+			Target:       rule.Threshold,
+		}); err != nil {
+			return awarded, err
+		}
+
+		if owned[rule.ID] || value < rule.Threshold {
+			continue
+		}
+
+		// A concurrent evaluation may have awarded it already; only the insert that wins announces it
+		n, err := e.queries.AwardUserBadge(ctx, generated.AwardUserBadgeParams{
+			UserID:  userID,
+			BadgeID: rule.ID,
+		})
+		if err != nil {
+			return awarded, err
+		}
+		if n == 0 {
+			continue
+		}
+
+		b := generated.Badge{ID: rule.ID, Name: rule.Name, Description: rule.Description, Icon: rule.Icon}
+		awarded = append(awarded, b)
+		e.publish(ctx, AwardEvent{UserID: userID, Badge: b, AwardedAt: time.Now()})
+	}
+	return awarded, nil
+}
+
+// MetricValue returns the user's current value for a rule metric.
+// ok is false for metrics the engine doesn't know.
+func MetricValue(m generated.GetUserBadgeMetricsRow, metric string) (value float64, ok bool) {
+	switch metric {
+	case MetricCompletedSessions:
+		return float64(m.CompletedSessions), true
+	case MetricGreenSessions:
+		return float64(m.GreenSessions), true
+	case MetricNightSessions:
+		return float64(m.NightSessions), true
+	case MetricMorningSessions:
+		return float64(m.MorningSessions), true
+	case MetricWeekendSessions:
+		return float64(m.WeekendSessions), true
+	case MetricOutskirtSessions:
+		return float64(m.OutskirtSessions), true
+	case MetricDistinctStations:
+		return float64(m.DistinctStations), true
+	case MetricCo2Saved:
+		return m.Co2Saved, true
+	}
+	return 0, false
+}
+
+func (e *Engine) publish(ctx context.Context, ev AwardEvent) {
+	for _, l := range e.listeners {
+		func() {
+			// A failing listener must not undo or block the award
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("badge: award listener panicked: %v", r)
+				}
+			}()
+			l(ctx, ev)
+		}()
+	}
+}
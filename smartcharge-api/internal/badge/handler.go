@@ -1,9 +1,15 @@
 package badge
 
 import (
+	"io"
+	"strconv"
+	"strings"
+
 	"github.com/gin-gonic/gin"
 
 	apperrors "smartcharge-api/internal/errors"
+	"smartcharge-api/internal/middleware"
+	"smartcharge-api/internal/policy"
 	"smartcharge-api/internal/response"
 )
 
@@ -18,15 +24,65 @@ func NewHandler(service *Service) *Handler {
 }
 
 // RegisterRoutes registers badge routes on the given router group.
-func (h *Handler) RegisterRoutes(rg *gin.RouterGroup) {
+func (h *Handler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
 	badges := rg.Group("/badges")
 
 	badges.GET("", h.List)
+
+	rg.GET("/users/:id/badges/progress", authMiddleware, h.Progress)
+
+	// Badge catalog management
+	admin := rg.Group("/admin/badges", authMiddleware, middleware.RequireRole(policy.RoleAdmin))
+	admin.GET("", h.AdminList)
+	admin.POST("", h.Create)
+	admin.GET("/:id", h.AdminGet)
+	admin.PUT("/:id", h.Update)
+	admin.POST("/:id/retire", h.Retire)
+	admin.PUT("/:id/rule", h.SetRule)
+	admin.DELETE("/:id/rule", h.DeleteRule)
+	admin.PUT("/:id/translations/:locale", h.SetTranslation)
+	admin.DELETE("/:id/translations/:locale", h.DeleteTranslation)
+	admin.PUT("/:id/icon", h.UploadIcon)
+	admin.DELETE("/:id/icon", h.DeleteIcon)
 }
 
 // List handles GET /v1/badges.
 func (h *Handler) List(c *gin.Context) {
-	badges, err := h.service.List(c.Request.Context())
+	badges, err := h.service.List(c.Request.Context(), requestLocale(c))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, badges)
+}
+
+// Progress handles GET /v1/users/:id/badges/progress.
+func (h *Handler) Progress(c *gin.Context) {
+	val, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "Invalid user ID")
+		return
+	}
+	id := int32(val)
+
+	// Users can only see their own progress
+	userID, ok := middleware.GetUserID(c)
+	if !ok || userID != id {
+		response.Err(c, 403, "AUTH_FORBIDDEN", "You can only view your own badge progress")
+		return
+	}
+
+	progress, err := h.service.Progress(c.Request.Context(), id, requestLocale(c))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, progress)
+}
+
+// AdminList handles GET /v1/admin/badges.
+func (h *Handler) AdminList(c *gin.Context) {
+	badges, err := h.service.AdminList(c.Request.Context())
 	if err != nil {
 		handleError(c, err)
 		return
@@ -34,8 +90,215 @@ func (h *Handler) List(c *gin.Context) {
 	response.OK(c, badges)
 }
 
+// AdminGet handles GET /v1/admin/badges/:id.
+func (h *Handler) AdminGet(c *gin.Context) {
+	id, err := parseID(c)
+	if err != nil {
+		return
+	}
+
+	result, err := h.service.AdminGet(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// Create handles POST /v1/admin/badges.
+func (h *Handler) Create(c *gin.Context) {
+	var req CreateBadgeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "name, description and icon are required")
+		return
+	}
+
+	result, err := h.service.Create(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.Created(c, result)
+}
+
+// Update handles PUT /v1/admin/badges/:id.
+func (h *Handler) Update(c *gin.Context) {
+	id, err := parseID(c)
+	if err != nil {
+		return
+	}
+
+	var req UpdateBadgeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "Invalid request body")
+		return
+	}
+
+	result, err := h.service.Update(c.Request.Context(), id, req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// Retire handles POST /v1/admin/badges/:id/retire.
+func (h *Handler) Retire(c *gin.Context) {
+	id, err := parseID(c)
+	if err != nil {
+		return
+	}
+
+	result, err := h.service.Retire(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// SetRule handles PUT /v1/admin/badges/:id/rule.
+func (h *Handler) SetRule(c *gin.Context) {
+	id, err := parseID(c)
+	if err != nil {
+		return
+	}
+
+	var req RuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "metric and a positive threshold are required")
+		return
+	}
+
+	result, err := h.service.SetRule(c.Request.Context(), id, req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// DeleteRule handles DELETE /v1/admin/badges/:id/rule.
+func (h *Handler) DeleteRule(c *gin.Context) {
+	id, err := parseID(c)
+	if err != nil {
+		return
+	}
+
+	result, err := h.service.DeleteRule(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// SetTranslation handles PUT /v1/admin/badges/:id/translations/:locale.
+func (h *Handler) SetTranslation(c *gin.Context) {
+	id, err := parseID(c)
+	if err != nil {
+		return
+	}
+
+	var req TranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "name and description are required")
+		return
+	}
+
+	result, err := h.service.SetTranslation(c.Request.Context(), id, c.Param("locale"), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// DeleteTranslation handles DELETE /v1/admin/badges/:id/translations/:locale.
+func (h *Handler) DeleteTranslation(c *gin.Context) {
+	id, err := parseID(c)
+	if err != nil {
+		return
+	}
+
+	result, err := h.service.DeleteTranslation(c.Request.Context(), id, c.Param("locale"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// UploadIcon handles PUT /v1/admin/badges/:id/icon with a multipart "icon" file.
+func (h *Handler) UploadIcon(c *gin.Context) {
+	id, err := parseID(c)
+	if err != nil {
+		return
+	}
+
+	header, err := c.FormFile("icon")
+	if err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "icon file is required")
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "Could not read icon file")
+		return
+	}
+	defer file.Close()
+
+	// Read one byte past the limit so oversized files are rejected by the service
+	data, err := io.ReadAll(io.LimitReader(file, MaxIconSize+1))
+	if err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "Could not read icon file")
+		return
+	}
+
+	result, err := h.service.UploadIcon(c.Request.Context(), id, data)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// DeleteIcon handles DELETE /v1/admin/badges/:id/icon.
+func (h *Handler) DeleteIcon(c *gin.Context) {
+	id, err := parseID(c)
+	if err != nil {
+		return
+	}
+
+	result, err := h.service.DeleteIcon(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
 // --- helpers ---
 
+// requestLocale picks the locale from ?locale=, falling back to the first Accept-Language entry.
+func requestLocale(c *gin.Context) string {
+	if locale := c.Query("locale"); locale != "" {
+		return NormalizeLocale(locale)
+	}
+	first, _, _ := strings.Cut(c.GetHeader("Accept-Language"), ",")
+	tag, _, _ := strings.Cut(first, ";")
+	return NormalizeLocale(tag)
+}
+
+func parseID(c *gin.Context) (int32, error) {
+	val, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "Invalid badge ID")
+		return 0, err
+	}
+	return int32(val), nil
+}
+
 func handleError(c *gin.Context, err error) {
 	if appErr, ok := err.(*apperrors.AppError); ok {
 		response.Err(c, appErr.StatusCode, appErr.Code, appErr.Message)
@@ -0,0 +1,102 @@
+package badge
+
+import (
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"smartcharge-api/db/generated"
+	"smartcharge-api/internal/apitest"
+)
+
+// pngIcon is a 1×1 transparent PNG.
+var pngIcon, _ = base64.StdEncoding.DecodeString("iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAQAAAC1HAwCAAAAC0lEQVR42mNkYAAAAAYAAjCB0C8AAAAASUVORK5CYII=")
+
+func TestAdminRoutesAuthorization(t *testing.T) {
+	adminOnly := map[string]int{"driver": 403, "owning operator": 403, "other operator": 403, "admin": 200}
+	tests := []struct {
+		name  string
+		route apitest.Route
+		write string         // the query that makes the change
+		want  map[string]int // status by apitest.Actors name
+	}{
+		{
+			name:  "create",
+			route: apitest.Route{Method: http.MethodPost, Path: "/v1/admin/badges", Body: CreateBadgeRequest{Name: "Gece kuşu", Description: "Gece şarjı", Icon: "moon"}},
+			write: "CreateBadge",
+			want:  map[string]int{"driver": 403, "owning operator": 403, "other operator": 403, "admin": 201},
+		},
+		{
+			name:  "update",
+			route: apitest.Route{Method: http.MethodPut, Path: "/v1/admin/badges/3", Body: map[string]any{"name": "Gece baykuşu"}},
+			write: "UpdateBadge",
+			want:  adminOnly,
+		},
+		{
+			name:  "retire",
+			route: apitest.Route{Method: http.MethodPost, Path: "/v1/admin/badges/3/retire"},
+			write: "RetireBadge",
+			want:  adminOnly,
+		},
+		{
+			name:  "set rule",
+			route: apitest.Route{Method: http.MethodPut, Path: "/v1/admin/badges/3/rule", Body: RuleRequest{Metric: MetricNightSessions, Threshold: 5}},
+			write: "UpsertBadgeRule",
+			want:  adminOnly,
+		},
+		{
+			name:  "delete rule",
+			route: apitest.Route{Method: http.MethodDelete, Path: "/v1/admin/badges/3/rule"},
+			write: "DeleteBadgeRule",
+			want:  adminOnly,
+		},
+		{
+			name:  "set translation",
+			route: apitest.Route{Method: http.MethodPut, Path: "/v1/admin/badges/3/translations/en", Body: TranslationRequest{Name: "Night owl", Description: "Night charging"}},
+			write: "UpsertBadgeTranslation",
+			want:  adminOnly,
+		},
+		{
+			name:  "delete translation",
+			route: apitest.Route{Method: http.MethodDelete, Path: "/v1/admin/badges/3/translations/en"},
+			write: "DeleteBadgeTranslation",
+			want:  adminOnly,
+		},
+		{
+			name:  "upload icon",
+			route: apitest.Route{Method: http.MethodPut, Path: "/v1/admin/badges/3/icon", Files: map[string][]byte{"icon": pngIcon}},
+			write: "SetBadgeIconURL",
+			want:  adminOnly,
+		},
+		{
+			name:  "delete icon",
+			route: apitest.Route{Method: http.MethodDelete, Path: "/v1/admin/badges/3/icon"},
+			write: "SetBadgeIconURL",
+			want:  adminOnly,
+		},
+	}
+
+	for _, tt := range tests {
+		for name, want := range tt.want {
+			actor := apitest.Actors[name]
+			t.Run(tt.name+"/"+name, func(t *testing.T) {
+				db := apitest.NewDB().
+					On("GetBadgeByID", generated.Badge{ID: 3, Name: "Gece kuşu", Description: "Gece şarjı", Icon: "moon", IconUrl: pgtype.Text{String: "/assets/badges/3-1.png", Valid: true}})
+				queries := generated.New(db)
+				h := NewHandler(&Service{queries: queries, pool: db, engine: NewEngine(queries), icons: NewIconStore(t.TempDir())})
+				r := apitest.Router(actor, func(v1 *gin.RouterGroup, auth gin.HandlerFunc) { h.RegisterRoutes(v1, auth) })
+
+				w := apitest.Do(r, tt.route)
+				if w.Code != want {
+					t.Fatalf("status = %d, want %d: %s", w.Code, want, w.Body)
+				}
+				if wrote := db.Called(tt.write); wrote != (want != http.StatusForbidden) {
+					t.Errorf("%s called = %v with status %d", tt.write, wrote, w.Code)
+				}
+			})
+		}
+	}
+}
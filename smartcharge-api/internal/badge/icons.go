@@ -0,0 +1,67 @@
+package badge
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	apperrors "smartcharge-api/internal/errors"
+)
+
+// MaxIconSize is the largest icon upload accepted, in bytes.
+const MaxIconSize = 512 << 10
+
+// iconExtensions maps the accepted icon content types to file extensions.
+// SVG is deliberately excluded since it can carry scripts.
+var iconExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/webp": ".webp",
+}
+
+// IconStore keeps uploaded badge icons on disk under dir and serves them from urlPrefix.
+type IconStore struct {
+	dir       string
+	urlPrefix string
+}
+
+// NewIconStore creates an icon store writing to assetsDir/badges, which the router serves as /assets.
+func NewIconStore(assetsDir string) *IconStore {
+	return &IconStore{dir: filepath.Join(assetsDir, "badges"), urlPrefix: "/assets/badges/"}
+}
+
+// Save validates and writes an icon for the badge and returns its public URL.
+// File names carry a timestamp so clients never see a stale cached icon.
+func (s *IconStore) Save(badgeID int32, data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", apperrors.NewValidationError("Icon file is empty")
+	}
+	if len(data) > MaxIconSize {
+		return "", apperrors.NewValidationError(fmt.Sprintf("Icon must be at most %d KB", MaxIconSize>>10))
+	}
+	ext, ok := iconExtensions[http.DetectContentType(data)]
+	if !ok {
+		return "", apperrors.NewValidationError("Icon must be a PNG, JPEG or WebP image")
+	}
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%d-%d%s", badgeID, time.Now().UnixNano(), ext)
+	if err := os.WriteFile(filepath.Join(s.dir, name), data, 0o644); err != nil {
+		return "", err
+	}
+	return s.urlPrefix + name, nil
+}
+
+// Remove deletes a previously saved icon. URLs not owned by the store are ignored.
+func (s *IconStore) Remove(url string) {
+	if !strings.HasPrefix(url, s.urlPrefix) {
+		return
+	}
+	_ = os.Remove(filepath.Join(s.dir, path.Base(url)))
+}
@@ -2,6 +2,12 @@ package badge
 
 import (
 	"context"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 
 	"smartcharge-api/db/generated"
 	apperrors "smartcharge-api/internal/errors"
@@ -10,36 +16,137 @@ import (
 // Service handles badge business logic.
 type Service struct {
 	queries *generated.Queries
+	pool    txBeginner
+	engine  *Engine
+	icons   *IconStore
 }
 
-// NewService creates a new badge service.
-func NewService(queries *generated.Queries) *Service {
-	return &Service{queries: queries}
+// txBeginner starts the transactions the service writes in; *pgxpool.Pool implements it.
+type txBeginner interface {
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
 }
 
-// BadgeResponse is the response DTO for a badge.
-type BadgeResponse struct {
-	ID          int32  `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Icon        string `json:"icon"`
+// NewService creates a new badge service.
+func NewService(queries *generated.Queries, pool *pgxpool.Pool, engine *Engine, icons *IconStore) *Service {
+	return &Service{queries: queries, pool: pool, engine: engine, icons: icons}
 }
 
-// List returns all badges sorted by name ASC.
-func (s *Service) List(ctx context.Context) ([]BadgeResponse, error) {
+// List returns all active badges sorted by name ASC.
+// When locale is set, names and descriptions are replaced by their translation where one exists.
+func (s *Service) List(ctx context.Context, locale string) ([]BadgeResponse, error) {
 	badges, err := s.queries.ListBadges(ctx)
 	if err != nil {
 		return nil, apperrors.ErrInternal
 	}
 
+	translations, err := s.translationsFor(ctx, locale)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
 	result := make([]BadgeResponse, len(badges))
 	for i, b := range badges {
-		result[i] = BadgeResponse{
+		item := BadgeResponse{
 			ID:          b.ID,
 			Name:        b.Name,
 			Description: b.Description,
 			Icon:        b.Icon,
+			IconURL:     optionalText(b.IconUrl),
+		}
+		if t, ok := translations[b.ID]; ok {
+			item.Name, item.Description = t.Name, t.Description
+		}
+		result[i] = item
+	}
+	return result, nil
+}
+
+// Progress returns the user's progress towards every active badge that has a rule, sorted by name ASC.
+// Users the engine hasn't evaluated yet are evaluated first so every rule has a value.
+func (s *Service) Progress(ctx context.Context, userID int32, locale string) ([]ProgressResponse, error) {
+	if _, err := s.queries.GetUserByID(ctx, userID); err != nil {
+		return nil, apperrors.NewNotFoundError("User")
+	}
+
+	rows, err := s.queries.ListUserBadgeProgress(ctx, userID)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	for _, r := range rows {
+		if !r.CurrentValue.Valid {
+			if _, err := s.engine.Evaluate(ctx, userID); err != nil {
+				return nil, apperrors.ErrInternal
+			}
+			if rows, err = s.queries.ListUserBadgeProgress(ctx, userID); err != nil {
+				return nil, apperrors.ErrInternal
+			}
+			break
+		}
+	}
+
+	translations, err := s.translationsFor(ctx, locale)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	result := make([]ProgressResponse, len(rows))
+	for i, r := range rows {
+		item := ProgressResponse{
+			BadgeID:     r.BadgeID,
+			Name:        r.Name,
+			Description: r.Description,
+			Icon:        r.Icon,
+			IconURL:     optionalText(r.IconUrl),
+			Metric:      r.Metric,
+			Current:     math.Round(r.CurrentValue.Float64*100) / 100,
+			Target:      r.Threshold,
+			Earned:      r.EarnedAt.Valid,
+			EarnedAt:    optionalTime(r.EarnedAt),
+			UpdatedAt:   optionalTime(r.ProgressUpdatedAt),
+		}
+		if t, ok := translations[r.BadgeID]; ok {
+			item.Name, item.Description = t.Name, t.Description
+		}
+		switch {
+		case item.Earned || r.CurrentValue.Float64 >= r.Threshold:
+			item.Percentage = 100
+		case r.Threshold > 0:
+			item.Percentage = math.Round(r.CurrentValue.Float64/r.Threshold*10000) / 100
 		}
+		result[i] = item
 	}
 	return result, nil
 }
+
+// translationsFor returns the badge translations for locale keyed by badge ID.
+// The default locale has no translations, so nothing is looked up for it.
+func (s *Service) translationsFor(ctx context.Context, locale string) (map[int32]generated.BadgeTranslation, error) {
+	if locale == "" || locale == DefaultLocale {
+		return nil, nil
+	}
+	rows, err := s.queries.ListBadgeTranslationsByLocale(ctx, locale)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[int32]generated.BadgeTranslation, len(rows))
+	for _, t := range rows {
+		out[t.BadgeID] = t
+	}
+	return out, nil
+}
+
+func optionalText(t pgtype.Text) *string {
+	if !t.Valid {
+		return nil
+	}
+	return &t.String
+}
+
+func optionalTime(t pgtype.Timestamptz) *string {
+	if !t.Valid {
+		return nil
+	}
+	s := t.Time.UTC().Format(time.RFC3339)
+	return &s
+}
@@ -0,0 +1,34 @@
+package calendar
+
+// --- Request DTOs ---
+
+// CreateEventRequest is the request body for POST /v1/calendar.
+type CreateEventRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Kind     string `json:"kind,omitempty"`              // HOLIDAY or EVENT (default)
+	StartsAt string `json:"startsAt" binding:"required"` // RFC 3339
+	EndsAt   string `json:"endsAt" binding:"required"`   // RFC 3339
+	// StationID is the station the event affects; omit it for every station (admins only)
+	StationID *int32 `json:"stationId,omitempty"`
+	// Impact is the expected change in load (-100 to 100); omit it to learn from past events of the same name or kind
+	Impact *int32 `json:"impact,omitempty"`
+}
+
+// --- Response DTOs ---
+
+// EventResponse is a holiday or event in the forecast calendar.
+type EventResponse struct {
+	ID        int32  `json:"id"`
+	Name      string `json:"name"`
+	Kind      string `json:"kind"`
+	StartsAt  string `json:"startsAt"`
+	EndsAt    string `json:"endsAt"`
+	StationID *int32 `json:"stationId"` // nil for every station
+	Impact    *int32 `json:"impact"`    // nil when learned from history
+	CreatedAt string `json:"createdAt"`
+}
+
+// ImportResponse reports how many events a calendar import added or updated.
+type ImportResponse struct {
+	Imported int64 `json:"imported"`
+}
@@ -0,0 +1,176 @@
+package calendar
+
+import (
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	apperrors "smartcharge-api/internal/errors"
+	"smartcharge-api/internal/forecast"
+	"smartcharge-api/internal/middleware"
+	"smartcharge-api/internal/policy"
+	"smartcharge-api/internal/pricing"
+	"smartcharge-api/internal/response"
+)
+
+// Handler handles HTTP requests for the holiday and event calendar.
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new calendar handler.
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers calendar routes on the given router group.
+func (h *Handler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	calendar := rg.Group("/calendar", authMiddleware)
+	calendar.GET("", h.List)
+	calendar.POST("", h.Create)
+	calendar.DELETE("/:id", h.Delete)
+
+	admin := rg.Group("/admin/calendar", authMiddleware, middleware.RequireRole(policy.RoleAdmin))
+	admin.POST("/import", h.Import)
+}
+
+// List handles GET /v1/calendar.
+// ?stationId= adds the station's own events to the public ones; ?from= and ?to= (YYYY-MM-DD) default
+// to the forecast horizon from today.
+func (h *Handler) List(c *gin.Context) {
+	var stationID *int32
+	if raw := c.Query("stationId"); raw != "" {
+		val, err := strconv.Atoi(raw)
+		if err != nil {
+			response.Err(c, 400, "VALIDATION_ERROR", "Invalid station ID")
+			return
+		}
+		id := int32(val)
+		stationID = &id
+	}
+
+	now := time.Now().In(pricing.SlotLocation)
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, pricing.SlotLocation)
+	if raw := c.Query("from"); raw != "" {
+		t, err := time.ParseInLocation("2006-01-02", raw, pricing.SlotLocation)
+		if err != nil {
+			response.Err(c, 400, "VALIDATION_ERROR", "from must be a date (YYYY-MM-DD)")
+			return
+		}
+		from = t
+	}
+	to := from.Add(forecast.Horizon)
+	if raw := c.Query("to"); raw != "" {
+		t, err := time.ParseInLocation("2006-01-02", raw, pricing.SlotLocation)
+		if err != nil || !t.After(from) {
+			response.Err(c, 400, "VALIDATION_ERROR", "to must be a date (YYYY-MM-DD) after from")
+			return
+		}
+		to = t
+	}
+
+	result, err := h.service.List(c.Request.Context(), stationID, from, to)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// Create handles POST /v1/calendar.
+func (h *Handler) Create(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	var req CreateEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "name, startsAt and endsAt are required")
+		return
+	}
+
+	result, err := h.service.Create(c.Request.Context(), actor, req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.Created(c, result)
+}
+
+// Delete handles DELETE /v1/calendar/:id.
+func (h *Handler) Delete(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+	id, err := parseID(c)
+	if err != nil {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), actor, id); err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, gin.H{"message": "Event deleted"})
+}
+
+// Import handles POST /v1/admin/calendar/import.
+// Expects a multipart form with an iCalendar file under "calendar" and optionally the events' "kind".
+func (h *Handler) Import(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	header, err := c.FormFile("calendar")
+	if err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "calendar file is required")
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "Could not read calendar file")
+		return
+	}
+	defer file.Close()
+
+	// Read one byte past the limit so oversized files are rejected by the service
+	data, err := io.ReadAll(io.LimitReader(file, MaxImportSize+1))
+	if err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "Could not read calendar file")
+		return
+	}
+
+	result, err := h.service.Import(c.Request.Context(), actor, data, c.PostForm("kind"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// --- helpers ---
+
+func parseID(c *gin.Context) (int32, error) {
+	val, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "Invalid event ID")
+		return 0, err
+	}
+	return int32(val), nil
+}
+
+func handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*apperrors.AppError); ok {
+		response.Err(c, appErr.StatusCode, appErr.Code, appErr.Message)
+		return
+	}
+	response.Err(c, 500, "INTERNAL_ERROR", "An unexpected error occurred")
+}
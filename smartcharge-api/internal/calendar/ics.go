@@ -0,0 +1,133 @@
+package calendar
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"smartcharge-api/internal/pricing"
+)
+
+// icsEvent is a VEVENT read from an iCalendar file.
+type icsEvent struct {
+	Summary    string
+	Start, End time.Time
+}
+
+// parseICS reads the events of an iCalendar (RFC 5545) file, such as a public holiday calendar export.
+// Only SUMMARY, DTSTART and DTEND are used. All-day and floating times are taken in pricing.SlotLocation.
+// An event without DTEND lasts one day if it is all-day and one hour otherwise.
+func parseICS(data []byte) ([]icsEvent, error) {
+	var events []icsEvent
+	var cur *icsEvent
+	allDay := false
+
+	for _, line := range unfoldICS(data) {
+		name, params, value, ok := splitICSLine(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			cur, allDay = &icsEvent{}, false
+		case cur == nil:
+			// Outside an event
+		case name == "END" && value == "VEVENT":
+			if cur.Start.IsZero() {
+				return nil, fmt.Errorf("event %d has no DTSTART", len(events)+1)
+			}
+			if cur.End.IsZero() {
+				if allDay {
+					cur.End = cur.Start.AddDate(0, 0, 1)
+				} else {
+					cur.End = cur.Start.Add(time.Hour)
+				}
+			}
+			if !cur.End.After(cur.Start) {
+				return nil, fmt.Errorf("event %d (%s) ends before it starts", len(events)+1, cur.Summary)
+			}
+			if strings.TrimSpace(cur.Summary) == "" {
+				return nil, fmt.Errorf("event %d has no SUMMARY", len(events)+1)
+			}
+			events = append(events, *cur)
+			cur = nil
+		case name == "SUMMARY":
+			cur.Summary = unescapeICS(value)
+		case name == "DTSTART" || name == "DTEND":
+			t, date, err := parseICSTime(params, value)
+			if err != nil {
+				return nil, fmt.Errorf("event %d: %v", len(events)+1, err)
+			}
+			if name == "DTSTART" {
+				cur.Start, allDay = t, date
+			} else {
+				cur.End = t
+			}
+		}
+	}
+	return events, nil
+}
+
+// unfoldICS splits the file into logical lines, joining continuation lines (those starting with a space or tab).
+func unfoldICS(data []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// splitICSLine splits "NAME;PARAM=VALUE:value" into its upper-cased name, parameters and value.
+func splitICSLine(line string) (name string, params map[string]string, value string, ok bool) {
+	head, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return "", nil, "", false
+	}
+	parts := strings.Split(head, ";")
+	params = make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		if k, v, found := strings.Cut(p, "="); found {
+			params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+		}
+	}
+	return strings.ToUpper(parts[0]), params, strings.TrimSpace(value), true
+}
+
+// parseICSTime parses a DTSTART or DTEND value, reporting whether it is an all-day date.
+func parseICSTime(params map[string]string, value string) (time.Time, bool, error) {
+	if params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", value, pricing.SlotLocation)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+
+	loc := pricing.SlotLocation
+	if tzid := params["TZID"]; tzid != "" {
+		l, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("unknown time zone %q", tzid)
+		}
+		loc = l
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	return t, false, err
+}
+
+// unescapeICS undoes iCalendar text escaping.
+func unescapeICS(s string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
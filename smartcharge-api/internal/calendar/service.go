@@ -0,0 +1,248 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"smartcharge-api/db/generated"
+	apperrors "smartcharge-api/internal/errors"
+	"smartcharge-api/internal/forecast"
+	"smartcharge-api/internal/policy"
+)
+
+// MaxImportSize caps an uploaded iCalendar file.
+const MaxImportSize = 1 << 20
+
+// maxImportEvents caps the events in one import.
+const maxImportEvents = 1000
+
+// Service handles the holiday and event calendar that forecasts take into account.
+type Service struct {
+	queries *generated.Queries
+	pool    forecast.TxBeginner
+}
+
+// NewService creates a new calendar service.
+func NewService(queries *generated.Queries, pool *pgxpool.Pool) *Service {
+	return &Service{queries: queries, pool: pool}
+}
+
+// List returns the events overlapping [from, to) that apply to the station: public events and the station's own.
+// Without a station, only public events.
+func (s *Service) List(ctx context.Context, stationID *int32, from, to time.Time) ([]EventResponse, error) {
+	params := generated.ListCalendarEventsParams{
+		WindowStart: pgtype.Timestamptz{Time: from, Valid: true},
+		WindowEnd:   pgtype.Timestamptz{Time: to, Valid: true},
+	}
+	if stationID != nil {
+		params.StationID = pgtype.Int4{Int32: *stationID, Valid: true}
+	}
+
+	rows, err := s.queries.ListCalendarEvents(ctx, params)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	result := make([]EventResponse, len(rows))
+	for i, e := range rows {
+		result[i] = toEventResponse(e)
+	}
+	return result, nil
+}
+
+// Create adds an event to the calendar. Operators annotate their own stations; only admins add events
+// for every station. The station's forecast is retrained straight away so the event shows in it.
+func (s *Service) Create(ctx context.Context, actor policy.Actor, req CreateEventRequest) (*EventResponse, error) {
+	if err := policy.RequireRole(actor, policy.RoleOperator); err != nil {
+		return nil, err
+	}
+
+	params := generated.CreateCalendarEventParams{
+		Name:      strings.TrimSpace(req.Name),
+		Kind:      req.Kind,
+		CreatedBy: pgtype.Int4{Int32: actor.UserID, Valid: true},
+	}
+	if params.Kind == "" {
+		params.Kind = forecast.EventSpecial
+	}
+	if err := validateEvent(params.Name, params.Kind); err != nil {
+		return nil, err
+	}
+
+	startsAt, err := time.Parse(time.RFC3339, req.StartsAt)
+	if err != nil {
+		return nil, apperrors.NewValidationError("startsAt must be an RFC 3339 timestamp")
+	}
+	endsAt, err := time.Parse(time.RFC3339, req.EndsAt)
+	if err != nil {
+		return nil, apperrors.NewValidationError("endsAt must be an RFC 3339 timestamp")
+	}
+	if !endsAt.After(startsAt) {
+		return nil, apperrors.NewValidationError("endsAt must be after startsAt")
+	}
+	params.StartsAt = pgtype.Timestamptz{Time: startsAt, Valid: true}
+	params.EndsAt = pgtype.Timestamptz{Time: endsAt, Valid: true}
+
+	if req.Impact != nil {
+		if *req.Impact < -100 || *req.Impact > 100 {
+			return nil, apperrors.NewValidationError("impact must be between -100 and 100")
+		}
+		params.Impact = pgtype.Int4{Int32: *req.Impact, Valid: true}
+	}
+
+	if req.StationID == nil {
+		if err := policy.RequireRole(actor, policy.RoleAdmin); err != nil {
+			return nil, err
+		}
+	} else {
+		station, err := s.queries.GetStationByID(ctx, *req.StationID)
+		if err != nil {
+			return nil, apperrors.NewNotFoundError("Station")
+		}
+		if err := policy.RequireStationOwner(actor, station.OwnerID); err != nil {
+			return nil, err
+		}
+		params.StationID = pgtype.Int4{Int32: station.ID, Valid: true}
+	}
+
+	event, err := s.queries.CreateCalendarEvent(ctx, params)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	s.retrain(ctx, event.StationID)
+
+	resp := toEventResponse(event)
+	return &resp, nil
+}
+
+// Delete removes an event: public ones by admins, a station's own by the station's owner.
+func (s *Service) Delete(ctx context.Context, actor policy.Actor, id int32) error {
+	event, err := s.queries.GetCalendarEvent(ctx, id)
+	if err != nil {
+		return apperrors.NewNotFoundError("Event")
+	}
+
+	if !event.StationID.Valid {
+		if err := policy.RequireRole(actor, policy.RoleAdmin); err != nil {
+			return err
+		}
+	} else {
+		station, err := s.queries.GetStationByID(ctx, event.StationID.Int32)
+		if err != nil {
+			return apperrors.NewNotFoundError("Station")
+		}
+		if err := policy.RequireStationOwner(actor, station.OwnerID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.queries.DeleteCalendarEvent(ctx, id); err != nil {
+		return apperrors.ErrInternal
+	}
+	s.retrain(ctx, event.StationID)
+	return nil
+}
+
+// Import adds the events of an iCalendar file, such as a public holiday calendar, for every station.
+// Events already imported under the same name and start are updated instead of duplicated.
+// Forecasts pick them up on their next retraining.
+func (s *Service) Import(ctx context.Context, actor policy.Actor, data []byte, kind string) (*ImportResponse, error) {
+	if len(data) > MaxImportSize {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("Calendar must be at most %d KB", MaxImportSize/1024))
+	}
+	if kind == "" {
+		kind = forecast.EventHoliday
+	}
+	if !validKind(kind) {
+		return nil, apperrors.NewValidationError("kind must be HOLIDAY or EVENT")
+	}
+
+	events, err := parseICS(data)
+	if err != nil {
+		return nil, apperrors.NewValidationError("Invalid iCalendar file: " + err.Error())
+	}
+	if len(events) == 0 {
+		return nil, apperrors.NewValidationError("The calendar has no events")
+	}
+	if len(events) > maxImportEvents {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("Import at most %d events at a time", maxImportEvents))
+	}
+
+	params := generated.ImportCalendarEventsParams{
+		Kind:      kind,
+		CreatedBy: pgtype.Int4{Int32: actor.UserID, Valid: true},
+	}
+	for _, e := range events {
+		name := strings.TrimSpace(e.Summary)
+		if err := validateEvent(name, kind); err != nil {
+			return nil, err
+		}
+		params.Names = append(params.Names, name)
+		params.StartsAt = append(params.StartsAt, pgtype.Timestamptz{Time: e.Start, Valid: true})
+		params.EndsAt = append(params.EndsAt, pgtype.Timestamptz{Time: e.End, Valid: true})
+	}
+
+	imported, err := s.queries.ImportCalendarEvents(ctx, params)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	return &ImportResponse{Imported: imported}, nil
+}
+
+// --- helpers ---
+
+func validateEvent(name, kind string) error {
+	if name == "" {
+		return apperrors.NewValidationError("name is required")
+	}
+	if len(name) > 100 {
+		return apperrors.NewValidationError(fmt.Sprintf("Event name %q is longer than 100 characters", name))
+	}
+	if !validKind(kind) {
+		return apperrors.NewValidationError("kind must be HOLIDAY or EVENT")
+	}
+	return nil
+}
+
+func validKind(kind string) bool {
+	return kind == forecast.EventHoliday || kind == forecast.EventSpecial
+}
+
+// retrain refreshes the forecast of the station an event belongs to. Public events wait for the scheduled
+// retraining, as they affect every station. Failures are logged; the event itself is saved either way.
+func (s *Service) retrain(ctx context.Context, stationID pgtype.Int4) {
+	if !stationID.Valid {
+		return
+	}
+	station, err := s.queries.GetStationByID(ctx, stationID.Int32)
+	if err != nil {
+		return
+	}
+	if _, err := forecast.Train(ctx, s.pool, s.queries, forecast.Lookup(station.ForecastModel), station.ID, time.Now()); err != nil {
+		log.Printf("forecast retraining failed for station %d: %v", station.ID, err)
+	}
+}
+
+func toEventResponse(e generated.CalendarEvent) EventResponse {
+	resp := EventResponse{
+		ID:        e.ID,
+		Name:      e.Name,
+		Kind:      e.Kind,
+		StartsAt:  e.StartsAt.Time.UTC().Format(time.RFC3339),
+		EndsAt:    e.EndsAt.Time.UTC().Format(time.RFC3339),
+		CreatedAt: e.CreatedAt.Time.UTC().Format(time.RFC3339),
+	}
+	if e.StationID.Valid {
+		resp.StationID = &e.StationID.Int32
+	}
+	if e.Impact.Valid {
+		resp.Impact = &e.Impact.Int32
+	}
+	return resp
+}
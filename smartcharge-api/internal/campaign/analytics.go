@@ -0,0 +1,181 @@
+package campaign
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"smartcharge-api/db/generated"
+	apperrors "smartcharge-api/internal/errors"
+	"smartcharge-api/internal/policy"
+	"smartcharge-api/internal/pricing"
+)
+
+// Analytics returns the results of one of the operator's campaigns.
+func (s *Service) Analytics(ctx context.Context, actor policy.Actor, campaignID int32) (*AnalyticsResponse, error) {
+	c, err := s.ownedCampaign(ctx, actor, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	return s.analytics(ctx, c, time.Now())
+}
+
+// ListAnalytics returns the results of every campaign the operator owns, newest first.
+func (s *Service) ListAnalytics(ctx context.Context, ownerID int32) ([]AnalyticsResponse, error) {
+	rows, err := s.queries.ListCampaignsByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	now := time.Now()
+	result := make([]AnalyticsResponse, 0, len(rows))
+	for _, row := range rows {
+		c, err := s.queries.GetCampaignByID(ctx, row.ID)
+		if err != nil {
+			return nil, apperrors.ErrInternal
+		}
+		a, err := s.analytics(ctx, c, now)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *a)
+	}
+	return result, nil
+}
+
+// analytics computes a campaign's results. The campaign period runs from its start (or creation)
+// to its end or now, whichever is earlier; the baseline is the equally long period right before it,
+// at least one day. Station-wide figures cover the campaign's station, or all of the operator's
+// stations for a global campaign.
+func (s *Service) analytics(ctx context.Context, c generated.Campaign, now time.Time) (*AnalyticsResponse, error) {
+	start := c.CreatedAt.Time
+	if c.StartDate.Valid {
+		start = c.StartDate.Time
+	}
+	end := now
+	if c.EndDate.Valid && c.EndDate.Time.Before(end) {
+		end = c.EndDate.Time
+	}
+	if end.Before(start) {
+		end = start
+	}
+	length := end.Sub(start)
+	if length < 24*time.Hour {
+		length = 24 * time.Hour
+	}
+
+	attribution, err := s.queries.GetCampaignAttribution(ctx, c.ID)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	during, err := s.periodStats(ctx, c, start, start.Add(length))
+	if err != nil {
+		return nil, err
+	}
+	baseline, err := s.periodStats(ctx, c, start.Add(-length), start)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &AnalyticsResponse{
+		CampaignID:  c.ID,
+		Title:       c.Title,
+		Status:      c.Status,
+		PeriodStart: start.UTC().Format(time.RFC3339),
+		PeriodEnd:   end.UTC().Format(time.RFC3339),
+
+		Reservations:          attribution.Reservations,
+		CompletedReservations: attribution.CompletedReservations,
+		UniqueDrivers:         attribution.UniqueDrivers,
+		GreenShare:            share(attribution.GreenReservations, attribution.Reservations),
+		CoinsSpent:            attribution.CoinBonus,
+		DiscountCost:          pricing.SessionDiscount(attribution.Discount),
+
+		CampaignPeriod:        during,
+		Baseline:              baseline,
+		IncrementalGreenShare: pricing.RoundTo2(during.GreenShare - baseline.GreenShare),
+	}
+	if baseline.ReservationsPerDay > 0 {
+		lift := pricing.RoundTo2((during.ReservationsPerDay/baseline.ReservationsPerDay - 1) * 100)
+		resp.ReservationLift = &lift
+	}
+	return resp, nil
+}
+
+func (s *Service) periodStats(ctx context.Context, c generated.Campaign, from, to time.Time) (PeriodStats, error) {
+	row, err := s.queries.GetStationActivity(ctx, generated.GetStationActivityParams{
+		StationID:   c.StationID,
+		OwnerID:     c.OwnerID,
+		PeriodStart: pgtype.Timestamptz{Time: from, Valid: true},
+		PeriodEnd:   pgtype.Timestamptz{Time: to, Valid: true},
+	})
+	if err != nil {
+		return PeriodStats{}, apperrors.ErrInternal
+	}
+
+	days := to.Sub(from).Hours() / 24
+	return PeriodStats{
+		PeriodStart:        from.UTC().Format(time.RFC3339),
+		PeriodEnd:          to.UTC().Format(time.RFC3339),
+		Reservations:       row.Reservations,
+		ReservationsPerDay: pricing.RoundTo2(float64(row.Reservations) / days),
+		GreenShare:         share(row.GreenReservations, row.Reservations),
+	}, nil
+}
+
+// share returns part/total as a percentage, 0 when there is nothing to divide.
+func share(part, total int32) float64 {
+	if total == 0 {
+		return 0
+	}
+	return pricing.RoundTo2(float64(part) / float64(total) * 100)
+}
+
+// analyticsCSVHeader are the columns of the analytics CSV export.
+var analyticsCSVHeader = []string{
+	"campaign_id", "title", "status", "period_start", "period_end",
+	"reservations", "completed_reservations", "unique_drivers", "green_share",
+	"coins_spent", "discount_cost",
+	"station_reservations", "station_reservations_per_day", "station_green_share",
+	"baseline_reservations", "baseline_reservations_per_day", "baseline_green_share",
+	"incremental_green_share", "reservation_lift",
+}
+
+// RenderCSV renders campaign analytics as CSV, one row per campaign.
+func RenderCSV(rows []AnalyticsResponse) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(analyticsCSVHeader); err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	i := func(v int32) string { return strconv.Itoa(int(v)) }
+	for _, a := range rows {
+		lift := ""
+		if a.ReservationLift != nil {
+			lift = f(*a.ReservationLift)
+		}
+		record := []string{
+			i(a.CampaignID), a.Title, a.Status, a.PeriodStart, a.PeriodEnd,
+			i(a.Reservations), i(a.CompletedReservations), i(a.UniqueDrivers), f(a.GreenShare),
+			i(a.CoinsSpent), f(a.DiscountCost),
+			i(a.CampaignPeriod.Reservations), f(a.CampaignPeriod.ReservationsPerDay), f(a.CampaignPeriod.GreenShare),
+			i(a.Baseline.Reservations), f(a.Baseline.ReservationsPerDay), f(a.Baseline.GreenShare),
+			f(a.IncrementalGreenShare), lift,
+		}
+		if err := w.Write(record); err != nil {
+			return nil, apperrors.ErrInternal
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	return buf.Bytes(), nil
+}
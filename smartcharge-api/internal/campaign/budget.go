@@ -0,0 +1,81 @@
+package campaign
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"smartcharge-api/db/generated"
+	apperrors "smartcharge-api/internal/errors"
+	"smartcharge-api/internal/pricing"
+)
+
+// budget holds a campaign's requested caps; NULL means unlimited.
+type budget struct {
+	coins          pgtype.Int4
+	discount       pgtype.Float8
+	maxRedemptions pgtype.Int4
+	maxPerUser     pgtype.Int4
+}
+
+// resolveBudget validates the requested caps. Every cap that is set must be positive.
+func resolveBudget(coins *int32, discount *float64, maxRedemptions, maxPerUser *int32) (budget, error) {
+	var b budget
+	if coins != nil {
+		if *coins <= 0 {
+			return b, apperrors.NewValidationError("coinBudget must be greater than 0")
+		}
+		b.coins = pgtype.Int4{Int32: *coins, Valid: true}
+	}
+	if discount != nil {
+		if *discount <= 0 {
+			return b, apperrors.NewValidationError("discountBudget must be greater than 0")
+		}
+		b.discount = pgtype.Float8{Float64: *discount, Valid: true}
+	}
+	if maxRedemptions != nil {
+		if *maxRedemptions <= 0 {
+			return b, apperrors.NewValidationError("maxRedemptions must be greater than 0")
+		}
+		b.maxRedemptions = pgtype.Int4{Int32: *maxRedemptions, Valid: true}
+	}
+	if maxPerUser != nil {
+		if *maxPerUser <= 0 {
+			return b, apperrors.NewValidationError("maxRedemptionsPerUser must be greater than 0")
+		}
+		b.maxPerUser = pgtype.Int4{Int32: *maxPerUser, Valid: true}
+	}
+	return b, nil
+}
+
+// checkResumable rejects activating a campaign whose new caps are already used up,
+// since the first booking would pause it again.
+func checkResumable(existing generated.Campaign, b budget) error {
+	existing.CoinBudget = b.coins
+	existing.DiscountBudget = b.discount
+	existing.MaxRedemptions = b.maxRedemptions
+	if reason := pricing.ExhaustedReason(existing); reason != "" {
+		return apperrors.NewValidationError(fmt.Sprintf("Campaign has used up its %s cap; raise it to activate the campaign",
+			strings.ToLower(strings.ReplaceAll(reason, "_", " "))))
+	}
+	return nil
+}
+
+func toBudgetResponse(coins pgtype.Int4, discount pgtype.Float8, maxRedemptions, maxPerUser pgtype.Int4,
+	coinsSpent int32, discountSpent float64, redemptions int32) BudgetResponse {
+	resp := BudgetResponse{CoinsSpent: coinsSpent, DiscountSpent: pricing.RoundTo2(discountSpent), Redemptions: redemptions}
+	if coins.Valid {
+		resp.CoinBudget = &coins.Int32
+	}
+	if discount.Valid {
+		resp.DiscountBudget = &discount.Float64
+	}
+	if maxRedemptions.Valid {
+		resp.MaxRedemptions = &maxRedemptions.Int32
+	}
+	if maxPerUser.Valid {
+		resp.MaxRedemptionsPerUser = &maxPerUser.Int32
+	}
+	return resp
+}
@@ -8,11 +8,30 @@ type CreateCampaignRequest struct {
 	Description    string  `json:"description"`
 	Status         string  `json:"status"`
 	Target         string  `json:"target"`
-	Discount       string  `json:"discount"`
+	Discount       string  `json:"discount"` // legacy text such as "%20"; used only when discountType is empty
+	DiscountType   string  `json:"discountType"`
+	DiscountValue  float64 `json:"discountValue"`
 	EndDate        *string `json:"endDate,omitempty"`
 	StationID      *int32  `json:"stationId,omitempty"`
 	CoinReward     *int32  `json:"coinReward,omitempty"`
 	TargetBadgeIDs []int32 `json:"targetBadgeIds,omitempty"`
+	// Eligibility restrictions on top of target badges
+	NewCustomersOnly bool     `json:"newCustomersOnly"`
+	VehicleTypes     []string `json:"vehicleTypes,omitempty"`
+	// Stacking: higher priority applies first; an exclusive campaign never combines with others,
+	// a stackable one can be combined with the top-ranked campaign
+	Priority  int32 `json:"priority"`
+	Exclusive bool  `json:"exclusive"`
+	Stackable bool  `json:"stackable"`
+	// Scheduling: a DRAFT with a startDate goes ACTIVE at that time; windows limit the weekly hours it applies in
+	StartDate *string         `json:"startDate,omitempty"`
+	Timezone  string          `json:"timezone"`
+	Windows   []WindowRequest `json:"windows,omitempty"`
+	// Budgets; omitted means unlimited. discountBudget is in ₺ across all bookings
+	CoinBudget            *int32   `json:"coinBudget,omitempty"`
+	DiscountBudget        *float64 `json:"discountBudget,omitempty"`
+	MaxRedemptions        *int32   `json:"maxRedemptions,omitempty"`
+	MaxRedemptionsPerUser *int32   `json:"maxRedemptionsPerUser,omitempty"`
 }
 
 // UpdateCampaignRequest is the request body for PUT /v1/campaigns/:id.
@@ -21,15 +40,77 @@ type UpdateCampaignRequest struct {
 	Description    string  `json:"description"`
 	Status         string  `json:"status"`
 	Target         string  `json:"target"`
-	Discount       string  `json:"discount"`
+	Discount       string  `json:"discount"` // legacy text such as "%20"; used only when discountType is empty
+	DiscountType   string  `json:"discountType"`
+	DiscountValue  float64 `json:"discountValue"`
 	EndDate        *string `json:"endDate,omitempty"`
 	StationID      *int32  `json:"stationId,omitempty"`
 	CoinReward     *int32  `json:"coinReward,omitempty"`
 	TargetBadgeIDs []int32 `json:"targetBadgeIds,omitempty"`
+	// Eligibility restrictions on top of target badges
+	NewCustomersOnly bool     `json:"newCustomersOnly"`
+	VehicleTypes     []string `json:"vehicleTypes,omitempty"`
+	// Stacking: higher priority applies first; an exclusive campaign never combines with others,
+	// a stackable one can be combined with the top-ranked campaign
+	Priority  int32 `json:"priority"`
+	Exclusive bool  `json:"exclusive"`
+	Stackable bool  `json:"stackable"`
+	// Scheduling: a DRAFT with a startDate goes ACTIVE at that time; windows limit the weekly hours it applies in
+	StartDate *string         `json:"startDate,omitempty"`
+	Timezone  string          `json:"timezone"`
+	Windows   []WindowRequest `json:"windows,omitempty"`
+	// Budgets; omitted means unlimited. discountBudget is in ₺ across all bookings
+	CoinBudget            *int32   `json:"coinBudget,omitempty"`
+	DiscountBudget        *float64 `json:"discountBudget,omitempty"`
+	MaxRedemptions        *int32   `json:"maxRedemptions,omitempty"`
+	MaxRedemptionsPerUser *int32   `json:"maxRedemptionsPerUser,omitempty"`
+}
+
+// WindowRequest is a weekly window a campaign applies in, in the campaign's time zone.
+// Days are 0=Monday … 6=Sunday (empty means every day); endHour is exclusive and may be
+// earlier than startHour for windows running past midnight.
+type WindowRequest struct {
+	Days      []int32 `json:"days"`
+	StartHour int32   `json:"startHour"`
+	EndHour   int32   `json:"endHour"`
+}
+
+// SetVariantsRequest is the request body for PUT /v1/campaigns/:id/variants.
+type SetVariantsRequest struct {
+	Variants []VariantRequest `json:"variants" binding:"required"`
+}
+
+// VariantRequest is one arm of a campaign experiment. Drivers are split between variants in proportion
+// to weight; a variant's discount and coin reward replace the campaign's for the drivers assigned to it.
+type VariantRequest struct {
+	Name          string  `json:"name"`
+	Weight        int32   `json:"weight"`
+	IsControl     bool    `json:"isControl"`
+	DiscountType  string  `json:"discountType"`
+	DiscountValue float64 `json:"discountValue"`
+	CoinReward    *int32  `json:"coinReward,omitempty"`
 }
 
 // --- Response DTOs ---
 
+// BudgetResponse is a campaign's caps and how much of them non-cancelled bookings have used.
+type BudgetResponse struct {
+	CoinBudget            *int32   `json:"coinBudget"`
+	DiscountBudget        *float64 `json:"discountBudget"`
+	MaxRedemptions        *int32   `json:"maxRedemptions"`
+	MaxRedemptionsPerUser *int32   `json:"maxRedemptionsPerUser"`
+	CoinsSpent            int32    `json:"coinsSpent"`
+	DiscountSpent         float64  `json:"discountSpent"`
+	Redemptions           int32    `json:"redemptions"`
+}
+
+// WindowResponse is a campaign's weekly window.
+type WindowResponse struct {
+	Days      []int32 `json:"days"`
+	StartHour int32   `json:"startHour"`
+	EndHour   int32   `json:"endHour"`
+}
+
 // BadgeResponse is a lightweight badge DTO used inside campaign responses.
 type BadgeResponse struct {
 	ID          int32  `json:"id"`
@@ -38,32 +119,155 @@ type BadgeResponse struct {
 	Icon        string `json:"icon"`
 }
 
-// ForUserCampaignResponse is the response DTO for campaigns/for-user (stub).
+// ForUserResponse is the response for GET /v1/campaigns/for-user.
+type ForUserResponse struct {
+	Campaigns  []ForUserCampaignResponse `json:"campaigns"`
+	UserBadges []BadgeResponse           `json:"userBadges"`
+}
+
+// ForUserCampaignResponse is an active campaign as seen by a specific user.
 type ForUserCampaignResponse struct {
 	ID            int32           `json:"id"`
 	Title         string          `json:"title"`
 	Description   string          `json:"description"`
 	Discount      string          `json:"discount"`
+	DiscountType  string          `json:"discountType"`
+	DiscountValue float64         `json:"discountValue"`
 	CoinReward    int32           `json:"coinReward"`
 	EndDate       *string         `json:"endDate"`
 	TargetBadges  []BadgeResponse `json:"targetBadges"`
 	MatchedBadges []BadgeResponse `json:"matchedBadges"`
+	Eligible      bool            `json:"eligible"`
+	Priority      int32           `json:"priority"`
+	Exclusive     bool            `json:"exclusive"`
+	Stackable     bool            `json:"stackable"`
+	// IneligibleReasons lists why the user doesn't qualify, e.g. BADGE_REQUIRED or VEHICLE_TYPE
+	IneligibleReasons []string `json:"ineligibleReasons"`
+
+	// Schedule
+	StartDate *string          `json:"startDate"`
+	Timezone  string           `json:"timezone"`
+	Windows   []WindowResponse `json:"windows"`
+	// ActiveNow is false outside the campaign's windows
+	ActiveNow bool `json:"activeNow"`
 }
 
 // CampaignResponse is the response DTO for a campaign.
 type CampaignResponse struct {
-	ID           int32           `json:"id"`
-	Title        string          `json:"title"`
-	Description  string          `json:"description"`
-	Status       string          `json:"status"`
-	Target       string          `json:"target"`
-	Discount     string          `json:"discount"`
-	EndDate      *string         `json:"endDate"`
-	OwnerID      int32           `json:"ownerId"`
-	StationID    *int32          `json:"stationId"`
-	StationName  *string         `json:"stationName,omitempty"`
-	CoinReward   int32           `json:"coinReward"`
-	CreatedAt    string          `json:"createdAt"`
-	UpdatedAt    string          `json:"updatedAt"`
-	TargetBadges []BadgeResponse `json:"targetBadges"`
+	ID               int32           `json:"id"`
+	Title            string          `json:"title"`
+	Description      string          `json:"description"`
+	Status           string          `json:"status"`
+	Target           string          `json:"target"`
+	Discount         string          `json:"discount"`
+	DiscountType     string          `json:"discountType"`
+	DiscountValue    float64         `json:"discountValue"`
+	EndDate          *string         `json:"endDate"`
+	OwnerID          int32           `json:"ownerId"`
+	StationID        *int32          `json:"stationId"`
+	StationName      *string         `json:"stationName,omitempty"`
+	CoinReward       int32           `json:"coinReward"`
+	CreatedAt        string          `json:"createdAt"`
+	UpdatedAt        string          `json:"updatedAt"`
+	TargetBadges     []BadgeResponse `json:"targetBadges"`
+	NewCustomersOnly bool            `json:"newCustomersOnly"`
+	VehicleTypes     []string        `json:"vehicleTypes"`
+	Priority         int32           `json:"priority"`
+	Exclusive        bool            `json:"exclusive"`
+	Stackable        bool            `json:"stackable"`
+
+	// Schedule
+	StartDate *string          `json:"startDate"`
+	Timezone  string           `json:"timezone"`
+	Windows   []WindowResponse `json:"windows"`
+
+	Budget BudgetResponse `json:"budget"`
+	// PausedReason says which cap paused the campaign, e.g. COIN_BUDGET
+	PausedReason *string `json:"pausedReason"`
+}
+
+// AnalyticsResponse is a campaign's results for GET /v1/campaigns/:id/analytics.
+// Shares are percentages; DiscountCost is in ₺ over an average session per booking.
+type AnalyticsResponse struct {
+	CampaignID  int32  `json:"campaignId"`
+	Title       string `json:"title"`
+	Status      string `json:"status"`
+	PeriodStart string `json:"periodStart"`
+	PeriodEnd   string `json:"periodEnd"`
+
+	// Reservations the campaign was applied to (cancelled ones excluded)
+	Reservations          int32   `json:"reservations"`
+	CompletedReservations int32   `json:"completedReservations"`
+	UniqueDrivers         int32   `json:"uniqueDrivers"`
+	GreenShare            float64 `json:"greenShare"`
+	CoinsSpent            int32   `json:"coinsSpent"`
+	DiscountCost          float64 `json:"discountCost"`
+
+	// All reservations at the campaign's stations, during the campaign and in the equally long period before it
+	CampaignPeriod PeriodStats `json:"campaignPeriod"`
+	Baseline       PeriodStats `json:"baseline"`
+	// IncrementalGreenShare is the change in green-hour share against the baseline, in percentage points
+	IncrementalGreenShare float64 `json:"incrementalGreenShare"`
+	// ReservationLift is the change in reservations per day against the baseline, in percent (nil without a baseline)
+	ReservationLift *float64 `json:"reservationLift"`
+}
+
+// PeriodStats is reservation activity at a campaign's stations over a period.
+type PeriodStats struct {
+	PeriodStart        string  `json:"periodStart"`
+	PeriodEnd          string  `json:"periodEnd"`
+	Reservations       int32   `json:"reservations"`
+	ReservationsPerDay float64 `json:"reservationsPerDay"`
+	GreenShare         float64 `json:"greenShare"`
+}
+
+// VariantResponse is a campaign experiment variant.
+type VariantResponse struct {
+	ID            int32   `json:"id"`
+	Name          string  `json:"name"`
+	Weight        int32   `json:"weight"`
+	IsControl     bool    `json:"isControl"`
+	Discount      string  `json:"discount"`
+	DiscountType  string  `json:"discountType"`
+	DiscountValue float64 `json:"discountValue"`
+	CoinReward    int32   `json:"coinReward"`
+}
+
+// ExperimentResponse is the result of a campaign's A/B experiment for GET /v1/campaigns/:id/experiment.
+// Rates, shares and intervals are percentages; intervals are 95% confidence intervals.
+type ExperimentResponse struct {
+	CampaignID int32  `json:"campaignId"`
+	Title      string `json:"title"`
+	// ControlID is the variant uplift is measured against: the control, or the first variant if none is marked
+	ControlID *int32          `json:"controlId"`
+	Variants  []VariantResult `json:"variants"`
+}
+
+// VariantResult is one variant's exposures and conversions.
+// A driver converts by booking at least one non-cancelled reservation priced with the variant.
+type VariantResult struct {
+	Variant          VariantResponse `json:"variant"`
+	Exposures        int32           `json:"exposures"`
+	ConvertedDrivers int32           `json:"convertedDrivers"`
+	Reservations     int32           `json:"reservations"`
+	ConversionRate   float64         `json:"conversionRate"`
+	ConversionCI     Interval        `json:"conversionCi"`
+	GreenShare       float64         `json:"greenShare"`
+	GreenShareCI     Interval        `json:"greenShareCi"`
+	// Uplift is the conversion rate difference against the control in percentage points (nil for the control)
+	Uplift *UpliftResult `json:"uplift"`
+}
+
+// Interval is a confidence interval.
+type Interval struct {
+	Low  float64 `json:"low"`
+	High float64 `json:"high"`
+}
+
+// UpliftResult is a variant's conversion uplift over the control.
+// Significant is true when the interval excludes zero.
+type UpliftResult struct {
+	Estimate    float64  `json:"estimate"`
+	CI          Interval `json:"ci"`
+	Significant bool     `json:"significant"`
 }
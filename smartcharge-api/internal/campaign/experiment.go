@@ -0,0 +1,232 @@
+package campaign
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"smartcharge-api/db/generated"
+	apperrors "smartcharge-api/internal/errors"
+	"smartcharge-api/internal/policy"
+	"smartcharge-api/internal/pricing"
+)
+
+// z95 is the normal quantile for two-sided 95% confidence intervals.
+const z95 = 1.96
+
+// SetVariants replaces the campaign's experiment variants. Replacing the variants restarts the experiment:
+// exposures of the old variants are dropped and past bookings no longer count towards any variant.
+func (s *Service) SetVariants(ctx context.Context, actor policy.Actor, campaignID int32, req SetVariantsRequest) ([]VariantResponse, error) {
+	c, err := s.ownedCampaign(ctx, actor, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if c.DiscountType == pricing.DiscountFreeHours {
+		return nil, apperrors.NewValidationError("Free-hour campaigns can't run experiments")
+	}
+	// Discount types are matched and stored upper-case, as resolveDiscount does for campaigns
+	for i := range req.Variants {
+		req.Variants[i].DiscountType = strings.ToUpper(strings.TrimSpace(req.Variants[i].DiscountType))
+	}
+	if err := validateVariants(req.Variants); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.queries.WithTx(tx)
+
+	if err := qtx.DeleteCampaignVariants(ctx, campaignID); err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	for _, v := range req.Variants {
+		coinReward := c.CoinReward
+		if v.CoinReward != nil {
+			coinReward = *v.CoinReward
+		}
+		if _, err := qtx.CreateCampaignVariant(ctx, generated.CreateCampaignVariantParams{
+			CampaignID:    campaignID,
+			Name:          strings.TrimSpace(v.Name),
+			Weight:        v.Weight,
+			IsControl:     v.IsControl,
+			DiscountType:  v.DiscountType,
+			DiscountValue: v.DiscountValue,
+			CoinReward:    coinReward,
+		}); err != nil {
+			return nil, apperrors.ErrInternal
+		}
+	}
+
+	variants, err := qtx.ListCampaignVariants(ctx, campaignID)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	out := make([]VariantResponse, len(variants))
+	for i, v := range variants {
+		out[i] = toVariantResponse(v.ID, v.Name, v.Weight, v.IsControl, v.DiscountType, v.DiscountValue, v.CoinReward)
+	}
+	return out, nil
+}
+
+// EndExperiment removes the campaign's variants; every driver gets the campaign as defined again.
+func (s *Service) EndExperiment(ctx context.Context, actor policy.Actor, campaignID int32) error {
+	if _, err := s.ownedCampaign(ctx, actor, campaignID); err != nil {
+		return err
+	}
+	if err := s.queries.DeleteCampaignVariants(ctx, campaignID); err != nil {
+		return apperrors.ErrInternal
+	}
+	return nil
+}
+
+// Experiment reports each variant's conversion and green-hour share, and its conversion uplift over the control.
+func (s *Service) Experiment(ctx context.Context, actor policy.Actor, campaignID int32) (*ExperimentResponse, error) {
+	c, err := s.ownedCampaign(ctx, actor, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.queries.ListCampaignVariantStats(ctx, campaignID)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	resp := &ExperimentResponse{CampaignID: c.ID, Title: c.Title, Variants: make([]VariantResult, len(rows))}
+	if len(rows) == 0 {
+		return resp, nil
+	}
+
+	control := rows[0]
+	for _, row := range rows {
+		if row.IsControl {
+			control = row
+			break
+		}
+	}
+	resp.ControlID = &control.ID
+
+	for i, row := range rows {
+		result := VariantResult{
+			Variant:          toVariantResponse(row.ID, row.Name, row.Weight, row.IsControl, row.DiscountType, row.DiscountValue, row.CoinReward),
+			Exposures:        row.Exposures,
+			ConvertedDrivers: row.ConvertedUsers,
+			Reservations:     row.Reservations,
+			ConversionRate:   share(row.ConvertedUsers, row.Exposures),
+			ConversionCI:     wilson(row.ConvertedUsers, row.Exposures),
+			GreenShare:       share(row.GreenReservations, row.Reservations),
+			GreenShareCI:     wilson(row.GreenReservations, row.Reservations),
+		}
+		if row.ID != control.ID {
+			result.Uplift = uplift(row.ConvertedUsers, row.Exposures, control.ConvertedUsers, control.Exposures)
+		}
+		resp.Variants[i] = result
+	}
+	return resp, nil
+}
+
+// ownedCampaign loads a campaign, making sure the caller may manage it.
+func (s *Service) ownedCampaign(ctx context.Context, actor policy.Actor, campaignID int32) (generated.Campaign, error) {
+	c, err := s.queries.GetCampaignByID(ctx, campaignID)
+	if err != nil {
+		return generated.Campaign{}, apperrors.NewNotFoundError("Campaign")
+	}
+	if err := policy.RequireOperator(actor, c.OwnerID); err != nil {
+		return generated.Campaign{}, err
+	}
+	return c, nil
+}
+
+// validateVariants checks an experiment has at least two uniquely named variants, at most one control,
+// and valid discounts. Free hours are capped per driver, so no variant may offer them.
+func validateVariants(variants []VariantRequest) error {
+	if len(variants) < 2 {
+		return apperrors.NewValidationError("An experiment needs at least 2 variants")
+	}
+	names := make(map[string]bool, len(variants))
+	controls := 0
+	for _, v := range variants {
+		name := strings.TrimSpace(v.Name)
+		if name == "" || len(name) > 50 {
+			return apperrors.NewValidationError("Variant name must be 1 to 50 characters")
+		}
+		if names[strings.ToLower(name)] {
+			return apperrors.NewValidationError(fmt.Sprintf("Duplicate variant name %q", name))
+		}
+		names[strings.ToLower(name)] = true
+		if v.Weight <= 0 {
+			return apperrors.NewValidationError("Variant weight must be greater than 0")
+		}
+		if v.IsControl {
+			controls++
+		}
+		if v.DiscountType == pricing.DiscountFreeHours {
+			return apperrors.NewValidationError("Variants can't offer free hours")
+		}
+		if err := (pricing.DiscountRule{Type: v.DiscountType, Value: v.DiscountValue}).Validate(); err != nil {
+			return apperrors.NewValidationError(err.Error())
+		}
+		if v.CoinReward != nil && *v.CoinReward < 0 {
+			return apperrors.NewValidationError("coinReward must not be negative")
+		}
+	}
+	if controls > 1 {
+		return apperrors.NewValidationError("At most one variant can be the control")
+	}
+	return nil
+}
+
+// wilson returns the Wilson score interval for x successes out of n, in percent.
+func wilson(x, n int32) Interval {
+	if n == 0 {
+		return Interval{}
+	}
+	p := float64(x) / float64(n)
+	nf := float64(n)
+	denom := 1 + z95*z95/nf
+	center := (p + z95*z95/(2*nf)) / denom
+	half := z95 * math.Sqrt(p*(1-p)/nf+z95*z95/(4*nf*nf)) / denom
+	return Interval{
+		Low:  pricing.RoundTo2(math.Max(center-half, 0) * 100),
+		High: pricing.RoundTo2(math.Min(center+half, 1) * 100),
+	}
+}
+
+// uplift returns the difference between two conversion rates in percentage points,
+// with a normal-approximation interval. Nil when either group has no exposures yet.
+func uplift(x1, n1, x0, n0 int32) *UpliftResult {
+	if n1 == 0 || n0 == 0 {
+		return nil
+	}
+	p1 := float64(x1) / float64(n1)
+	p0 := float64(x0) / float64(n0)
+	diff := p1 - p0
+	half := z95 * math.Sqrt(p1*(1-p1)/float64(n1)+p0*(1-p0)/float64(n0))
+	return &UpliftResult{
+		Estimate:    pricing.RoundTo2(diff * 100),
+		CI:          Interval{Low: pricing.RoundTo2((diff - half) * 100), High: pricing.RoundTo2((diff + half) * 100)},
+		Significant: diff-half > 0 || diff+half < 0,
+	}
+}
+
+func toVariantResponse(id int32, name string, weight int32, isControl bool, discountType string, discountValue float64, coinReward int32) VariantResponse {
+	return VariantResponse{
+		ID:            id,
+		Name:          name,
+		Weight:        weight,
+		IsControl:     isControl,
+		Discount:      pricing.DiscountRule{Type: discountType, Value: discountValue}.Label(),
+		DiscountType:  discountType,
+		DiscountValue: discountValue,
+		CoinReward:    coinReward,
+	}
+}
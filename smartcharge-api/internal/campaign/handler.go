@@ -1,12 +1,15 @@
 package campaign
 
 import (
+	"fmt"
+	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 
 	apperrors "smartcharge-api/internal/errors"
 	"smartcharge-api/internal/middleware"
+	"smartcharge-api/internal/policy"
 	"smartcharge-api/internal/response"
 )
 
@@ -28,19 +31,34 @@ func (h *Handler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.Handler
 	campaigns.GET("/for-user", h.ListForUser)
 	campaigns.GET("", h.List)
 	campaigns.POST("", h.Create)
+	campaigns.GET("/analytics", h.ListAnalytics)
+	campaigns.GET("/analytics/download", h.DownloadAnalytics)
 	campaigns.PUT("/:id", h.Update)
 	campaigns.DELETE("/:id", h.Delete)
+	campaigns.GET("/:id/analytics", h.Analytics)
+	campaigns.GET("/:id/analytics/download", h.DownloadCampaignAnalytics)
+	campaigns.PUT("/:id/variants", h.SetVariants)
+	campaigns.DELETE("/:id/variants", h.EndExperiment)
+	campaigns.GET("/:id/experiment", h.Experiment)
 }
 
 // ListForUser handles GET /v1/campaigns/for-user.
-// Stub: returns all active campaigns with matchedBadges: [].
+// Pass ?includeIneligible=true to also list campaigns the user doesn't qualify for.
 func (h *Handler) ListForUser(c *gin.Context) {
-	campaigns, err := h.service.ListForUser(c.Request.Context())
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	includeIneligible := c.Query("includeIneligible") == "true"
+
+	result, err := h.service.ListForUser(c.Request.Context(), userID, includeIneligible)
 	if err != nil {
 		handleError(c, err)
 		return
 	}
-	response.OK(c, gin.H{"campaigns": campaigns})
+	response.OK(c, result)
 }
 
 // List handles GET /v1/campaigns — lists campaigns owned by the authenticated user.
@@ -61,7 +79,7 @@ func (h *Handler) List(c *gin.Context) {
 
 // Create handles POST /v1/campaigns.
 func (h *Handler) Create(c *gin.Context) {
-	userID, ok := middleware.GetUserID(c)
+	actor, ok := policy.FromContext(c)
 	if !ok {
 		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
 		return
@@ -73,7 +91,7 @@ func (h *Handler) Create(c *gin.Context) {
 		return
 	}
 
-	result, err := h.service.Create(c.Request.Context(), userID, req)
+	result, err := h.service.Create(c.Request.Context(), actor, req)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -83,6 +101,11 @@ func (h *Handler) Create(c *gin.Context) {
 
 // Update handles PUT /v1/campaigns/:id.
 func (h *Handler) Update(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
 	id, err := parseID(c)
 	if err != nil {
 		return
@@ -94,7 +117,7 @@ func (h *Handler) Update(c *gin.Context) {
 		return
 	}
 
-	result, err := h.service.Update(c.Request.Context(), id, req)
+	result, err := h.service.Update(c.Request.Context(), actor, id, req)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -104,20 +127,174 @@ func (h *Handler) Update(c *gin.Context) {
 
 // Delete handles DELETE /v1/campaigns/:id.
 func (h *Handler) Delete(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
 	id, err := parseID(c)
 	if err != nil {
 		return
 	}
 
-	if err := h.service.Delete(c.Request.Context(), id); err != nil {
+	if err := h.service.Delete(c.Request.Context(), actor, id); err != nil {
 		handleError(c, err)
 		return
 	}
 	response.OK(c, gin.H{"message": "Campaign deleted"})
 }
 
+// ListAnalytics handles GET /v1/campaigns/analytics — results of every campaign the caller owns.
+func (h *Handler) ListAnalytics(c *gin.Context) {
+	ownerID, ok := middleware.GetUserID(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	result, err := h.service.ListAnalytics(c.Request.Context(), ownerID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// DownloadAnalytics handles GET /v1/campaigns/analytics/download.
+// Returns the results of every campaign the caller owns as a CSV attachment.
+func (h *Handler) DownloadAnalytics(c *gin.Context) {
+	ownerID, ok := middleware.GetUserID(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	result, err := h.service.ListAnalytics(c.Request.Context(), ownerID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	sendCSV(c, "smartcharge-campaign-analytics.csv", result)
+}
+
+// Analytics handles GET /v1/campaigns/:id/analytics.
+func (h *Handler) Analytics(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+	id, err := parseID(c)
+	if err != nil {
+		return
+	}
+
+	result, err := h.service.Analytics(c.Request.Context(), actor, id)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// DownloadCampaignAnalytics handles GET /v1/campaigns/:id/analytics/download.
+// Returns the campaign's results as a CSV attachment.
+func (h *Handler) DownloadCampaignAnalytics(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+	id, err := parseID(c)
+	if err != nil {
+		return
+	}
+
+	result, err := h.service.Analytics(c.Request.Context(), actor, id)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	sendCSV(c, fmt.Sprintf("smartcharge-campaign-%d-analytics.csv", id), []AnalyticsResponse{*result})
+}
+
+// SetVariants handles PUT /v1/campaigns/:id/variants — starts or restarts an A/B experiment.
+func (h *Handler) SetVariants(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+	id, err := parseID(c)
+	if err != nil {
+		return
+	}
+
+	var req SetVariantsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "variants are required")
+		return
+	}
+
+	result, err := h.service.SetVariants(c.Request.Context(), actor, id, req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// EndExperiment handles DELETE /v1/campaigns/:id/variants.
+func (h *Handler) EndExperiment(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+	id, err := parseID(c)
+	if err != nil {
+		return
+	}
+
+	if err := h.service.EndExperiment(c.Request.Context(), actor, id); err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, gin.H{"message": "Experiment ended"})
+}
+
+// Experiment handles GET /v1/campaigns/:id/experiment.
+func (h *Handler) Experiment(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+	id, err := parseID(c)
+	if err != nil {
+		return
+	}
+
+	result, err := h.service.Experiment(c.Request.Context(), actor, id)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
 // --- helpers ---
 
+func sendCSV(c *gin.Context, filename string, rows []AnalyticsResponse) {
+	body, err := RenderCSV(rows)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", body)
+}
+
 func parseID(c *gin.Context) (int32, error) {
 	raw := c.Param("id")
 	val, err := strconv.Atoi(raw)
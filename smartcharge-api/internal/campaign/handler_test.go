@@ -0,0 +1,87 @@
+package campaign
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"smartcharge-api/db/generated"
+	"smartcharge-api/internal/apitest"
+	"smartcharge-api/internal/pricing"
+)
+
+func TestMutatingRoutesAuthorization(t *testing.T) {
+	stationID := int32(5)
+	campaign := map[string]any{
+		"title":         "Gece şarjı",
+		"status":        StatusActive,
+		"discountType":  pricing.DiscountPercentage,
+		"discountValue": 20,
+		"stationId":     stationID,
+	}
+	variants := map[string]any{"variants": []VariantRequest{
+		{Name: "A", Weight: 1, IsControl: true, DiscountType: pricing.DiscountPercentage, DiscountValue: 10},
+		{Name: "B", Weight: 1, DiscountType: pricing.DiscountPercentage, DiscountValue: 20},
+	}}
+
+	tests := []struct {
+		name  string
+		route apitest.Route
+		write string         // the query that makes the change
+		want  map[string]int // status by apitest.Actors name
+	}{
+		{
+			name:  "create",
+			route: apitest.Route{Method: http.MethodPost, Path: "/v1/campaigns", Body: campaign},
+			write: "CreateCampaign",
+			want:  map[string]int{"driver": 403, "owning operator": 201, "other operator": 403, "admin": 201},
+		},
+		{
+			name:  "update",
+			route: apitest.Route{Method: http.MethodPut, Path: "/v1/campaigns/10", Body: campaign},
+			write: "UpdateCampaign",
+			want:  map[string]int{"driver": 403, "owning operator": 200, "other operator": 403, "admin": 200},
+		},
+		{
+			name:  "delete",
+			route: apitest.Route{Method: http.MethodDelete, Path: "/v1/campaigns/10"},
+			write: "DeleteCampaign",
+			want:  map[string]int{"driver": 403, "owning operator": 200, "other operator": 403, "admin": 200},
+		},
+		{
+			name:  "set variants",
+			route: apitest.Route{Method: http.MethodPut, Path: "/v1/campaigns/10/variants", Body: variants},
+			write: "CreateCampaignVariant",
+			want:  map[string]int{"driver": 403, "owning operator": 200, "other operator": 403, "admin": 200},
+		},
+		{
+			name:  "end experiment",
+			route: apitest.Route{Method: http.MethodDelete, Path: "/v1/campaigns/10/variants"},
+			write: "DeleteCampaignVariants",
+			want:  map[string]int{"driver": 403, "owning operator": 200, "other operator": 403, "admin": 200},
+		},
+	}
+
+	for _, tt := range tests {
+		for name, want := range tt.want {
+			actor := apitest.Actors[name]
+			t.Run(tt.name+"/"+name, func(t *testing.T) {
+				db := apitest.NewDB().
+					On("GetCampaignByID", generated.Campaign{ID: 10, OwnerID: apitest.Owner.UserID, Status: StatusActive, DiscountType: pricing.DiscountPercentage, DiscountValue: 10}).
+					On("GetStationByID", generated.Station{ID: stationID, OwnerID: pgtype.Int4{Int32: apitest.Owner.UserID, Valid: true}})
+				h := NewHandler(&Service{queries: generated.New(db), pool: db})
+				r := apitest.Router(actor, func(v1 *gin.RouterGroup, auth gin.HandlerFunc) { h.RegisterRoutes(v1, auth) })
+
+				w := apitest.Do(r, tt.route)
+				if w.Code != want {
+					t.Fatalf("status = %d, want %d: %s", w.Code, want, w.Body)
+				}
+				if wrote := db.Called(tt.write); wrote != (want != http.StatusForbidden) {
+					t.Errorf("%s called = %v with status %d", tt.write, wrote, w.Code)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package campaign
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"smartcharge-api/db/generated"
+	apperrors "smartcharge-api/internal/errors"
+	"smartcharge-api/internal/pricing"
+)
+
+// Campaign statuses. A DRAFT with a start date is scheduled: it already prices slots after its start
+// and goes ACTIVE when it starts. An ACTIVE or scheduled campaign goes PAUSED when it reaches a budget
+// cap; any of them goes ENDED once its end date passes.
+const (
+	StatusDraft  = "DRAFT"
+	StatusActive = "ACTIVE"
+	StatusPaused = "PAUSED"
+	StatusEnded  = "ENDED"
+)
+
+// ScheduleJob moves campaigns through their lifecycle as start and end dates pass.
+// It implements scheduler.Job.
+type ScheduleJob struct {
+	queries *generated.Queries
+}
+
+// NewScheduleJob creates the campaign schedule job.
+func NewScheduleJob(queries *generated.Queries) *ScheduleJob {
+	return &ScheduleJob{queries: queries}
+}
+
+// Name implements scheduler.Job.
+func (j *ScheduleJob) Name() string {
+	return "campaign-schedule"
+}
+
+// Run implements scheduler.Job.
+func (j *ScheduleJob) Run(ctx context.Context) error {
+	started, err := j.queries.ActivateScheduledCampaigns(ctx)
+	if err != nil {
+		return err
+	}
+	ended, err := j.queries.EndExpiredCampaigns(ctx)
+	if err != nil {
+		return err
+	}
+
+	if started > 0 || ended > 0 {
+		log.Printf("campaign-schedule: started %d, ended %d campaigns", started, ended)
+	}
+	return nil
+}
+
+// --- helpers ---
+
+// parseDate parses an optional RFC3339 or YYYY-MM-DD date; nil or empty is NULL.
+func parseDate(raw *string, field string) (pgtype.Timestamptz, error) {
+	if raw == nil || *raw == "" {
+		return pgtype.Timestamptz{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, *raw)
+	if err != nil {
+		// Try date-only fallback
+		t, err = time.Parse("2006-01-02", *raw)
+		if err != nil {
+			return pgtype.Timestamptz{}, apperrors.NewValidationError(fmt.Sprintf("Invalid %s format", field))
+		}
+	}
+	return pgtype.Timestamptz{Time: t, Valid: true}, nil
+}
+
+// resolveTimezone validates a campaign time zone, defaulting to pricing.DefaultTimezone.
+func resolveTimezone(tz string) (string, error) {
+	tz = strings.TrimSpace(tz)
+	if tz == "" {
+		return pricing.DefaultTimezone, nil
+	}
+	if _, err := pricing.LoadLocation(tz); err != nil {
+		return "", apperrors.NewValidationError(fmt.Sprintf("Unknown timezone %q", tz))
+	}
+	return tz, nil
+}
+
+// resolveStatus validates the requested status against the schedule. An ACTIVE campaign that
+// hasn't started yet is stored as DRAFT so the schedule job activates it on time, and one whose
+// end date has passed is stored as ENDED.
+func resolveStatus(status, fallback string, start, end pgtype.Timestamptz, now time.Time) (string, error) {
+	status = strings.ToUpper(strings.TrimSpace(status))
+	if status == "" {
+		status = fallback
+	}
+	switch status {
+	case StatusDraft, StatusActive, StatusPaused, StatusEnded:
+	default:
+		return "", apperrors.NewValidationError(fmt.Sprintf("status must be one of %s, %s, %s, %s", StatusDraft, StatusActive, StatusPaused, StatusEnded))
+	}
+
+	if start.Valid && end.Valid && !end.Time.After(start.Time) {
+		return "", apperrors.NewValidationError("endDate must be after startDate")
+	}
+
+	if status == StatusActive && start.Valid && start.Time.After(now) {
+		status = StatusDraft
+	}
+	if status != StatusEnded && end.Valid && end.Time.Before(now) {
+		status = StatusEnded
+	}
+	return status, nil
+}
+
+// validateWindows checks every requested weekly window.
+func validateWindows(windows []WindowRequest) error {
+	for _, w := range windows {
+		if err := pricing.ValidateWindow(w.Days, w.StartHour, w.EndHour); err != nil {
+			return apperrors.NewValidationError(err.Error())
+		}
+	}
+	return nil
+}
+
+// replaceWindows stores the campaign's windows, dropping any it had before.
+func replaceWindows(ctx context.Context, q *generated.Queries, campaignID int32, windows []WindowRequest) ([]generated.CampaignWindow, error) {
+	if err := q.RemoveCampaignWindows(ctx, campaignID); err != nil {
+		return nil, err
+	}
+	for _, w := range windows {
+		days := w.Days
+		if days == nil {
+			days = []int32{}
+		}
+		if err := q.AddCampaignWindow(ctx, generated.AddCampaignWindowParams{
+			CampaignID: campaignID,
+			Days:       days,
+			StartHour:  w.StartHour,
+			EndHour:    w.EndHour,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return q.ListCampaignWindows(ctx, campaignID)
+}
+
+func toWindowResponses(windows []generated.CampaignWindow) []WindowResponse {
+	out := make([]WindowResponse, len(windows))
+	for i, w := range windows {
+		out[i] = WindowResponse{Days: w.Days, StartHour: w.StartHour, EndHour: w.EndHour}
+	}
+	return out
+}
+
+func formatDate(t pgtype.Timestamptz) *string {
+	if !t.Valid {
+		return nil
+	}
+	s := t.Time.UTC().Format(time.RFC3339)
+	return &s
+}
+
+func optionalText(t pgtype.Text) *string {
+	if !t.Valid {
+		return nil
+	}
+	return &t.String
+}
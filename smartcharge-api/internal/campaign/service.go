@@ -2,22 +2,35 @@ package campaign
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 
 	"smartcharge-api/db/generated"
 	apperrors "smartcharge-api/internal/errors"
+	"smartcharge-api/internal/policy"
+	"smartcharge-api/internal/pricing"
 )
 
 // Service handles campaign business logic.
 type Service struct {
 	queries *generated.Queries
+	pool    txBeginner
+}
+
+// txBeginner starts the transactions the service writes in; *pgxpool.Pool implements it.
+type txBeginner interface {
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
 }
 
 // NewService creates a new campaign service.
-func NewService(queries *generated.Queries) *Service {
-	return &Service{queries: queries}
+func NewService(queries *generated.Queries, pool *pgxpool.Pool) *Service {
+	return &Service{queries: queries, pool: pool}
 }
 
 // ListByOwner returns all campaigns for a given owner, ordered by createdAt DESC.
@@ -34,25 +47,67 @@ func (s *Service) ListByOwner(ctx context.Context, ownerID int32) ([]CampaignRes
 		if err != nil {
 			badges = []generated.Badge{}
 		}
+		windows, err := s.queries.ListCampaignWindows(ctx, row.ID)
+		if err != nil {
+			return nil, apperrors.ErrInternal
+		}
 
-		result = append(result, campaignRowToResponse(row, badges))
+		result = append(result, campaignRowToResponse(row, badges, windows))
 	}
 	return result, nil
 }
 
-// ListForUser returns all active campaigns (stub — no badge matching).
-// Placeholder matchedBadges: []. Can be upgraded to real badge matching later.
-func (s *Service) ListForUser(ctx context.Context) ([]ForUserCampaignResponse, error) {
+// ListForUser returns the active campaigns the user is eligible for, with the target badges they hold.
+// Eligibility is decided by pricing.CheckEligibility, the same rules used when pricing a booking.
+// With includeIneligible, campaigns the user does not qualify for are returned too, flagged eligible=false.
+func (s *Service) ListForUser(ctx context.Context, userID int32, includeIneligible bool) (*ForUserResponse, error) {
+	profile, err := pricing.LoadProfile(ctx, s.queries, userID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("User")
+	}
+	owned, err := s.queries.GetUserBadges(ctx, userID)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
 	campaigns, err := s.queries.ListActiveCampaigns(ctx)
 	if err != nil {
 		return nil, apperrors.ErrInternal
 	}
 
+	now := time.Now()
 	result := make([]ForUserCampaignResponse, 0, len(campaigns))
 	for _, c := range campaigns {
-		badges, err := s.queries.GetCampaignTargetBadges(ctx, c.ID)
+		targets, err := s.queries.GetCampaignTargetBadges(ctx, c.ID)
 		if err != nil {
-			badges = []generated.Badge{}
+			return nil, apperrors.ErrInternal
+		}
+
+		check := pricing.CheckEligibility(c, targets, profile)
+		if !check.Eligible && !includeIneligible {
+			continue
+		}
+
+		windows, err := s.queries.ListCampaignWindows(ctx, c.ID)
+		if err != nil {
+			return nil, apperrors.ErrInternal
+		}
+
+		// Drivers in an experiment see their variant's terms; seeing them counts as an exposure
+		if check.Eligible {
+			variant, err := pricing.LoadVariant(ctx, s.queries, c, profile)
+			if err != nil {
+				return nil, apperrors.ErrInternal
+			}
+			if variant != nil {
+				if err := s.queries.RecordCampaignExposure(ctx, generated.RecordCampaignExposureParams{
+					VariantID: variant.ID,
+					UserID:    userID,
+				}); err != nil {
+					return nil, apperrors.ErrInternal
+				}
+				c = pricing.WithVariant(c, variant)
+			}
 		}
 
 		var endDate *string
@@ -61,44 +116,61 @@ func (s *Service) ListForUser(ctx context.Context) ([]ForUserCampaignResponse, e
 			endDate = &s
 		}
 
-		badgeResponses := make([]BadgeResponse, len(badges))
-		for i, b := range badges {
-			badgeResponses[i] = BadgeResponse{
-				ID:          b.ID,
-				Name:        b.Name,
-				Description: b.Description,
-				Icon:        b.Icon,
-			}
-		}
-
 		result = append(result, ForUserCampaignResponse{
-			ID:            c.ID,
-			Title:         c.Title,
-			Description:   c.Description,
-			Discount:      c.Discount,
-			CoinReward:    c.CoinReward,
-			EndDate:       endDate,
-			TargetBadges:  badgeResponses,
-			MatchedBadges: []BadgeResponse{}, // stub — no badge matching
+			ID:                c.ID,
+			Title:             c.Title,
+			Description:       c.Description,
+			Discount:          pricing.CampaignDiscount(c).Label(),
+			DiscountType:      c.DiscountType,
+			DiscountValue:     c.DiscountValue,
+			CoinReward:        c.CoinReward,
+			EndDate:           endDate,
+			TargetBadges:      toBadgeResponses(targets),
+			MatchedBadges:     toBadgeResponses(check.MatchedBadges),
+			Eligible:          check.Eligible,
+			Priority:          c.Priority,
+			Exclusive:         c.Exclusive,
+			Stackable:         c.Stackable,
+			IneligibleReasons: check.Reasons,
+
+			StartDate: formatDate(c.StartDate),
+			Timezone:  c.Timezone,
+			Windows:   toWindowResponses(windows),
+			ActiveNow: pricing.CampaignSchedule(c, windows).ActiveAt(now),
 		})
 	}
-	return result, nil
+
+	return &ForUserResponse{
+		Campaigns:  result,
+		UserBadges: toBadgeResponses(owned),
+	}, nil
 }
 
 // Create creates a new campaign with optional target badge linking.
-func (s *Service) Create(ctx context.Context, ownerID int32, req CreateCampaignRequest) (*CampaignResponse, error) {
-	// Parse endDate
-	var endDate pgtype.Timestamptz
-	if req.EndDate != nil && *req.EndDate != "" {
-		t, err := time.Parse(time.RFC3339, *req.EndDate)
-		if err != nil {
-			// Try date-only fallback
-			t, err = time.Parse("2006-01-02", *req.EndDate)
-			if err != nil {
-				return nil, apperrors.NewValidationError("Invalid endDate format")
-			}
-		}
-		endDate = pgtype.Timestamptz{Time: t, Valid: true}
+// Only operators can create campaigns, and only for their own stations.
+func (s *Service) Create(ctx context.Context, actor policy.Actor, req CreateCampaignRequest) (*CampaignResponse, error) {
+	if err := policy.RequireRole(actor, policy.RoleOperator); err != nil {
+		return nil, err
+	}
+	if err := s.checkStation(ctx, actor, req.StationID); err != nil {
+		return nil, err
+	}
+
+	// Parse schedule
+	startDate, err := parseDate(req.StartDate, "startDate")
+	if err != nil {
+		return nil, err
+	}
+	endDate, err := parseDate(req.EndDate, "endDate")
+	if err != nil {
+		return nil, err
+	}
+	timezone, err := resolveTimezone(req.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateWindows(req.Windows); err != nil {
+		return nil, err
 	}
 
 	// Parse stationId
@@ -113,64 +185,117 @@ func (s *Service) Create(ctx context.Context, ownerID int32, req CreateCampaignR
 		coinReward = *req.CoinReward
 	}
 
-	// Default status to ACTIVE
-	status := req.Status
-	if status == "" {
-		status = "ACTIVE"
+	discount, err := resolveDiscount(req.DiscountType, req.DiscountValue, req.Discount)
+	if err != nil {
+		return nil, err
+	}
+
+	vehicleTypes, err := normalizeVehicleTypes(req.VehicleTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateStacking(discount, req.Exclusive, req.Stackable); err != nil {
+		return nil, err
+	}
+
+	caps, err := resolveBudget(req.CoinBudget, req.DiscountBudget, req.MaxRedemptions, req.MaxRedemptionsPerUser)
+	if err != nil {
+		return nil, err
 	}
 
-	campaign, err := s.queries.CreateCampaign(ctx, generated.CreateCampaignParams{
-		Title:       req.Title,
-		Description: req.Description,
-		Status:      status,
-		Target:      req.Target,
-		Discount:    req.Discount,
-		EndDate:     endDate,
-		OwnerID:     ownerID,
-		StationID:   stationID,
-		CoinReward:  coinReward,
+	// Default status to ACTIVE; the schedule may hold it as DRAFT until startDate
+	status, err := resolveStatus(req.Status, StatusActive, startDate, endDate, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// The campaign, its badge links and windows are written together or not at all
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.queries.WithTx(tx)
+
+	campaign, err := qtx.CreateCampaign(ctx, generated.CreateCampaignParams{
+		Title:         req.Title,
+		Description:   req.Description,
+		Status:        status,
+		Target:        req.Target,
+		DiscountType:  discount.Type,
+		DiscountValue: discount.Value,
+		EndDate:       endDate,
+		OwnerID:       actor.UserID,
+		StationID:     stationID,
+		CoinReward:    coinReward,
+
+		NewCustomersOnly: req.NewCustomersOnly,
+		VehicleTypes:     vehicleTypes,
+		Priority:         req.Priority,
+		Exclusive:        req.Exclusive,
+		Stackable:        req.Stackable,
+		StartDate:        startDate,
+		Timezone:         timezone,
+
+		CoinBudget:            caps.coins,
+		DiscountBudget:        caps.discount,
+		MaxRedemptions:        caps.maxRedemptions,
+		MaxRedemptionsPerUser: caps.maxPerUser,
 	})
 	if err != nil {
 		return nil, apperrors.ErrInternal
 	}
 
-	// Link target badges
-	for _, badgeID := range req.TargetBadgeIDs {
-		_ = s.queries.AddCampaignTargetBadge(ctx, generated.AddCampaignTargetBadgeParams{
-			CampaignID: campaign.ID,
-			BadgeID:    badgeID,
-		})
+	if err := linkBadges(ctx, qtx, campaign.ID, req.TargetBadgeIDs); err != nil {
+		return nil, err
+	}
+
+	windows, err := replaceWindows(ctx, qtx, campaign.ID, req.Windows)
+	if err != nil {
+		return nil, apperrors.ErrInternal
 	}
 
 	// Fetch linked badges for response
-	badges, err := s.queries.GetCampaignTargetBadges(ctx, campaign.ID)
+	badges, err := qtx.GetCampaignTargetBadges(ctx, campaign.ID)
 	if err != nil {
-		badges = []generated.Badge{}
+		return nil, apperrors.ErrInternal
 	}
 
-	resp := campaignToResponse(campaign, nil, badges)
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	resp := campaignToResponse(campaign, nil, badges, windows)
 	return &resp, nil
 }
 
 // Update updates a campaign's fields and reconnects target badges (disconnect all, then reconnect).
-func (s *Service) Update(ctx context.Context, campaignID int32, req UpdateCampaignRequest) (*CampaignResponse, error) {
-	// Verify campaign exists
-	existing, err := s.queries.GetCampaignByID(ctx, campaignID)
+func (s *Service) Update(ctx context.Context, actor policy.Actor, campaignID int32, req UpdateCampaignRequest) (*CampaignResponse, error) {
+	existing, err := s.ownedCampaign(ctx, actor, campaignID)
 	if err != nil {
-		return nil, apperrors.NewNotFoundError("Campaign")
+		return nil, err
+	}
+	if err := s.checkStation(ctx, actor, req.StationID); err != nil {
+		return nil, err
 	}
 
-	// Parse endDate
-	var endDate pgtype.Timestamptz
-	if req.EndDate != nil && *req.EndDate != "" {
-		t, err := time.Parse(time.RFC3339, *req.EndDate)
-		if err != nil {
-			t, err = time.Parse("2006-01-02", *req.EndDate)
-			if err != nil {
-				return nil, apperrors.NewValidationError("Invalid endDate format")
-			}
-		}
-		endDate = pgtype.Timestamptz{Time: t, Valid: true}
+	// Parse schedule
+	startDate, err := parseDate(req.StartDate, "startDate")
+	if err != nil {
+		return nil, err
+	}
+	endDate, err := parseDate(req.EndDate, "endDate")
+	if err != nil {
+		return nil, err
+	}
+	timezone, err := resolveTimezone(req.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateWindows(req.Windows); err != nil {
+		return nil, err
 	}
 
 	// Parse stationId
@@ -185,63 +310,136 @@ func (s *Service) Update(ctx context.Context, campaignID int32, req UpdateCampai
 		coinReward = *req.CoinReward
 	}
 
+	discount, err := resolveDiscount(req.DiscountType, req.DiscountValue, req.Discount)
+	if err != nil {
+		return nil, err
+	}
+
+	vehicleTypes, err := normalizeVehicleTypes(req.VehicleTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateStacking(discount, req.Exclusive, req.Stackable); err != nil {
+		return nil, err
+	}
+
+	if discount.Type == pricing.DiscountFreeHours {
+		variants, err := s.queries.ListCampaignVariants(ctx, campaignID)
+		if err != nil {
+			return nil, apperrors.ErrInternal
+		}
+		if len(variants) > 0 {
+			return nil, apperrors.NewValidationError("End the campaign's experiment before switching it to free hours")
+		}
+	}
+
+	caps, err := resolveBudget(req.CoinBudget, req.DiscountBudget, req.MaxRedemptions, req.MaxRedemptionsPerUser)
+	if err != nil {
+		return nil, err
+	}
+
 	// Default status — keep existing if not provided
-	status := req.Status
-	if status == "" {
-		status = existing.Status
+	status, err := resolveStatus(req.Status, existing.Status, startDate, endDate, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if status == StatusActive {
+		if err := checkResumable(existing, caps); err != nil {
+			return nil, err
+		}
 	}
 
-	// Step 1: disconnect all existing badges
-	if err := s.queries.RemoveCampaignTargetBadges(ctx, campaignID); err != nil {
+	// Campaign fields, badge links and windows are replaced together or not at all
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
 		return nil, apperrors.ErrInternal
 	}
-
-	// Step 2: update campaign fields
-	updated, err := s.queries.UpdateCampaign(ctx, generated.UpdateCampaignParams{
-		ID:          campaignID,
-		Title:       req.Title,
-		Description: req.Description,
-		Status:      status,
-		Target:      req.Target,
-		Discount:    req.Discount,
-		EndDate:     endDate,
-		StationID:   stationID,
-		CoinReward:  coinReward,
+	defer tx.Rollback(ctx)
+
+	qtx := s.queries.WithTx(tx)
+
+	// Step 1: update campaign fields
+	updated, err := qtx.UpdateCampaign(ctx, generated.UpdateCampaignParams{
+		ID:            campaignID,
+		Title:         req.Title,
+		Description:   req.Description,
+		Status:        status,
+		Target:        req.Target,
+		DiscountType:  discount.Type,
+		DiscountValue: discount.Value,
+		EndDate:       endDate,
+		StationID:     stationID,
+		CoinReward:    coinReward,
+
+		NewCustomersOnly: req.NewCustomersOnly,
+		VehicleTypes:     vehicleTypes,
+		Priority:         req.Priority,
+		Exclusive:        req.Exclusive,
+		Stackable:        req.Stackable,
+		StartDate:        startDate,
+		Timezone:         timezone,
+
+		CoinBudget:            caps.coins,
+		DiscountBudget:        caps.discount,
+		MaxRedemptions:        caps.maxRedemptions,
+		MaxRedemptionsPerUser: caps.maxPerUser,
 	})
 	if err != nil {
 		return nil, apperrors.ErrInternal
 	}
 
-	// Step 3: reconnect new badges
-	for _, badgeID := range req.TargetBadgeIDs {
-		_ = s.queries.AddCampaignTargetBadge(ctx, generated.AddCampaignTargetBadgeParams{
-			CampaignID: campaignID,
-			BadgeID:    badgeID,
-		})
+	// Step 2: replace the target badges
+	if err := qtx.RemoveCampaignTargetBadges(ctx, campaignID); err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	if err := linkBadges(ctx, qtx, campaignID, req.TargetBadgeIDs); err != nil {
+		return nil, err
+	}
+
+	// Step 3: replace the weekly windows
+	windows, err := replaceWindows(ctx, qtx, campaignID, req.Windows)
+	if err != nil {
+		return nil, apperrors.ErrInternal
 	}
 
 	// Fetch badges for response
-	badges, err := s.queries.GetCampaignTargetBadges(ctx, campaignID)
+	badges, err := qtx.GetCampaignTargetBadges(ctx, campaignID)
 	if err != nil {
-		badges = []generated.Badge{}
+		return nil, apperrors.ErrInternal
 	}
 
-	resp := campaignToResponse(updated, nil, badges)
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	resp := campaignToResponse(updated, nil, badges, windows)
 	return &resp, nil
 }
 
 // Delete deletes a campaign by ID.
-func (s *Service) Delete(ctx context.Context, campaignID int32) error {
-	// Verify it exists
-	_, err := s.queries.GetCampaignByID(ctx, campaignID)
+func (s *Service) Delete(ctx context.Context, actor policy.Actor, campaignID int32) error {
+	if _, err := s.ownedCampaign(ctx, actor, campaignID); err != nil {
+		return err
+	}
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
-		return apperrors.NewNotFoundError("Campaign")
+		return apperrors.ErrInternal
 	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.queries.WithTx(tx)
 
 	// Remove badge links first (FK constraint)
-	_ = s.queries.RemoveCampaignTargetBadges(ctx, campaignID)
+	if err := qtx.RemoveCampaignTargetBadges(ctx, campaignID); err != nil {
+		return apperrors.ErrInternal
+	}
+	if err := qtx.DeleteCampaign(ctx, campaignID); err != nil {
+		return apperrors.ErrInternal
+	}
 
-	if err := s.queries.DeleteCampaign(ctx, campaignID); err != nil {
+	if err := tx.Commit(ctx); err != nil {
 		return apperrors.ErrInternal
 	}
 	return nil
@@ -249,7 +447,107 @@ func (s *Service) Delete(ctx context.Context, campaignID int32) error {
 
 // --- helpers ---
 
-func campaignRowToResponse(row generated.ListCampaignsByOwnerRow, badges []generated.Badge) CampaignResponse {
+// linkBadges links the campaign's target badges in a single insert.
+// IDs that don't match a badge are reported as a validation error.
+func linkBadges(ctx context.Context, q *generated.Queries, campaignID int32, badgeIDs []int32) error {
+	if len(badgeIDs) == 0 {
+		return nil
+	}
+	linked, err := q.AddCampaignTargetBadges(ctx, generated.AddCampaignTargetBadgesParams{
+		CampaignID: campaignID,
+		BadgeIds:   badgeIDs,
+	})
+	if err != nil {
+		return apperrors.ErrInternal
+	}
+
+	found := make(map[int32]bool, len(linked))
+	for _, id := range linked {
+		found[id] = true
+	}
+	var unknown []string
+	for _, id := range badgeIDs {
+		if !found[id] {
+			found[id] = true // report each ID once
+			unknown = append(unknown, strconv.Itoa(int(id)))
+		}
+	}
+	if len(unknown) > 0 {
+		return apperrors.NewValidationError("Unknown target badge IDs: " + strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// checkStation makes sure a station-scoped campaign targets one of the caller's stations.
+func (s *Service) checkStation(ctx context.Context, actor policy.Actor, stationID *int32) error {
+	if stationID == nil {
+		return nil
+	}
+	station, err := s.queries.GetStationByID(ctx, *stationID)
+	if err != nil {
+		return apperrors.NewNotFoundError("Station")
+	}
+	return policy.RequireStationOwner(actor, station.OwnerID)
+}
+
+// resolveDiscount validates a typed discount, falling back to parsing legacy discount text.
+func resolveDiscount(discountType string, value float64, legacy string) (pricing.DiscountRule, error) {
+	d := pricing.DiscountRule{Type: strings.ToUpper(strings.TrimSpace(discountType)), Value: value}
+	var err error
+	if d.Type == "" {
+		d, err = pricing.ParseLegacyDiscount(legacy)
+	} else {
+		err = d.Validate()
+	}
+	if err != nil {
+		return pricing.DiscountRule{}, apperrors.NewValidationError(err.Error())
+	}
+	return d, nil
+}
+
+// validateStacking rejects stacking flags that contradict each other or the discount.
+// Free hours zero the price, so they can't be combined with other discounts.
+func validateStacking(discount pricing.DiscountRule, exclusive, stackable bool) error {
+	if exclusive && stackable {
+		return apperrors.NewValidationError("A campaign cannot be both exclusive and stackable")
+	}
+	if stackable && discount.Type == pricing.DiscountFreeHours {
+		return apperrors.NewValidationError("Free-hour campaigns cannot be stackable")
+	}
+	return nil
+}
+
+// normalizeVehicleTypes upper-cases and de-duplicates vehicle types, rejecting unknown ones.
+func normalizeVehicleTypes(in []string) ([]string, error) {
+	out := []string{}
+	seen := make(map[string]bool, len(in))
+	for _, v := range in {
+		t, ok := pricing.NormalizeVehicleType(v)
+		if !ok {
+			return nil, apperrors.NewValidationError(fmt.Sprintf("Unknown vehicle type %q, expected one of %s", v, strings.Join(pricing.VehicleTypes, ", ")))
+		}
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func toBadgeResponses(badges []generated.Badge) []BadgeResponse {
+	out := make([]BadgeResponse, len(badges))
+	for i, b := range badges {
+		out[i] = BadgeResponse{
+			ID:          b.ID,
+			Name:        b.Name,
+			Description: b.Description,
+			Icon:        b.Icon,
+		}
+	}
+	return out
+}
+
+func campaignRowToResponse(row generated.ListCampaignsByOwnerRow, badges []generated.Badge, windows []generated.CampaignWindow) CampaignResponse {
 	var endDate *string
 	if row.EndDate.Valid {
 		s := row.EndDate.Time.UTC().Format(time.RFC3339)
@@ -276,35 +574,41 @@ func campaignRowToResponse(row generated.ListCampaignsByOwnerRow, badges []gener
 		updatedAt = row.UpdatedAt.Time.UTC().Format(time.RFC3339)
 	}
 
-	badgeResponses := make([]BadgeResponse, len(badges))
-	for i, b := range badges {
-		badgeResponses[i] = BadgeResponse{
-			ID:          b.ID,
-			Name:        b.Name,
-			Description: b.Description,
-			Icon:        b.Icon,
-		}
-	}
-
 	return CampaignResponse{
-		ID:           row.ID,
-		Title:        row.Title,
-		Description:  row.Description,
-		Status:       row.Status,
-		Target:       row.Target,
-		Discount:     row.Discount,
-		EndDate:      endDate,
-		OwnerID:      row.OwnerID,
-		StationID:    stationID,
-		StationName:  stationName,
-		CoinReward:   row.CoinReward,
-		CreatedAt:    createdAt,
-		UpdatedAt:    updatedAt,
-		TargetBadges: badgeResponses,
+		ID:            row.ID,
+		Title:         row.Title,
+		Description:   row.Description,
+		Status:        row.Status,
+		Target:        row.Target,
+		Discount:      pricing.DiscountRule{Type: row.DiscountType, Value: row.DiscountValue}.Label(),
+		DiscountType:  row.DiscountType,
+		DiscountValue: row.DiscountValue,
+		EndDate:       endDate,
+		OwnerID:       row.OwnerID,
+		StationID:     stationID,
+		StationName:   stationName,
+		CoinReward:    row.CoinReward,
+		CreatedAt:     createdAt,
+		UpdatedAt:     updatedAt,
+		TargetBadges:  toBadgeResponses(badges),
+
+		NewCustomersOnly: row.NewCustomersOnly,
+		VehicleTypes:     row.VehicleTypes,
+		Priority:         row.Priority,
+		Exclusive:        row.Exclusive,
+		Stackable:        row.Stackable,
+
+		StartDate: formatDate(row.StartDate),
+		Timezone:  row.Timezone,
+		Windows:   toWindowResponses(windows),
+
+		Budget: toBudgetResponse(row.CoinBudget, row.DiscountBudget, row.MaxRedemptions, row.MaxRedemptionsPerUser,
+			row.CoinsSpent, row.DiscountSpent, row.Redemptions),
+		PausedReason: optionalText(row.PausedReason),
 	}
 }
 
-func campaignToResponse(c generated.Campaign, stationName *string, badges []generated.Badge) CampaignResponse {
+func campaignToResponse(c generated.Campaign, stationName *string, badges []generated.Badge, windows []generated.CampaignWindow) CampaignResponse {
 	var endDate *string
 	if c.EndDate.Valid {
 		s := c.EndDate.Time.UTC().Format(time.RFC3339)
@@ -326,30 +630,36 @@ func campaignToResponse(c generated.Campaign, stationName *string, badges []gene
 		updatedAt = c.UpdatedAt.Time.UTC().Format(time.RFC3339)
 	}
 
-	badgeResponses := make([]BadgeResponse, len(badges))
-	for i, b := range badges {
-		badgeResponses[i] = BadgeResponse{
-			ID:          b.ID,
-			Name:        b.Name,
-			Description: b.Description,
-			Icon:        b.Icon,
-		}
-	}
-
 	return CampaignResponse{
-		ID:           c.ID,
-		Title:        c.Title,
-		Description:  c.Description,
-		Status:       c.Status,
-		Target:       c.Target,
-		Discount:     c.Discount,
-		EndDate:      endDate,
-		OwnerID:      c.OwnerID,
-		StationID:    stationID,
-		StationName:  stationName,
-		CoinReward:   c.CoinReward,
-		CreatedAt:    createdAt,
-		UpdatedAt:    updatedAt,
-		TargetBadges: badgeResponses,
+		ID:            c.ID,
+		Title:         c.Title,
+		Description:   c.Description,
+		Status:        c.Status,
+		Target:        c.Target,
+		Discount:      pricing.CampaignDiscount(c).Label(),
+		DiscountType:  c.DiscountType,
+		DiscountValue: c.DiscountValue,
+		EndDate:       endDate,
+		OwnerID:       c.OwnerID,
+		StationID:     stationID,
+		StationName:   stationName,
+		CoinReward:    c.CoinReward,
+		CreatedAt:     createdAt,
+		UpdatedAt:     updatedAt,
+		TargetBadges:  toBadgeResponses(badges),
+
+		NewCustomersOnly: c.NewCustomersOnly,
+		VehicleTypes:     c.VehicleTypes,
+		Priority:         c.Priority,
+		Exclusive:        c.Exclusive,
+		Stackable:        c.Stackable,
+
+		StartDate: formatDate(c.StartDate),
+		Timezone:  c.Timezone,
+		Windows:   toWindowResponses(windows),
+
+		Budget: toBudgetResponse(c.CoinBudget, c.DiscountBudget, c.MaxRedemptions, c.MaxRedemptionsPerUser,
+			c.CoinsSpent, c.DiscountSpent, c.Redemptions),
+		PausedReason: optionalText(c.PausedReason),
 	}
 }
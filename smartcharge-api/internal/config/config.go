@@ -2,6 +2,8 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -12,6 +14,31 @@ type Config struct {
 	Port        string
 	GinMode     string
 	FrontendURL string
+
+	// Zone charging slots are laid out in (an IANA name); empty means pricing.DefaultTimezone
+	Timezone string
+
+	// Coin expiry
+	CoinExpiryMonths      int
+	CoinExpiryWarningDays int
+	CoinExpiryInterval    time.Duration
+
+	// Badges
+	BadgeBackfillInterval time.Duration
+
+	// Campaigns
+	CampaignScheduleInterval time.Duration
+
+	// Forecasts
+	ForecastRetrainInterval  time.Duration
+	ForecastAccuracyInterval time.Duration
+
+	// Weather: fetched from WeatherURL every WeatherFetchInterval; an empty URL disables fetching
+	WeatherURL           string
+	WeatherFetchInterval time.Duration
+
+	// Uploaded assets (badge icons), served under /assets
+	AssetsDir string
 }
 
 func Load() *Config {
@@ -23,6 +50,24 @@ func Load() *Config {
 		Port:        getEnv("PORT", "8080"),
 		GinMode:     getEnv("GIN_MODE", "debug"),
 		FrontendURL: getEnv("FRONTEND_URL", "http://localhost:3000"),
+
+		Timezone: getEnv("TIMEZONE", ""),
+
+		CoinExpiryMonths:      getEnvInt("COIN_EXPIRY_MONTHS", 12),
+		CoinExpiryWarningDays: getEnvInt("COIN_EXPIRY_WARNING_DAYS", 30),
+		CoinExpiryInterval:    getEnvDuration("COIN_EXPIRY_INTERVAL", 24*time.Hour),
+
+		BadgeBackfillInterval: getEnvDuration("BADGE_BACKFILL_INTERVAL", 24*time.Hour),
+
+		CampaignScheduleInterval: getEnvDuration("CAMPAIGN_SCHEDULE_INTERVAL", time.Minute),
+
+		ForecastRetrainInterval:  getEnvDuration("FORECAST_RETRAIN_INTERVAL", 6*time.Hour),
+		ForecastAccuracyInterval: getEnvDuration("FORECAST_ACCURACY_INTERVAL", 24*time.Hour),
+
+		WeatherURL:           getEnv("WEATHER_URL", ""),
+		WeatherFetchInterval: getEnvDuration("WEATHER_FETCH_INTERVAL", time.Hour),
+
+		AssetsDir: getEnv("ASSETS_DIR", "./assets"),
 	}
 
 	return cfg
@@ -34,3 +79,22 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+func getEnvInt(key string, fallback int) int {
+	if v := os.Getenv(key); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			return n
+		}
+	}
+	return fallback
+}
+
+// getEnvDuration reads a positive duration; unparseable, zero and negative values fall back.
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			return d
+		}
+	}
+	return fallback
+}
@@ -22,6 +22,8 @@ var (
 	ErrValidation         = &AppError{http.StatusBadRequest, "VALIDATION_ERROR", "Invalid input"}
 	ErrConflict           = &AppError{http.StatusConflict, "RESOURCE_CONFLICT", "Resource already exists"}
 	ErrAlreadyCompleted   = &AppError{http.StatusBadRequest, "RESERVATION_ALREADY_COMPLETED", "Reservation is already completed"}
+	ErrInsufficientCoins  = &AppError{http.StatusBadRequest, "WALLET_INSUFFICIENT_COINS", "Not enough SmartCoins"}
+	ErrCampaignExhausted  = &AppError{http.StatusConflict, "CAMPAIGN_EXHAUSTED", "A campaign on this booking has run out; please refresh the prices"}
 	ErrInternal           = &AppError{http.StatusInternalServerError, "INTERNAL_ERROR", "An unexpected error occurred"}
 )
 
@@ -0,0 +1,119 @@
+package forecast
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"smartcharge-api/db/generated"
+)
+
+// EvaluationWindow is the stretch of history a backtest scores a forecast on: the week it forecasts.
+const EvaluationWindow = 7 * 24 * time.Hour
+
+// Accuracy is how far a P50 forecast was from the actual load over a set of hours, in load points.
+type Accuracy struct {
+	Samples int
+	MAE     float64 // mean absolute error
+	Bias    float64 // mean of forecast minus actual; positive when the forecast ran high
+
+	// MAPE is the mean absolute percentage error over the MAPESamples hours with a non-zero load
+	// (an idle hour has no percentage error). Zero if there were none.
+	MAPE        float64
+	MAPESamples int
+}
+
+// Evaluation is a backtest's accuracy, per hour of day and overall.
+type Evaluation struct {
+	Model   string
+	Overall Accuracy
+	ByHour  [24]Accuracy
+}
+
+// errorSum accumulates forecast errors into an Accuracy.
+type errorSum struct {
+	n, pctN          int
+	abs, signed, pct float64
+}
+
+func (s *errorSum) add(predicted, actual float64) {
+	e := predicted - actual
+	s.n++
+	s.abs += math.Abs(e)
+	s.signed += e
+	if actual > 0 {
+		s.pctN++
+		s.pct += math.Abs(e) / actual * 100
+	}
+}
+
+func (s errorSum) accuracy() Accuracy {
+	a := Accuracy{Samples: s.n, MAPESamples: s.pctN}
+	if s.n > 0 {
+		a.MAE = s.abs / float64(s.n)
+		a.Bias = s.signed / float64(s.n)
+	}
+	if s.pctN > 0 {
+		a.MAPE = s.pct / float64(s.pctN)
+	}
+	return a
+}
+
+// Backtest replays the model as if it had been trained at each cutoff: it forecasts the EvaluationWindow after
+// the cutoff from the history before it, calendar events and weather included, and scores the forecast against
+// what happened. The weather is what was observed, so the score assumes a perfect weather forecast.
+// Errors from all cutoffs are pooled. It reports false if no cutoff had both training and actual data.
+func Backtest(history []Sample, events []Event, weather WeatherSeries, model Model, cutoffs ...time.Time) (Evaluation, bool) {
+	var overall errorSum
+	var byHour [24]errorSum
+	for _, cutoff := range cutoffs {
+		_, slots, ok := ForecastDates(model, history, events, weather, cutoff, EvaluationWindow)
+		if !ok {
+			continue
+		}
+		predicted := make(map[int64]float64, len(slots))
+		for _, s := range slots {
+			predicted[s.Start.Unix()] = float64(s.P50)
+		}
+
+		for _, s := range history {
+			p, ok := predicted[s.Time.Unix()]
+			if !ok {
+				continue
+			}
+			_, hour := SlotOf(s.Time)
+			overall.add(p, s.Load)
+			byHour[hour].add(p, s.Load)
+		}
+	}
+	if overall.n == 0 {
+		return Evaluation{}, false
+	}
+
+	e := Evaluation{Model: model.Name(), Overall: overall.accuracy()}
+	for hour := range byHour {
+		e.ByHour[hour] = byHour[hour].accuracy()
+	}
+	return e, true
+}
+
+// Evaluate backtests the model on the station's last EvaluationWindow: trained on the history before it,
+// as the scheduled retraining would have been, and scored on what actually happened since.
+// It reports false if the station doesn't have the history for it.
+func Evaluate(ctx context.Context, q *generated.Queries, model Model, stationID int32, now time.Time) (Evaluation, bool, error) {
+	cutoff := now.Add(-EvaluationWindow).Truncate(time.Hour)
+	history, err := LoadHistory(ctx, q, stationID, cutoff.Add(-EventHistoryWindow), now.Truncate(time.Hour))
+	if err != nil {
+		return Evaluation{}, false, err
+	}
+	events, err := LoadEvents(ctx, q, stationID, cutoff.Add(-EventHistoryWindow), now)
+	if err != nil {
+		return Evaluation{}, false, err
+	}
+	weather, err := LoadWeather(ctx, q, stationID, cutoff.Add(-HistoryWindow), now)
+	if err != nil {
+		return Evaluation{}, false, err
+	}
+	e, ok := Backtest(history, events, weather, model, cutoff)
+	return e, ok, nil
+}
@@ -0,0 +1,199 @@
+package forecast
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"smartcharge-api/db/generated"
+)
+
+// Calendar event kinds.
+const (
+	EventHoliday = "HOLIDAY" // public holiday, imported for every station
+	EventSpecial = "EVENT"   // anything else that changes a station's load, such as a concert nearby
+)
+
+// Horizon is how far ahead dated forecasts go.
+const Horizon = 14 * 24 * time.Hour
+
+// EventHistoryWindow is how far back the effect of events is learned from:
+// a little over a year, so every annual holiday has been seen at least once.
+const EventHistoryWindow = 400 * 24 * time.Hour
+
+// Event is a holiday or special event that changes a station's usual load while it lasts.
+type Event struct {
+	Name       string
+	Kind       string
+	Start, End time.Time
+	// Impact is the expected change in load, in points. Nil learns it from history:
+	// past events of the same name, failing that of the same kind.
+	Impact *float64
+}
+
+func (e Event) covers(t time.Time) bool {
+	return !t.Before(e.Start) && t.Before(e.End)
+}
+
+// Slot is the forecast load for one dated hour.
+type Slot struct {
+	Start         time.Time
+	P10, P50, P90 int32
+	Event         string         // the event the forecast was adjusted for; empty if none
+	Weather       *WeatherEffect // the weather the forecast was adjusted for; nil if unknown
+}
+
+// LoadEvents returns the events that apply to the station and overlap the window, in start order.
+func LoadEvents(ctx context.Context, q *generated.Queries, stationID int32, from, to time.Time) ([]Event, error) {
+	rows, err := q.ListCalendarEvents(ctx, generated.ListCalendarEventsParams{
+		StationID:   pgtype.Int4{Int32: stationID, Valid: true},
+		WindowStart: pgtype.Timestamptz{Time: from, Valid: true},
+		WindowEnd:   pgtype.Timestamptz{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, len(rows))
+	for i, r := range rows {
+		events[i] = Event{Name: r.Name, Kind: r.Kind, Start: r.StartsAt.Time, End: r.EndsAt.Time}
+		if r.Impact.Valid {
+			impact := float64(r.Impact.Int32)
+			events[i].Impact = &impact
+		}
+	}
+	return events, nil
+}
+
+// ForecastDates forecasts every hour from `from` (an hour start) until the horizon has passed,
+// using only the history before `from`.
+//
+// The model is trained on the last HistoryWindow of ordinary hours, so holidays don't distort the usual week;
+// that weekly forecast is returned as well. Hours during an event are then shifted by the event's effect:
+// its Impact if set, otherwise how far actual load departed from the usual week during past events
+// of the same name, or failing that of the same kind. Overlapping events don't add up; the strongest applies.
+// Hours whose weather is known are shifted as well, by how load has departed from the usual week
+// in the same weather (see fitWeather); weather is a regressor for every model this way.
+// It reports false if there is no history before `from`.
+func ForecastDates(model Model, history []Sample, events []Event, weather WeatherSeries, from time.Time, horizon time.Duration) (Prediction, []Slot, bool) {
+	var recent, ordinary []Sample
+	for _, s := range history {
+		if !s.Time.Before(from) || s.Time.Before(from.Add(-HistoryWindow)) {
+			continue
+		}
+		recent = append(recent, s)
+		if eventAt(events, s.Time) == nil {
+			ordinary = append(ordinary, s)
+		}
+	}
+	if len(ordinary) == 0 {
+		// Every recent hour was eventful; better than nothing
+		ordinary = recent
+	}
+	if len(ordinary) == 0 {
+		return Prediction{}, nil, false
+	}
+
+	p := model.Forecast(ordinary)
+	byName, byKind := learnEffects(p, history, events, from)
+	wm, hasWeather := fitWeather(p, ordinary, weather)
+
+	effect := func(e Event) float64 {
+		if e.Impact != nil {
+			return *e.Impact
+		}
+		if v, ok := byName[eventKey(e.Name)]; ok {
+			return v
+		}
+		return byKind[e.Kind]
+	}
+
+	slots := make([]Slot, 0, int(horizon/time.Hour))
+	for t := from; t.Before(from.Add(horizon)); t = t.Add(time.Hour) {
+		day, hour := SlotOf(t)
+		p10, p50, p90 := float64(p.P10[day][hour]), float64(p.P50[day][hour]), float64(p.P90[day][hour])
+		slot := Slot{Start: t}
+
+		var strongest float64
+		for _, e := range events {
+			if e.covers(t) {
+				if v := effect(e); slot.Event == "" || math.Abs(v) > math.Abs(strongest) {
+					strongest, slot.Event = v, e.Name
+				}
+			}
+		}
+
+		shift := strongest
+		if w, ok := weather.at(t); ok && hasWeather {
+			we := wm.effect(w)
+			slot.Weather = &we
+			shift += we.Total()
+		}
+
+		slot.P50 = clampLoad(p50 + shift)
+		slot.P10 = min(slot.P50, clampLoad(p10+shift))
+		slot.P90 = max(slot.P50, clampLoad(p90+shift))
+		slots = append(slots, slot)
+	}
+	return p, slots, true
+}
+
+// learnEffects measures how far actual load departed from the weekly forecast during each past event,
+// averaged by event name and by kind.
+func learnEffects(p Prediction, history []Sample, events []Event, before time.Time) (byName, byKind map[string]float64) {
+	type sum struct {
+		total float64
+		n     int
+	}
+	names := map[string]*sum{}
+	kinds := map[string]*sum{}
+	add := func(m map[string]*sum, key string, v float64) {
+		if m[key] == nil {
+			m[key] = &sum{}
+		}
+		m[key].total += v
+		m[key].n++
+	}
+
+	for _, s := range history {
+		if !s.Time.Before(before) {
+			break
+		}
+		e := eventAt(events, s.Time)
+		if e == nil {
+			continue
+		}
+		day, hour := SlotOf(s.Time)
+		diff := s.Load - float64(p.P50[day][hour])
+		add(names, eventKey(e.Name), diff)
+		add(kinds, e.Kind, diff)
+	}
+
+	byName = make(map[string]float64, len(names))
+	for k, v := range names {
+		byName[k] = v.total / float64(v.n)
+	}
+	byKind = make(map[string]float64, len(kinds))
+	for k, v := range kinds {
+		byKind[k] = v.total / float64(v.n)
+	}
+	return byName, byKind
+}
+
+// eventAt returns the first event covering t, or nil.
+func eventAt(events []Event, t time.Time) *Event {
+	for i := range events {
+		if events[i].covers(t) {
+			return &events[i]
+		}
+	}
+	return nil
+}
+
+// eventKey matches recurring events by name, ignoring case and surrounding space.
+func eventKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -0,0 +1,97 @@
+package forecast
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"smartcharge-api/db/generated"
+	"smartcharge-api/internal/pricing"
+)
+
+// Occupancy reading sources.
+const (
+	SourceOperator  = "OPERATOR"  // pushed by the station operator
+	SourceSimulated = "SIMULATED" // generated by the seed script
+)
+
+// HistoryWindow is how far back training looks: eight whole weeks, so every weekly slot gets eight samples.
+const HistoryWindow = 8 * 7 * 24 * time.Hour
+
+// ReservationLoad is the load one booked session is taken to add to an hour with no occupancy reading.
+// Stations don't record their charger count, so bookings can only approximate load.
+const ReservationLoad = 25.0
+
+// Record stores occupancy readings for a station, one per hour; a later reading for the same hour replaces the earlier one.
+func Record(ctx context.Context, q *generated.Queries, stationID int32, source string, samples []Sample) error {
+	byHour := make(map[time.Time]int32, len(samples))
+	for _, s := range samples {
+		byHour[s.Time.Truncate(time.Hour).UTC()] = clampLoad(s.Load)
+	}
+	if len(byHour) == 0 {
+		return nil
+	}
+
+	params := generated.UpsertOccupancyParams{StationID: stationID, Source: source}
+	for t, load := range byHour {
+		params.ObservedAt = append(params.ObservedAt, pgtype.Timestamptz{Time: t, Valid: true})
+		params.Loads = append(params.Loads, load)
+	}
+	return q.UpsertOccupancy(ctx, params)
+}
+
+// LoadHistory returns the station's hourly load over [since, until), one sample per hour in time order.
+// Occupancy readings take precedence; hours without one but with bookings are estimated from the bookings,
+// and hours with neither had no load. History starts at the station's first reading or booking in the range,
+// so a newly added station isn't taken to have stood idle before it; one with neither has no history.
+func LoadHistory(ctx context.Context, q *generated.Queries, stationID int32, since, until time.Time) ([]Sample, error) {
+	readings, err := q.ListOccupancy(ctx, generated.ListOccupancyParams{
+		StationID:  stationID,
+		ObservedAt: pgtype.Timestamptz{Time: since, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	booked, err := q.ListReservationHours(ctx, generated.ListReservationHoursParams{
+		StationID: stationID,
+		Date:      pgtype.Timestamptz{Time: since, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(readings) == 0 && len(booked) == 0 {
+		return []Sample{}, nil
+	}
+
+	loads := make(map[time.Time]float64, len(readings)+len(booked))
+	var first time.Time
+	for _, b := range booked {
+		t := pricing.SlotStart(b.Date.Time, b.Hour).UTC()
+		loads[t] = math.Min(100, loads[t]+float64(b.Reservations)*ReservationLoad)
+		if first.IsZero() || t.Before(first) {
+			first = t
+		}
+	}
+	for _, r := range readings {
+		t := r.ObservedAt.Time.Truncate(time.Hour).UTC()
+		loads[t] = float64(r.Load)
+		if first.IsZero() || t.Before(first) {
+			first = t
+		}
+	}
+
+	start := since.Truncate(time.Hour)
+	if start.Before(since) {
+		start = start.Add(time.Hour)
+	}
+	if first.After(start) {
+		start = first
+	}
+	history := make([]Sample, 0, max(0, int(until.Sub(start)/time.Hour)+1))
+	for t := start.UTC(); t.Before(until); t = t.Add(time.Hour) {
+		history = append(history, Sample{Time: t, Load: loads[t]})
+	}
+	return history, nil
+}
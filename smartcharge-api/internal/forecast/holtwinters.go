@@ -0,0 +1,139 @@
+package forecast
+
+import (
+	"math"
+	"time"
+)
+
+// HoltWinters is additive exponential smoothing with a damped trend and two seasonal cycles, one daily and one
+// weekly (Taylor's double seasonal method). Unlike Linear, every hour informs the level and the daily pattern,
+// so it reacts to a recent shift within days instead of weeks and doesn't extrapolate a short-lived trend.
+// The smoothing parameters are fitted to each station's history by a grid search on the one-step-ahead error.
+type HoltWinters struct{}
+
+// hwDamping shrinks the trend each hour, so a week-ahead forecast flattens out instead of running away.
+const hwDamping = 0.98
+
+// hwParams are the smoothing weights of the level, trend, daily season and weekly season.
+type hwParams struct {
+	alpha, beta, gamma, omega float64
+}
+
+// hwState is the smoothed components after the last observed hour.
+type hwState struct {
+	level, trend float64
+	daily        [24]float64
+	weekly       [7][24]float64
+}
+
+// predict returns the load expected steps hours after the state's last hour, which falls in the given slot.
+func (s *hwState) predict(steps, day, hour int) float64 {
+	trend := 0.0
+	damp := 1.0
+	for i := 0; i < steps; i++ {
+		damp *= hwDamping
+		trend += damp * s.trend
+	}
+	return s.level + trend + s.daily[hour] + s.weekly[day][hour]
+}
+
+// Name implements Model.
+func (HoltWinters) Name() string {
+	return "holt-winters"
+}
+
+// Forecast implements Model. Hours missing from the history are skipped: the components carry on as predicted.
+// P10 and P90 come from the one-step-ahead errors of the fitted parameters.
+func (HoltWinters) Forecast(history []Sample) Prediction {
+	init := hwInit(history)
+
+	var best hwParams
+	bestSSE := math.Inf(1)
+	for _, alpha := range []float64{0.05, 0.1, 0.2, 0.4} {
+		for _, beta := range []float64{0, 0.01, 0.05} {
+			for _, gamma := range []float64{0.05, 0.1, 0.2} {
+				for _, omega := range []float64{0.05, 0.1, 0.2, 0.4} {
+					p := hwParams{alpha, beta, gamma, omega}
+					if sse, _, _ := hwFit(history, init, p); sse < bestSSE {
+						best, bestSSE = p, sse
+					}
+				}
+			}
+		}
+	}
+
+	_, state, residuals := hwFit(history, init, best)
+
+	var w Weekly
+	last := history[len(history)-1].Time.Truncate(time.Hour)
+	for step := 1; step <= 7*24; step++ {
+		day, hour := SlotOf(last.Add(time.Duration(step) * time.Hour))
+		w[day][hour] = clampLoad(state.predict(step, day, hour))
+	}
+	return withResidualBand(w, residuals)
+}
+
+// hwInit starts the level at the history's mean and each season at its average deviation from it.
+func hwInit(history []Sample) hwState {
+	var s hwState
+	var dailySum, dailyN [24]float64
+	var weeklySum, weeklyN [7][24]float64
+	for _, h := range history {
+		day, hour := SlotOf(h.Time)
+		s.level += h.Load
+		dailySum[hour] += h.Load
+		dailyN[hour]++
+		weeklySum[day][hour] += h.Load
+		weeklyN[day][hour]++
+	}
+	s.level /= float64(len(history))
+
+	for hour := range s.daily {
+		if dailyN[hour] > 0 {
+			s.daily[hour] = dailySum[hour]/dailyN[hour] - s.level
+		}
+	}
+	for day := range s.weekly {
+		for hour := range s.weekly[day] {
+			if weeklyN[day][hour] > 0 {
+				s.weekly[day][hour] = weeklySum[day][hour]/weeklyN[day][hour] - s.level - s.daily[hour]
+			}
+		}
+	}
+	return s
+}
+
+// hwFit runs the smoothing over the history hour by hour. It returns the sum of squared one-step-ahead errors,
+// the state after the last hour, and the errors grouped by hour of day.
+func hwFit(history []Sample, s hwState, p hwParams) (float64, hwState, [24][]float64) {
+	var residuals [24][]float64
+	sse := 0.0
+
+	t := history[0].Time.Truncate(time.Hour)
+	for i := 0; i < len(history); t = t.Add(time.Hour) {
+		day, hour := SlotOf(t)
+		forecast := s.predict(1, day, hour)
+
+		// Advance one hour; without an observation the components stay on their forecast
+		s.level += hwDamping * s.trend
+		s.trend *= hwDamping
+		if history[i].Time.Truncate(time.Hour).After(t) {
+			continue
+		}
+
+		e := history[i].Load - forecast
+		sse += e * e
+		residuals[hour] = append(residuals[hour], e)
+
+		s.level += p.alpha * e
+		s.trend += p.alpha * p.beta * e
+		s.daily[hour] += p.gamma * e
+		s.weekly[day][hour] += p.omega * e
+
+		// Later samples within the same hour are ignored
+		for i < len(history) && !history[i].Time.Truncate(time.Hour).After(t) {
+			i++
+		}
+	}
+	return sse, s, residuals
+}
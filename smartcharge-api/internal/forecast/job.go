@@ -0,0 +1,229 @@
+package forecast
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"smartcharge-api/db/generated"
+)
+
+// TxBeginner starts the transaction Train stores a station's forecasts in; *pgxpool.Pool implements it.
+type TxBeginner interface {
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+}
+
+// RetrainJob refreshes every station's forecasts from its recent history, with the station's chosen model.
+// It implements scheduler.Job.
+type RetrainJob struct {
+	queries *generated.Queries
+	pool    *pgxpool.Pool
+}
+
+// NewRetrainJob creates the forecast retraining job.
+func NewRetrainJob(queries *generated.Queries, pool *pgxpool.Pool) *RetrainJob {
+	return &RetrainJob{queries: queries, pool: pool}
+}
+
+// Name implements scheduler.Job.
+func (j *RetrainJob) Name() string {
+	return "forecast-retrain"
+}
+
+// Run implements scheduler.Job. Stations without any history keep their current forecast,
+// and a station that fails to train is logged and skipped.
+func (j *RetrainJob) Run(ctx context.Context) error {
+	stations, err := j.queries.ListStations(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	trained := 0
+	for _, s := range stations {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		ok, err := Train(ctx, j.pool, j.queries, Lookup(s.ForecastModel), s.ID, now)
+		if err != nil {
+			log.Printf("forecast-retrain: station %d: %v", s.ID, err)
+			continue
+		}
+		if ok {
+			trained++
+		}
+	}
+
+	if trained > 0 {
+		log.Printf("forecast-retrain: retrained %d of %d stations", trained, len(stations))
+	}
+	return nil
+}
+
+// Train fits the model to the station's history up to now and stores its forecasts: the usual week, as P10, P50 and P90
+// per weekly slot, and each dated hour of the Horizon with the station's calendar events and weather taken into account.
+// It updates the station's density to the weekly P50 average, and reports false if there was no history.
+// The forecasts and density are replaced together in one transaction, so a failure leaves the previous ones in place.
+func Train(ctx context.Context, pool TxBeginner, q *generated.Queries, model Model, stationID int32, now time.Time) (bool, error) {
+	from := now.Truncate(time.Hour)
+	history, err := LoadHistory(ctx, q, stationID, from.Add(-EventHistoryWindow), from)
+	if err != nil {
+		return false, err
+	}
+	events, err := LoadEvents(ctx, q, stationID, from.Add(-EventHistoryWindow), from.Add(Horizon))
+	if err != nil {
+		return false, err
+	}
+
+	weather, err := LoadWeather(ctx, q, stationID, from.Add(-HistoryWindow), from.Add(Horizon))
+	if err != nil {
+		return false, err
+	}
+
+	p, slots, ok := ForecastDates(model, history, events, weather, from, Horizon)
+	if !ok {
+		return false, nil
+	}
+
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+
+	for day := range p.P50 {
+		for hour, load := range p.P50[day] {
+			if err := qtx.UpsertForecast(ctx, generated.UpsertForecastParams{
+				StationID:     stationID,
+				DayOfWeek:     int32(day),
+				Hour:          int32(hour),
+				PredictedLoad: load,
+				P10Load:       min(load, p.P10[day][hour]),
+				P90Load:       max(load, p.P90[day][hour]),
+				Model:         model.Name(),
+			}); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	dated := generated.UpsertStationForecastsParams{StationID: stationID, Model: model.Name()}
+	for _, s := range slots {
+		dated.SlotStarts = append(dated.SlotStarts, pgtype.Timestamptz{Time: s.Start, Valid: true})
+		dated.PredictedLoads = append(dated.PredictedLoads, s.P50)
+		dated.P10Loads = append(dated.P10Loads, s.P10)
+		dated.P90Loads = append(dated.P90Loads, s.P90)
+		dated.Events = append(dated.Events, s.Event)
+		var w WeatherEffect
+		if s.Weather != nil {
+			w = *s.Weather
+		}
+		dated.HasWeather = append(dated.HasWeather, s.Weather != nil)
+		dated.Temperatures = append(dated.Temperatures, w.Temperature)
+		dated.Precipitations = append(dated.Precipitations, w.Precipitation)
+		dated.TemperatureEffects = append(dated.TemperatureEffects, w.TemperatureEffect)
+		dated.PrecipitationEffects = append(dated.PrecipitationEffects, w.PrecipitationEffect)
+	}
+	if err := qtx.UpsertStationForecasts(ctx, dated); err != nil {
+		return false, err
+	}
+	// Drop the hours that have passed
+	if err := qtx.DeleteStationForecastsOutside(ctx, generated.DeleteStationForecastsOutsideParams{
+		StationID:   stationID,
+		WindowStart: pgtype.Timestamptz{Time: from, Valid: true},
+		WindowEnd:   pgtype.Timestamptz{Time: from.Add(Horizon), Valid: true},
+	}); err != nil {
+		return false, err
+	}
+
+	if err := qtx.UpdateStationDensity(ctx, generated.UpdateStationDensityParams{
+		ID:      stationID,
+		Density: p.P50.Average(),
+	}); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// AccuracyJob scores every station's model on the week just gone and stores the results
+// in forecast_accuracy, so stations whose forecasts are off can be spotted. It implements scheduler.Job.
+type AccuracyJob struct {
+	queries *generated.Queries
+}
+
+// NewAccuracyJob creates the forecast accuracy job.
+func NewAccuracyJob(queries *generated.Queries) *AccuracyJob {
+	return &AccuracyJob{queries: queries}
+}
+
+// Name implements scheduler.Job.
+func (j *AccuracyJob) Name() string {
+	return "forecast-accuracy"
+}
+
+// Run implements scheduler.Job. Stations without history for the week keep their last results,
+// and a station that fails to score is logged and skipped.
+func (j *AccuracyJob) Run(ctx context.Context) error {
+	stations, err := j.queries.ListStations(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	scored := 0
+	for _, s := range stations {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		ok, err := Score(ctx, j.queries, Lookup(s.ForecastModel), s.ID, now)
+		if err != nil {
+			log.Printf("forecast-accuracy: station %d: %v", s.ID, err)
+			continue
+		}
+		if ok {
+			scored++
+		}
+	}
+
+	if scored > 0 {
+		log.Printf("forecast-accuracy: scored %d of %d stations", scored, len(stations))
+	}
+	return nil
+}
+
+// Score evaluates the model on the station's week up to now and stores the per-hour results.
+// It reports false, leaving the previous results, if the station doesn't have the history for it.
+func Score(ctx context.Context, q *generated.Queries, model Model, stationID int32, now time.Time) (bool, error) {
+	e, ok, err := Evaluate(ctx, q, model, stationID, now)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	for hour, a := range e.ByHour {
+		if err := q.UpsertForecastAccuracy(ctx, generated.UpsertForecastAccuracyParams{
+			StationID:   stationID,
+			Hour:        int32(hour),
+			Model:       e.Model,
+			WindowStart: pgtype.Timestamptz{Time: now.Add(-EvaluationWindow).Truncate(time.Hour), Valid: true},
+			WindowEnd:   pgtype.Timestamptz{Time: now, Valid: true},
+			Samples:     int32(a.Samples),
+			Mae:         a.MAE,
+			Bias:        a.Bias,
+			Mape:        a.MAPE,
+			MapeSamples: int32(a.MAPESamples),
+		}); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
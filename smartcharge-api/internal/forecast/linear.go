@@ -0,0 +1,73 @@
+package forecast
+
+// Linear fits a straight line through each weekly slot's past values and extends it one week ahead,
+// so a slot that has been getting busier week over week is predicted busier still.
+type Linear struct{}
+
+// Name implements Model.
+func (Linear) Name() string {
+	return "linear"
+}
+
+// Forecast implements Model. Slots with fewer than two samples use their average,
+// and slots without any use the average of the whole history. P10 and P90 come from the fit's residuals.
+func (Linear) Forecast(history []Sample) Prediction {
+	// Each slot's samples as (weeks since the start of the history, load)
+	var slots [7][24][][2]float64
+	total := 0.0
+	for _, s := range history {
+		day, hour := SlotOf(s.Time)
+		weeks := s.Time.Sub(history[0].Time).Hours() / (7 * 24)
+		slots[day][hour] = append(slots[day][hour], [2]float64{weeks, s.Load})
+		total += s.Load
+	}
+	overall := total / float64(len(history))
+
+	var w Weekly
+	var residuals [24][]float64
+	for day := range slots {
+		for hour, points := range slots[day] {
+			switch len(points) {
+			case 0:
+				w[day][hour] = clampLoad(overall)
+			case 1:
+				w[day][hour] = clampLoad(points[0][1])
+			default:
+				// Regress load on the week and predict the week after the slot's last sample,
+				// so weeks missing from the history don't compress the trend
+				m, b := linearReg(points)
+				next := points[len(points)-1][0] + 1
+				w[day][hour] = clampLoad(m*next + b)
+				for _, p := range points {
+					residuals[hour] = append(residuals[hour], p[1]-(m*p[0]+b))
+				}
+			}
+		}
+	}
+	return withResidualBand(w, residuals)
+}
+
+// linearReg computes slope (m) and intercept (b) for y = mx + b.
+func linearReg(points [][2]float64) (m, b float64) {
+	n := float64(len(points))
+	if n == 0 {
+		return 0, 0
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	for _, p := range points {
+		sumX += p[0]
+		sumY += p[1]
+		sumXY += p[0] * p[1]
+		sumXX += p[0] * p[0]
+	}
+
+	denom := n*sumXX - sumX*sumX
+	if denom == 0 {
+		return 0, sumY / n
+	}
+
+	m = (n*sumXY - sumX*sumY) / denom
+	b = (sumY - m*sumX) / n
+	return m, b
+}
@@ -0,0 +1,102 @@
+// Package forecast predicts hourly station load (0–100) for each weekly slot from observed history.
+package forecast
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"smartcharge-api/internal/pricing"
+)
+
+// Sample is the observed load of a station during the hour starting at Time.
+type Sample struct {
+	Time time.Time
+	Load float64
+}
+
+// Weekly is a predicted load per weekly slot, indexed [dayOfWeek][hour] with 0=Monday.
+type Weekly [7][24]int32
+
+// Prediction is a weekly forecast as three quantiles of the load: P50 is the typical ("likely busy") load,
+// P90 the load exceeded only one week in ten ("possibly busy") and P10 the load it falls below one week in ten.
+// P10–P90 is the forecast's 80% band, and always contains P50.
+type Prediction struct {
+	P10 Weekly
+	P50 Weekly
+	P90 Weekly
+}
+
+// Model turns a station's history into next week's forecast. History is in time order and never empty.
+type Model interface {
+	Name() string
+	Forecast(history []Sample) Prediction
+}
+
+// DefaultModel is the model stations use until their operator picks another.
+const DefaultModel = "linear"
+
+// Models lists every available model, keyed by name.
+var Models = map[string]Model{
+	Linear{}.Name():           Linear{},
+	HoltWinters{}.Name():      HoltWinters{},
+	Quantile{Weeks: 4}.Name(): Quantile{Weeks: 4},
+}
+
+// ModelNames returns the names of the available models, sorted.
+func ModelNames() []string {
+	names := make([]string, 0, len(Models))
+	for name := range Models {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lookup returns the model with the given name, falling back to the default model for unknown names.
+func Lookup(name string) Model {
+	if m, ok := Models[name]; ok {
+		return m
+	}
+	return Models[DefaultModel]
+}
+
+// Average returns the mean predicted load over the week.
+func (w Weekly) Average() int32 {
+	sum := 0.0
+	for _, day := range w {
+		for _, load := range day {
+			sum += float64(load)
+		}
+	}
+	return int32(math.Round(sum / (7 * 24)))
+}
+
+// SlotOf returns the weekly slot t falls in, in pricing.SlotLocation (0=Monday, like the rest of the API).
+func SlotOf(t time.Time) (dayOfWeek, hour int) {
+	t = t.In(pricing.SlotLocation)
+	return (int(t.Weekday()) + 6) % 7, t.Hour()
+}
+
+// withResidualBand completes a P50 forecast with a P10 and P90 from the model's past errors (actual minus fitted):
+// each slot's P10 and P90 are its P50 plus the 10th and 90th percentiles of the errors seen at that hour of day.
+func withResidualBand(p50 Weekly, residuals [24][]float64) Prediction {
+	p := Prediction{P50: p50}
+	for day := range p50 {
+		for hour, load := range p50[day] {
+			low, high := 0.0, 0.0
+			if len(residuals[hour]) > 0 {
+				low = math.Min(0, quantile(residuals[hour], 0.1))
+				high = math.Max(0, quantile(residuals[hour], 0.9))
+			}
+			p.P10[day][hour] = clampLoad(float64(load) + low)
+			p.P90[day][hour] = clampLoad(float64(load) + high)
+		}
+	}
+	return p
+}
+
+// clampLoad rounds a prediction into the 0–100 load range.
+func clampLoad(v float64) int32 {
+	return int32(math.Min(100, math.Max(0, math.Round(v))))
+}
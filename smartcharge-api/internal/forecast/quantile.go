@@ -0,0 +1,66 @@
+package forecast
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Quantile predicts each weekly slot as the median, 10th and 90th percentiles of its values over the last Weeks weeks.
+// It assumes no trend, so it follows recent behaviour and is robust to the odd unusual week.
+// Zero Weeks uses the whole history.
+type Quantile struct {
+	Weeks int
+}
+
+// Name implements Model.
+func (Quantile) Name() string {
+	return "quantile"
+}
+
+// Forecast implements Model. Slots without samples in the window use the same hour on the other days,
+// and failing that the whole window.
+func (m Quantile) Forecast(history []Sample) Prediction {
+	if m.Weeks > 0 {
+		since := history[len(history)-1].Time.Add(-time.Duration(m.Weeks) * 7 * 24 * time.Hour)
+		start := sort.Search(len(history), func(i int) bool { return history[i].Time.After(since) })
+		history = history[start:]
+	}
+
+	var slots [7][24][]float64
+	var byHour [24][]float64
+	all := make([]float64, 0, len(history))
+	for _, s := range history {
+		day, hour := SlotOf(s.Time)
+		slots[day][hour] = append(slots[day][hour], s.Load)
+		byHour[hour] = append(byHour[hour], s.Load)
+		all = append(all, s.Load)
+	}
+
+	var p Prediction
+	for day := range slots {
+		for hour, values := range slots[day] {
+			if len(values) == 0 {
+				values = byHour[hour]
+			}
+			if len(values) == 0 {
+				values = all
+			}
+			p.P10[day][hour] = clampLoad(quantile(values, 0.1))
+			p.P50[day][hour] = clampLoad(quantile(values, 0.5))
+			p.P90[day][hour] = clampLoad(quantile(values, 0.9))
+		}
+	}
+	return p
+}
+
+// quantile returns the q-th quantile (0–1) of values, interpolating between the nearest ranks.
+// values must not be empty; it is not modified.
+func quantile(values []float64, q float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	pos := q * float64(len(sorted)-1)
+	lo, hi := int(math.Floor(pos)), int(math.Ceil(pos))
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(pos-float64(lo))
+}
@@ -0,0 +1,169 @@
+package forecast
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"smartcharge-api/db/generated"
+)
+
+// Temperatures beyond which charging behaviour changes: batteries charge slower and range drops in the cold,
+// and air conditioning drains them in the heat.
+const (
+	ColdBelow = 10.0 // °C
+	HotAbove  = 25.0 // °C
+)
+
+// minWeatherSamples is how many ordinary hours with known weather it takes to learn its effect.
+const minWeatherSamples = 72
+
+// weatherRidge shrinks the weather coefficients towards zero, so weather the history has barely seen
+// (the first heat wave of the year) doesn't swing the forecast.
+const weatherRidge = 10.0
+
+// Weather is the weather during an hour.
+type Weather struct {
+	Temperature   float64 // °C
+	Precipitation float64 // mm
+}
+
+// WeatherSeries is hourly weather, observed or forecast, keyed by the hour's start in Unix seconds.
+type WeatherSeries map[int64]Weather
+
+func (ws WeatherSeries) at(t time.Time) (Weather, bool) {
+	w, ok := ws[t.Unix()]
+	return w, ok
+}
+
+// WeatherEffect is the weather forecast for an hour and how far it moved the forecast load, in points.
+type WeatherEffect struct {
+	Weather
+	TemperatureEffect   float64
+	PrecipitationEffect float64
+}
+
+// Total is the weather's whole effect on the load.
+func (e WeatherEffect) Total() float64 {
+	return e.TemperatureEffect + e.PrecipitationEffect
+}
+
+// LoadWeather returns the weather the station had or is forecast to have during [from, to).
+// The station's own weather takes precedence over the area-wide weather.
+func LoadWeather(ctx context.Context, q *generated.Queries, stationID int32, from, to time.Time) (WeatherSeries, error) {
+	rows, err := q.ListWeather(ctx, generated.ListWeatherParams{
+		StationID:   pgtype.Int4{Int32: stationID, Valid: true},
+		WindowStart: pgtype.Timestamptz{Time: from, Valid: true},
+		WindowEnd:   pgtype.Timestamptz{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Area-wide rows come first, so the station's own overwrite them
+	series := make(WeatherSeries, len(rows))
+	for _, r := range rows {
+		series[r.ObservedAt.Time.Unix()] = Weather{Temperature: r.Temperature, Precipitation: r.Precipitation}
+	}
+	return series, nil
+}
+
+// weatherFeatures are the regressors for an hour's weather: degrees below ColdBelow, degrees above HotAbove,
+// and precipitation.
+func weatherFeatures(w Weather) [3]float64 {
+	return [3]float64{
+		math.Max(0, ColdBelow-w.Temperature),
+		math.Max(0, w.Temperature-HotAbove),
+		w.Precipitation,
+	}
+}
+
+// weatherModel is the linear effect of weather on load, beyond what the weekly forecast expects,
+// in points per degree of cold, per degree of heat and per mm of precipitation.
+type weatherModel struct {
+	cold, heat, rain float64
+}
+
+func (m weatherModel) effect(w Weather) WeatherEffect {
+	f := weatherFeatures(w)
+	return WeatherEffect{
+		Weather:             w,
+		TemperatureEffect:   m.cold*f[0] + m.heat*f[1],
+		PrecipitationEffect: m.rain * f[2],
+	}
+}
+
+// fitWeather regresses how far load departed from the weekly forecast on the weather at the time,
+// over the samples whose weather is known. An intercept absorbs any overall bias of the weekly forecast,
+// so only what varies with the weather is put down to it. It reports false without enough samples.
+func fitWeather(p Prediction, samples []Sample, weather WeatherSeries) (weatherModel, bool) {
+	// Normal equations of [1, cold, heat, rain] with a ridge penalty on the weather terms
+	var a [4][4]float64
+	var b [4]float64
+	n := 0
+	for _, s := range samples {
+		w, ok := weather.at(s.Time)
+		if !ok {
+			continue
+		}
+		day, hour := SlotOf(s.Time)
+		f := weatherFeatures(w)
+		x := [4]float64{1, f[0], f[1], f[2]}
+		diff := s.Load - float64(p.P50[day][hour])
+		for i := range x {
+			for j := range x {
+				a[i][j] += x[i] * x[j]
+			}
+			b[i] += x[i] * diff
+		}
+		n++
+	}
+	if n < minWeatherSamples {
+		return weatherModel{}, false
+	}
+	for i := 1; i < 4; i++ {
+		a[i][i] += weatherRidge
+	}
+
+	coef, ok := solve4(a, b)
+	if !ok {
+		return weatherModel{}, false
+	}
+	return weatherModel{cold: coef[1], heat: coef[2], rain: coef[3]}, true
+}
+
+// solve4 solves a·x = b by Gaussian elimination with partial pivoting. It reports false if a is singular.
+func solve4(a [4][4]float64, b [4]float64) ([4]float64, bool) {
+	var x [4]float64
+	for col := range 4 {
+		pivot := col
+		for row := col + 1; row < 4; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-9 {
+			return x, false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+
+		for row := col + 1; row < 4; row++ {
+			factor := a[row][col] / a[col][col]
+			for k := col; k < 4; k++ {
+				a[row][k] -= factor * a[col][k]
+			}
+			b[row] -= factor * b[col]
+		}
+	}
+	for row := 3; row >= 0; row-- {
+		sum := b[row]
+		for k := row + 1; k < 4; k++ {
+			sum -= a[row][k] * x[k]
+		}
+		x[row] = sum / a[row][row]
+	}
+	return x, true
+}
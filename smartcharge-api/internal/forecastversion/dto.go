@@ -0,0 +1,39 @@
+package forecastversion
+
+// --- Request DTOs ---
+
+// UploadRequest is the version metadata sent with an upload to POST /v1/admin/forecast-versions,
+// as multipart form fields alongside the "forecasts" file.
+type UploadRequest struct {
+	Model     string `form:"model" binding:"required"`
+	Version   string `form:"version" binding:"required"`
+	TrainedAt string `form:"trainedAt"` // RFC 3339, optional
+	Notes     string `form:"notes"`
+	Format    string `form:"format"` // csv or jsonl; detected from the file if omitted
+}
+
+// --- Response DTOs ---
+
+// VersionResponse is an uploaded forecast version and what it covers.
+type VersionResponse struct {
+	ID        int32   `json:"id"`
+	Model     string  `json:"model"`
+	Version   string  `json:"version"`
+	TrainedAt *string `json:"trainedAt"`
+	Notes     string  `json:"notes"`
+	CreatedBy *int32  `json:"createdBy"`
+	CreatedAt string  `json:"createdAt"`
+	UpdatedAt string  `json:"updatedAt"`
+	// RolledBackAt is when the version was rolled back; nil while it applies
+	RolledBackAt *string `json:"rolledBackAt"`
+	Hours        int32   `json:"hours"`
+	Stations     int32   `json:"stations"`
+	FirstSlot    *string `json:"firstSlot"`
+	LastSlot     *string `json:"lastSlot"`
+}
+
+// UploadResponse reports what an upload stored.
+type UploadResponse struct {
+	Version  VersionResponse `json:"version"`
+	Uploaded int64           `json:"uploaded"` // predictions added to or updated in the version
+}
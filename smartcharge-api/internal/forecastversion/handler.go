@@ -0,0 +1,132 @@
+package forecastversion
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	apperrors "smartcharge-api/internal/errors"
+	"smartcharge-api/internal/middleware"
+	"smartcharge-api/internal/policy"
+	"smartcharge-api/internal/response"
+)
+
+// Handler handles HTTP requests for externally trained forecast versions.
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new forecast version handler.
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers forecast version routes on the given router group.
+func (h *Handler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	versions := rg.Group("/admin/forecast-versions", authMiddleware, middleware.RequireRole(policy.RoleAdmin))
+	versions.GET("", h.List)
+	versions.POST("", h.Upload)
+	versions.POST("/:id/rollback", h.Rollback)
+	versions.POST("/:id/restore", h.Restore)
+}
+
+// List handles GET /v1/admin/forecast-versions.
+func (h *Handler) List(c *gin.Context) {
+	result, err := h.service.List(c.Request.Context())
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// Upload handles POST /v1/admin/forecast-versions.
+// Expects a multipart form with a CSV or JSON lines file under "forecasts" and the version metadata
+// (see UploadRequest).
+func (h *Handler) Upload(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	var req UploadRequest
+	if err := c.ShouldBind(&req); err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "model and version are required")
+		return
+	}
+
+	header, err := c.FormFile("forecasts")
+	if err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "forecasts file is required")
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "Could not read forecasts file")
+		return
+	}
+	defer file.Close()
+
+	// Read one byte past the limit so oversized files are rejected by the service
+	data, err := io.ReadAll(io.LimitReader(file, MaxUploadSize+1))
+	if err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "Could not read forecasts file")
+		return
+	}
+
+	result, err := h.service.Upload(c.Request.Context(), actor, req, header.Filename, data)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.Created(c, result)
+}
+
+// Rollback handles POST /v1/admin/forecast-versions/:id/rollback.
+func (h *Handler) Rollback(c *gin.Context) {
+	id, err := parseID(c)
+	if err != nil {
+		return
+	}
+	result, err := h.service.Rollback(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// Restore handles POST /v1/admin/forecast-versions/:id/restore.
+func (h *Handler) Restore(c *gin.Context) {
+	id, err := parseID(c)
+	if err != nil {
+		return
+	}
+	result, err := h.service.Restore(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// --- helpers ---
+
+func parseID(c *gin.Context) (int32, error) {
+	val, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "Invalid forecast version ID")
+		return 0, err
+	}
+	return int32(val), nil
+}
+
+func handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*apperrors.AppError); ok {
+		response.Err(c, appErr.StatusCode, appErr.Code, appErr.Message)
+		return
+	}
+	response.Err(c, 500, "INTERNAL_ERROR", "An unexpected error occurred")
+}
@@ -0,0 +1,235 @@
+package forecastversion
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"smartcharge-api/internal/pricing"
+)
+
+// Upload formats.
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// row is one uploaded prediction, as in the file. P10 and P90 are optional.
+type row struct {
+	StationID     *int64   `json:"station_id"`
+	Date          string   `json:"date"`
+	Hour          *int64   `json:"hour"`
+	PredictedLoad *float64 `json:"predicted_load"`
+	P10Load       *float64 `json:"p10_load"`
+	P90Load       *float64 `json:"p90_load"`
+}
+
+// prediction is a validated row.
+type prediction struct {
+	StationID     int32
+	SlotStart     time.Time
+	P10, P50, P90 int32
+}
+
+// lineError is a problem with one line of an upload.
+type lineError struct {
+	Line int
+	Err  error
+}
+
+func (e lineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+// detectFormat picks the upload format: the one asked for, else from the file extension,
+// else JSON lines if the file starts with an object and CSV otherwise.
+func detectFormat(requested, filename string, data []byte) (string, error) {
+	switch strings.ToLower(requested) {
+	case FormatCSV, FormatJSONL:
+		return strings.ToLower(requested), nil
+	case "":
+	default:
+		return "", fmt.Errorf("format must be %s or %s", FormatCSV, FormatJSONL)
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".jsonl", ".ndjson", ".json":
+		return FormatJSONL, nil
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return FormatJSONL, nil
+	}
+	return FormatCSV, nil
+}
+
+// parseRows reads the rows of an upload with their line numbers.
+func parseRows(format string, data []byte) ([]row, []int, error) {
+	if format == FormatJSONL {
+		return parseJSONL(data)
+	}
+	return parseCSV(data)
+}
+
+// parseJSONL reads one JSON object per line, with the same keys as the CSV columns. Blank lines are skipped.
+func parseJSONL(data []byte) ([]row, []int, error) {
+	var rows []row
+	var lines []int
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 64*1024), 1<<20)
+	for line := 1; sc.Scan(); line++ {
+		text := bytes.TrimSpace(sc.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var r row
+		if err := json.Unmarshal(text, &r); err != nil {
+			return nil, nil, lineError{line, errors.New("invalid JSON")}
+		}
+		rows = append(rows, r)
+		lines = append(lines, line)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, nil, err
+	}
+	return rows, lines, nil
+}
+
+// parseCSV reads a CSV file whose header names the station_id, date, hour and predicted_load columns,
+// and optionally p10_load and p90_load, in any order; other columns are ignored.
+func parseCSV(data []byte) ([]row, []int, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.TrimLeadingSpace = true
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, nil, errors.New("missing header row")
+	}
+	cols := map[string]int{}
+	for i, name := range header {
+		cols[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, name := range []string{"station_id", "date", "hour", "predicted_load"} {
+		if _, ok := cols[name]; !ok {
+			return nil, nil, fmt.Errorf("header has no %s column", name)
+		}
+	}
+
+	var rows []row
+	var lines []int
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		line, _ := r.FieldPos(0)
+		if err != nil {
+			return nil, nil, lineError{line, err}
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+
+		field := func(name string) string {
+			i, ok := cols[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		var rw row
+		var perr error
+		rw.StationID, perr = parseInt(field("station_id"), "station_id", perr)
+		rw.Date = field("date")
+		rw.Hour, perr = parseInt(field("hour"), "hour", perr)
+		rw.PredictedLoad, perr = parseFloat(field("predicted_load"), "predicted_load", perr)
+		rw.P10Load, perr = parseFloat(field("p10_load"), "p10_load", perr)
+		rw.P90Load, perr = parseFloat(field("p90_load"), "p90_load", perr)
+		if perr != nil {
+			return nil, nil, lineError{line, perr}
+		}
+		rows = append(rows, rw)
+		lines = append(lines, line)
+	}
+	return rows, lines, nil
+}
+
+// parseInt parses an optional integer field, keeping the first error.
+func parseInt(raw, name string, err error) (*int64, error) {
+	if raw == "" || err != nil {
+		return nil, err
+	}
+	v, perr := strconv.ParseInt(raw, 10, 64)
+	if perr != nil {
+		return nil, fmt.Errorf("%s %q is not an integer", name, raw)
+	}
+	return &v, nil
+}
+
+// parseFloat parses an optional number field, keeping the first error.
+func parseFloat(raw, name string, err error) (*float64, error) {
+	if raw == "" || err != nil {
+		return nil, err
+	}
+	v, perr := strconv.ParseFloat(raw, 64)
+	if perr != nil {
+		return nil, fmt.Errorf("%s %q is not a number", name, raw)
+	}
+	return &v, nil
+}
+
+// validate checks a row and turns it into a prediction. Loads are rounded to whole points;
+// a missing P10 or P90 is taken as the predicted load, for a point forecast.
+func (r row) validate() (prediction, error) {
+	if r.StationID == nil || r.Hour == nil || r.Date == "" || r.PredictedLoad == nil {
+		return prediction{}, errors.New("station_id, date, hour and predicted_load are required")
+	}
+	if *r.StationID < 1 || *r.StationID > math.MaxInt32 {
+		return prediction{}, fmt.Errorf("invalid station_id %d", *r.StationID)
+	}
+	day, err := time.ParseInLocation("2006-01-02", r.Date, pricing.SlotLocation)
+	if err != nil {
+		return prediction{}, fmt.Errorf("date %q must be YYYY-MM-DD", r.Date)
+	}
+	if *r.Hour < 0 || *r.Hour > 23 {
+		return prediction{}, fmt.Errorf("hour %d must be between 0 and 23", *r.Hour)
+	}
+
+	load := func(name string, v *float64, fallback int32) (int32, error) {
+		if v == nil {
+			return fallback, nil
+		}
+		if math.IsNaN(*v) || *v < 0 || *v > 100 {
+			return 0, fmt.Errorf("%s %v must be between 0 and 100", name, *v)
+		}
+		return int32(math.Round(*v)), nil
+	}
+	p := prediction{
+		StationID: int32(*r.StationID),
+		SlotStart: time.Date(day.Year(), day.Month(), day.Day(), int(*r.Hour), 0, 0, 0, pricing.SlotLocation),
+	}
+	if p.P50, err = load("predicted_load", r.PredictedLoad, 0); err != nil {
+		return prediction{}, err
+	}
+	if p.P10, err = load("p10_load", r.P10Load, p.P50); err != nil {
+		return prediction{}, err
+	}
+	if p.P90, err = load("p90_load", r.P90Load, p.P50); err != nil {
+		return prediction{}, err
+	}
+	if p.P10 > p.P50 || p.P50 > p.P90 {
+		return prediction{}, errors.New("loads must satisfy p10_load <= predicted_load <= p90_load")
+	}
+	return p, nil
+}
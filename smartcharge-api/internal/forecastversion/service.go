@@ -0,0 +1,261 @@
+// Package forecastversion ingests forecasts trained outside the API, such as the data science team's
+// offline models, as versions that take precedence over the API's own forecasts and can be rolled back.
+package forecastversion
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"smartcharge-api/db/generated"
+	apperrors "smartcharge-api/internal/errors"
+	"smartcharge-api/internal/policy"
+)
+
+// MaxUploadSize caps an uploaded forecast file.
+const MaxUploadSize = 16 << 20
+
+// maxUploadRows caps the predictions in one upload.
+const maxUploadRows = 200_000
+
+// maxReportedErrors caps how many invalid lines a rejected upload lists.
+const maxReportedErrors = 10
+
+// Service handles externally trained forecast versions.
+type Service struct {
+	queries *generated.Queries
+	pool    *pgxpool.Pool
+}
+
+// NewService creates a new forecast version service.
+func NewService(queries *generated.Queries, pool *pgxpool.Pool) *Service {
+	return &Service{queries: queries, pool: pool}
+}
+
+// List returns every forecast version, most recently uploaded first.
+func (s *Service) List(ctx context.Context) ([]VersionResponse, error) {
+	rows, err := s.queries.ListForecastVersions(ctx, pgtype.Int4{})
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	result := make([]VersionResponse, len(rows))
+	for i, r := range rows {
+		result[i] = toVersionResponse(r)
+	}
+	return result, nil
+}
+
+// Upload validates a file of predictions and upserts them into the model version, creating it if needed.
+// Uploading to an existing version adds to it and makes it the latest again, restoring it if it was rolled back.
+// The whole file is rejected if any line is invalid.
+func (s *Service) Upload(ctx context.Context, actor policy.Actor, req UploadRequest, filename string, data []byte) (*UploadResponse, error) {
+	if len(data) > MaxUploadSize {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("Forecast file must be at most %d MB", MaxUploadSize>>20))
+	}
+
+	params := generated.UpsertForecastVersionParams{
+		Model:     strings.TrimSpace(req.Model),
+		Version:   strings.TrimSpace(req.Version),
+		Notes:     strings.TrimSpace(req.Notes),
+		CreatedBy: pgtype.Int4{Int32: actor.UserID, Valid: true},
+	}
+	if params.Model == "" || len(params.Model) > 50 || params.Version == "" || len(params.Version) > 50 {
+		return nil, apperrors.NewValidationError("model and version are required, at most 50 characters each")
+	}
+	if len(params.Notes) > 1000 {
+		return nil, apperrors.NewValidationError("notes must be at most 1000 characters")
+	}
+	if req.TrainedAt != "" {
+		t, err := time.Parse(time.RFC3339, req.TrainedAt)
+		if err != nil {
+			return nil, apperrors.NewValidationError("trainedAt must be an RFC 3339 timestamp")
+		}
+		params.TrainedAt = pgtype.Timestamptz{Time: t, Valid: true}
+	}
+
+	predictions, err := s.parse(ctx, req.Format, filename, data)
+	if err != nil {
+		return nil, err
+	}
+
+	forecasts := generated.UpsertExternalForecastsParams{}
+	for _, p := range predictions {
+		forecasts.StationIds = append(forecasts.StationIds, p.StationID)
+		forecasts.SlotStarts = append(forecasts.SlotStarts, pgtype.Timestamptz{Time: p.SlotStart, Valid: true})
+		forecasts.PredictedLoads = append(forecasts.PredictedLoads, p.P50)
+		forecasts.P10Loads = append(forecasts.P10Loads, p.P10)
+		forecasts.P90Loads = append(forecasts.P90Loads, p.P90)
+	}
+
+	// The version and its predictions are written together or not at all
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.queries.WithTx(tx)
+	version, err := qtx.UpsertForecastVersion(ctx, params)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	forecasts.VersionID = version.ID
+	uploaded, err := qtx.UpsertExternalForecasts(ctx, forecasts)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	resp, err := s.version(ctx, version.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &UploadResponse{Version: *resp, Uploaded: uploaded}, nil
+}
+
+// Rollback stops a version from applying, so for the hours it covered the previous version applies again,
+// or failing that the API's own forecast. The version is kept and can be restored.
+func (s *Service) Rollback(ctx context.Context, id int32) (*VersionResponse, error) {
+	return s.setRolledBack(ctx, id, true)
+}
+
+// Restore makes a rolled back version apply again, in its original place among the versions.
+func (s *Service) Restore(ctx context.Context, id int32) (*VersionResponse, error) {
+	return s.setRolledBack(ctx, id, false)
+}
+
+// --- helpers ---
+
+// parse reads and validates an upload. Invalid lines are reported together, up to maxReportedErrors.
+func (s *Service) parse(ctx context.Context, requestedFormat, filename string, data []byte) ([]prediction, error) {
+	format, err := detectFormat(requestedFormat, filename, data)
+	if err != nil {
+		return nil, apperrors.NewValidationError(err.Error())
+	}
+	rows, lines, err := parseRows(format, data)
+	if err != nil {
+		return nil, apperrors.NewValidationError("Invalid forecast file: " + err.Error())
+	}
+	if len(rows) == 0 {
+		return nil, apperrors.NewValidationError("The forecast file has no predictions")
+	}
+	if len(rows) > maxUploadRows {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("Upload at most %d predictions at a time", maxUploadRows))
+	}
+
+	stations, err := s.queries.ListStations(ctx)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	known := make(map[int32]bool, len(stations))
+	for _, st := range stations {
+		known[st.ID] = true
+	}
+
+	var problems []error
+	predictions := make([]prediction, 0, len(rows))
+	seen := make(map[[2]int64]int, len(rows))
+	for i, r := range rows {
+		p, err := r.validate()
+		switch {
+		case err != nil:
+		case !known[p.StationID]:
+			err = fmt.Errorf("station %d not found", p.StationID)
+		default:
+			key := [2]int64{int64(p.StationID), p.SlotStart.Unix()}
+			if first, dup := seen[key]; dup {
+				err = fmt.Errorf("station %d at %s is already predicted on line %d", p.StationID, p.SlotStart.Format("2006-01-02 15:04"), first)
+			}
+			seen[key] = lines[i]
+		}
+		if err != nil {
+			problems = append(problems, lineError{lines[i], err})
+			continue
+		}
+		predictions = append(predictions, p)
+	}
+
+	if len(problems) > 0 {
+		msgs := make([]string, 0, maxReportedErrors)
+		for _, p := range problems[:min(len(problems), maxReportedErrors)] {
+			msgs = append(msgs, p.Error())
+		}
+		msg := fmt.Sprintf("%d invalid lines: %s", len(problems), strings.Join(msgs, "; "))
+		if len(problems) > maxReportedErrors {
+			msg += "; …"
+		}
+		return nil, apperrors.NewValidationError(msg)
+	}
+	return predictions, nil
+}
+
+func (s *Service) setRolledBack(ctx context.Context, id int32, rolledBack bool) (*VersionResponse, error) {
+	version, err := s.queries.GetForecastVersion(ctx, id)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("Forecast version")
+	}
+	if version.RolledBackAt.Valid == rolledBack {
+		if rolledBack {
+			return nil, apperrors.NewConflictError("Forecast version is already rolled back")
+		}
+		return nil, apperrors.NewConflictError("Forecast version is not rolled back")
+	}
+
+	params := generated.SetForecastVersionRolledBackParams{ID: id}
+	if rolledBack {
+		params.RolledBackAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	}
+	if _, err := s.queries.SetForecastVersionRolledBack(ctx, params); err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	return s.version(ctx, id)
+}
+
+// version returns one version with what it covers.
+func (s *Service) version(ctx context.Context, id int32) (*VersionResponse, error) {
+	rows, err := s.queries.ListForecastVersions(ctx, pgtype.Int4{Int32: id, Valid: true})
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	if len(rows) == 0 {
+		return nil, apperrors.NewNotFoundError("Forecast version")
+	}
+	resp := toVersionResponse(rows[0])
+	return &resp, nil
+}
+
+func toVersionResponse(v generated.ListForecastVersionsRow) VersionResponse {
+	resp := VersionResponse{
+		ID:        v.ID,
+		Model:     v.Model,
+		Version:   v.Version,
+		Notes:     v.Notes,
+		CreatedAt: v.CreatedAt.Time.UTC().Format(time.RFC3339),
+		UpdatedAt: v.UpdatedAt.Time.UTC().Format(time.RFC3339),
+		Hours:     v.Hours,
+		Stations:  v.Stations,
+	}
+	optional := func(t pgtype.Timestamptz) *string {
+		if !t.Valid {
+			return nil
+		}
+		s := t.Time.UTC().Format(time.RFC3339)
+		return &s
+	}
+	resp.TrainedAt = optional(v.TrainedAt)
+	resp.RolledBackAt = optional(v.RolledBackAt)
+	resp.FirstSlot = optional(v.FirstSlot)
+	resp.LastSlot = optional(v.LastSlot)
+	if v.CreatedBy.Valid {
+		resp.CreatedBy = &v.CreatedBy.Int32
+	}
+	return resp
+}
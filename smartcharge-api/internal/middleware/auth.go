@@ -28,41 +28,63 @@ func AuthRequired(jwtSecret []byte) gin.HandlerFunc {
 			return
 		}
 
-		parts := strings.SplitN(authHeader, " ", 2)
-		if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") {
-			response.Err(c, http.StatusUnauthorized, "AUTH_UNAUTHORIZED", "Invalid authorization format. Use: Bearer <token>")
+		userID, role, msg := parseBearer(authHeader, jwtSecret)
+		if msg != "" {
+			response.Err(c, http.StatusUnauthorized, "AUTH_UNAUTHORIZED", msg)
 			return
 		}
 
-		tokenString := parts[1]
-		claims := jwt.MapClaims{}
+		c.Set(string(ContextUserID), userID)
+		c.Set(string(ContextUserRole), role)
 
-		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return jwtSecret, nil
-		})
+		c.Next()
+	}
+}
 
-		if err != nil || !token.Valid {
-			response.Err(c, http.StatusUnauthorized, "AUTH_UNAUTHORIZED", "Invalid or expired token")
-			return
+// OptionalAuth returns a Gin middleware for public routes that personalize their response.
+// A valid token identifies the user as in AuthRequired; a missing or invalid one leaves the request anonymous.
+func OptionalAuth(jwtSecret []byte) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if authHeader := c.GetHeader("Authorization"); authHeader != "" {
+			if userID, role, msg := parseBearer(authHeader, jwtSecret); msg == "" {
+				c.Set(string(ContextUserID), userID)
+				c.Set(string(ContextUserRole), role)
+			}
 		}
+		c.Next()
+	}
+}
 
-		// Extract user ID and role from claims
-		userIDFloat, ok := claims["user_id"].(float64)
-		if !ok {
-			response.Err(c, http.StatusUnauthorized, "AUTH_UNAUTHORIZED", "Invalid token claims")
-			return
-		}
+// parseBearer validates a "Bearer <token>" header and extracts the user ID and role.
+// On failure it returns a client-facing message describing the problem.
+func parseBearer(authHeader string, jwtSecret []byte) (int32, string, string) {
+	parts := strings.SplitN(authHeader, " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") {
+		return 0, "", "Invalid authorization format. Use: Bearer <token>"
+	}
 
-		role, _ := claims["role"].(string)
+	tokenString := parts[1]
+	claims := jwt.MapClaims{}
 
-		c.Set(string(ContextUserID), int32(userIDFloat))
-		c.Set(string(ContextUserRole), role)
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return jwtSecret, nil
+	})
 
-		c.Next()
+	if err != nil || !token.Valid {
+		return 0, "", "Invalid or expired token"
 	}
+
+	// Extract user ID and role from claims
+	userIDFloat, ok := claims["user_id"].(float64)
+	if !ok {
+		return 0, "", "Invalid token claims"
+	}
+
+	role, _ := claims["role"].(string)
+	return int32(userIDFloat), role, ""
 }
 
 // GetUserID extracts the authenticated user's ID from the Gin context.
@@ -84,3 +106,18 @@ func GetUserRole(c *gin.Context) (string, bool) {
 	role, ok := val.(string)
 	return role, ok
 }
+
+// RequireRole returns a Gin middleware that only admits users with one of the given roles.
+// Must be chained after AuthRequired.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, _ := GetUserRole(c)
+		for _, r := range roles {
+			if role == r {
+				c.Next()
+				return
+			}
+		}
+		response.Err(c, http.StatusForbidden, "AUTH_FORBIDDEN", "Insufficient permissions")
+	}
+}
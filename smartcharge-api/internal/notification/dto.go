@@ -0,0 +1,20 @@
+package notification
+
+// --- Response DTOs ---
+
+// NotificationResponse is a single in-app notification.
+type NotificationResponse struct {
+	ID        int32   `json:"id"`
+	Type      string  `json:"type"`
+	Title     string  `json:"title"`
+	Body      string  `json:"body"`
+	Read      bool    `json:"read"`
+	ReadAt    *string `json:"readAt"`
+	CreatedAt string  `json:"createdAt"`
+}
+
+// ListResponse is the response for GET /v1/notifications.
+type ListResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+	UnreadCount   int32                  `json:"unreadCount"`
+}
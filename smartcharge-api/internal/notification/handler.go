@@ -0,0 +1,94 @@
+package notification
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	apperrors "smartcharge-api/internal/errors"
+	"smartcharge-api/internal/middleware"
+	"smartcharge-api/internal/policy"
+	"smartcharge-api/internal/response"
+)
+
+// Handler handles HTTP requests for notifications.
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new notification handler.
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers notification routes on the given router group.
+func (h *Handler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	notifications := rg.Group("/notifications", authMiddleware)
+
+	notifications.GET("", h.List)
+	notifications.PATCH("/:id/read", h.MarkRead)
+}
+
+// List handles GET /v1/notifications?page=1&perPage=20 for the authenticated user.
+func (h *Handler) List(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	page := queryInt(c, "page", 1)
+	perPage := queryInt(c, "perPage", 20)
+	if perPage > 100 {
+		perPage = 100
+	}
+
+	result, total, err := h.service.List(c.Request.Context(), userID, page, perPage)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.Paginated(c, result, response.Meta{Page: page, PerPage: perPage, TotalCount: total})
+}
+
+// MarkRead handles PATCH /v1/notifications/:id/read.
+func (h *Handler) MarkRead(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	val, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "Invalid notification ID")
+		return
+	}
+
+	result, err := h.service.MarkRead(c.Request.Context(), actor, int32(val))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// --- helpers ---
+
+// queryInt reads a positive integer query parameter, falling back to def.
+func queryInt(c *gin.Context, key string, def int) int {
+	if raw := c.Query(key); raw != "" {
+		if val, err := strconv.Atoi(raw); err == nil && val > 0 {
+			return val
+		}
+	}
+	return def
+}
+
+func handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*apperrors.AppError); ok {
+		response.Err(c, appErr.StatusCode, appErr.Code, appErr.Message)
+		return
+	}
+	response.Err(c, 500, "INTERNAL_ERROR", "An unexpected error occurred")
+}
@@ -0,0 +1,43 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"smartcharge-api/db/generated"
+)
+
+// Notification types.
+const (
+	TypeCoinExpiryWarning = "COIN_EXPIRY_WARNING"
+	TypeCoinExpired       = "COIN_EXPIRED"
+	TypeBadgeEarned       = "BADGE_EARNED"
+	TypeCampaignPaused    = "CAMPAIGN_PAUSED"
+)
+
+// Message is a notification to deliver to a user.
+type Message struct {
+	UserID int32
+	Type   string
+	Title  string
+	Body   string
+	// DedupeKey, if set, makes Send a no-op when a notification with the same key already exists.
+	DedupeKey string
+}
+
+// Send stores a notification. q may be bound to the caller's transaction.
+func Send(ctx context.Context, q *generated.Queries, m Message) error {
+	var key pgtype.Text
+	if m.DedupeKey != "" {
+		key = pgtype.Text{String: m.DedupeKey, Valid: true}
+	}
+
+	return q.CreateNotification(ctx, generated.CreateNotificationParams{
+		UserID:    m.UserID,
+		Type:      m.Type,
+		Title:     m.Title,
+		Body:      m.Body,
+		DedupeKey: key,
+	})
+}
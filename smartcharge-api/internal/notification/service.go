@@ -0,0 +1,90 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"smartcharge-api/db/generated"
+	apperrors "smartcharge-api/internal/errors"
+	"smartcharge-api/internal/policy"
+)
+
+// Service handles reading and acknowledging notifications.
+type Service struct {
+	queries *generated.Queries
+}
+
+// NewService creates a new notification service.
+func NewService(queries *generated.Queries) *Service {
+	return &Service{queries: queries}
+}
+
+// List returns a page of the user's notifications (newest first), the unread count and the total count.
+func (s *Service) List(ctx context.Context, userID int32, page, perPage int) (*ListResponse, int, error) {
+	total, err := s.queries.CountNotificationsByUser(ctx, userID)
+	if err != nil {
+		return nil, 0, apperrors.ErrInternal
+	}
+
+	unread, err := s.queries.CountUnreadNotifications(ctx, userID)
+	if err != nil {
+		return nil, 0, apperrors.ErrInternal
+	}
+
+	rows, err := s.queries.ListNotificationsByUser(ctx, generated.ListNotificationsByUserParams{
+		UserID: userID,
+		Limit:  int32(perPage),
+		Offset: int32((page - 1) * perPage),
+	})
+	if err != nil {
+		return nil, 0, apperrors.ErrInternal
+	}
+
+	items := make([]NotificationResponse, len(rows))
+	for i, r := range rows {
+		items[i] = notificationToResponse(r)
+	}
+	return &ListResponse{Notifications: items, UnreadCount: unread}, int(total), nil
+}
+
+// MarkRead marks one of the user's notifications as read.
+func (s *Service) MarkRead(ctx context.Context, actor policy.Actor, notificationID int32) (*NotificationResponse, error) {
+	existing, err := s.queries.GetNotificationByID(ctx, notificationID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("Notification")
+	}
+	if err := policy.RequireOwner(actor, existing.UserID); err != nil {
+		return nil, err
+	}
+
+	n, err := s.queries.MarkNotificationRead(ctx, generated.MarkNotificationReadParams{
+		ID:     notificationID,
+		UserID: existing.UserID,
+	})
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("Notification")
+	}
+
+	resp := notificationToResponse(n)
+	return &resp, nil
+}
+
+// --- helpers ---
+
+func notificationToResponse(n generated.Notification) NotificationResponse {
+	resp := NotificationResponse{
+		ID:    n.ID,
+		Type:  n.Type,
+		Title: n.Title,
+		Body:  n.Body,
+		Read:  n.ReadAt.Valid,
+	}
+	if n.ReadAt.Valid {
+		readAt := n.ReadAt.Time.UTC().Format(time.RFC3339)
+		resp.ReadAt = &readAt
+	}
+	if n.CreatedAt.Valid {
+		resp.CreatedAt = n.CreatedAt.Time.UTC().Format(time.RFC3339)
+	}
+	return resp
+}
@@ -20,6 +20,36 @@ type UpdateStationRequest struct {
 	Price   *float64 `json:"price,omitempty"`
 }
 
+// UpdateCoinSettingsRequest is the request body for PUT /v1/company/coin-settings.
+// Omitted fields keep their current value.
+type UpdateCoinSettingsRequest struct {
+	CoinValue             *float64 `json:"coinValue,omitempty"`
+	MaxCoinShare          *float64 `json:"maxCoinShare,omitempty"`
+	RefundWindowHours     *int32   `json:"refundWindowHours,omitempty"`
+	LateCancelRefundShare *float64 `json:"lateCancelRefundShare,omitempty"`
+}
+
+// UpdateCampaignSettingsRequest is the request body for PUT /v1/company/campaign-settings.
+type UpdateCampaignSettingsRequest struct {
+	MaxCombinedDiscount float64 `json:"maxCombinedDiscount"`
+}
+
+// IngestOccupancyRequest is the request body for POST /v1/company/my-stations/:id/occupancy.
+type IngestOccupancyRequest struct {
+	Readings []OccupancyReading `json:"readings" binding:"required"`
+}
+
+// OccupancyReading is a station's observed load (0–100) during the hour containing time.
+type OccupancyReading struct {
+	Time string `json:"time"` // RFC 3339
+	Load int32  `json:"load"`
+}
+
+// SetForecastModelRequest is the request body for PUT /v1/company/my-stations/:id/forecast-model.
+type SetForecastModelRequest struct {
+	Model string `json:"model" binding:"required"`
+}
+
 // --- Response DTOs ---
 
 // StationSummary is a single station with computed stats for the operator dashboard.
@@ -35,6 +65,7 @@ type StationSummary struct {
 	ReservationCount      int32   `json:"reservationCount"`
 	GreenReservationCount int32   `json:"greenReservationCount"`
 	Revenue               float64 `json:"revenue"`
+	ForecastModel         string  `json:"forecastModel"`
 }
 
 // AggregateStats are the total stats across all operator stations.
@@ -61,3 +92,62 @@ type StationResponse struct {
 	Price   float64 `json:"price"`
 	Density int32   `json:"density"`
 }
+
+// CoinSettingsResponse is the operator's SmartCoin redemption policy.
+type CoinSettingsResponse struct {
+	CoinValue             float64 `json:"coinValue"`
+	MaxCoinShare          float64 `json:"maxCoinShare"`
+	RefundWindowHours     int32   `json:"refundWindowHours"`
+	LateCancelRefundShare float64 `json:"lateCancelRefundShare"`
+}
+
+// CampaignSettingsResponse is the operator's campaign stacking policy.
+type CampaignSettingsResponse struct {
+	// MaxCombinedDiscount is the largest share (0–1) of a slot price stacked campaigns may take off together.
+	MaxCombinedDiscount float64 `json:"maxCombinedDiscount"`
+}
+
+// IngestOccupancyResponse reports how many hourly readings were stored.
+type IngestOccupancyResponse struct {
+	StationID int32 `json:"stationId"`
+	Ingested  int   `json:"ingested"`
+}
+
+// ForecastModelResponse is the station's forecasting model after a change.
+// Trained is false if the station has no history yet; it is then forecast on the next retraining after some arrives.
+type ForecastModelResponse struct {
+	StationID int32  `json:"stationId"`
+	Model     string `json:"model"`
+	Trained   bool   `json:"trained"`
+}
+
+// ForecastAccuracyResponse is how well a station's forecast did over its last evaluated week,
+// overall and per hour of day. Errors are in load points (0–100).
+type ForecastAccuracyResponse struct {
+	StationID   int32  `json:"stationId"`
+	StationName string `json:"stationName"`
+	// Model is the model that was evaluated; CurrentModel differs if the station has switched since
+	Model        string          `json:"model"`
+	CurrentModel string          `json:"currentModel"`
+	WindowStart  string          `json:"windowStart"`
+	WindowEnd    string          `json:"windowEnd"`
+	EvaluatedAt  string          `json:"evaluatedAt"`
+	Overall      AccuracyMetrics `json:"overall"`
+	ByHour       []HourAccuracy  `json:"byHour"`
+}
+
+// AccuracyMetrics are forecast errors over a set of hours.
+type AccuracyMetrics struct {
+	Samples int32   `json:"samples"`
+	MAE     float64 `json:"mae"`
+	// MAPE is a percentage over the hours with a non-zero load; nil if there were none
+	MAPE *float64 `json:"mape"`
+	// Bias is the mean of forecast minus actual: positive when the forecast runs high
+	Bias float64 `json:"bias"`
+}
+
+// HourAccuracy is the forecast accuracy at one hour of the day.
+type HourAccuracy struct {
+	Hour int32 `json:"hour"`
+	AccuracyMetrics
+}
@@ -7,6 +7,7 @@ import (
 
 	apperrors "smartcharge-api/internal/errors"
 	"smartcharge-api/internal/middleware"
+	"smartcharge-api/internal/policy"
 	"smartcharge-api/internal/response"
 )
 
@@ -28,6 +29,15 @@ func (h *Handler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.Handler
 	company.POST("/my-stations", h.CreateStation)
 	company.PUT("/my-stations/:id", h.UpdateStation)
 	company.DELETE("/my-stations/:id", h.DeleteStation)
+	company.POST("/my-stations/:id/occupancy", h.IngestOccupancy)
+	company.PUT("/my-stations/:id/forecast-model", h.SetForecastModel)
+
+	company.GET("/forecast-accuracy", h.ForecastAccuracy)
+
+	company.GET("/coin-settings", h.GetCoinSettings)
+	company.PUT("/coin-settings", h.UpdateCoinSettings)
+	company.GET("/campaign-settings", h.GetCampaignSettings)
+	company.PUT("/campaign-settings", h.UpdateCampaignSettings)
 }
 
 // ListMyStations handles GET /v1/company/my-stations.
@@ -48,7 +58,7 @@ func (h *Handler) ListMyStations(c *gin.Context) {
 
 // CreateStation handles POST /v1/company/my-stations.
 func (h *Handler) CreateStation(c *gin.Context) {
-	userID, ok := middleware.GetUserID(c)
+	actor, ok := policy.FromContext(c)
 	if !ok {
 		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
 		return
@@ -60,7 +70,7 @@ func (h *Handler) CreateStation(c *gin.Context) {
 		return
 	}
 
-	result, err := h.service.CreateStation(c.Request.Context(), userID, req)
+	result, err := h.service.CreateStation(c.Request.Context(), actor, req)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -70,6 +80,11 @@ func (h *Handler) CreateStation(c *gin.Context) {
 
 // UpdateStation handles PUT /v1/company/my-stations/:id.
 func (h *Handler) UpdateStation(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
 	id, err := parseID(c)
 	if err != nil {
 		return
@@ -81,7 +96,7 @@ func (h *Handler) UpdateStation(c *gin.Context) {
 		return
 	}
 
-	result, err := h.service.UpdateStation(c.Request.Context(), id, req)
+	result, err := h.service.UpdateStation(c.Request.Context(), actor, id, req)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -91,18 +106,179 @@ func (h *Handler) UpdateStation(c *gin.Context) {
 
 // DeleteStation handles DELETE /v1/company/my-stations/:id.
 func (h *Handler) DeleteStation(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
 	id, err := parseID(c)
 	if err != nil {
 		return
 	}
 
-	if err := h.service.DeleteStation(c.Request.Context(), id); err != nil {
+	if err := h.service.DeleteStation(c.Request.Context(), actor, id); err != nil {
 		handleError(c, err)
 		return
 	}
 	response.OK(c, gin.H{"message": "Station deleted"})
 }
 
+// IngestOccupancy handles POST /v1/company/my-stations/:id/occupancy.
+func (h *Handler) IngestOccupancy(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+	id, err := parseID(c)
+	if err != nil {
+		return
+	}
+
+	var req IngestOccupancyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "readings are required")
+		return
+	}
+
+	result, err := h.service.IngestOccupancy(c.Request.Context(), actor, id, req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// SetForecastModel handles PUT /v1/company/my-stations/:id/forecast-model.
+func (h *Handler) SetForecastModel(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+	id, err := parseID(c)
+	if err != nil {
+		return
+	}
+
+	var req SetForecastModelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "model is required")
+		return
+	}
+
+	result, err := h.service.SetForecastModel(c.Request.Context(), actor, id, req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// ForecastAccuracy handles GET /v1/company/forecast-accuracy.
+// Pass ?stationId= to get a single station.
+func (h *Handler) ForecastAccuracy(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	var stationID *int32
+	if raw := c.Query("stationId"); raw != "" {
+		val, err := strconv.Atoi(raw)
+		if err != nil {
+			response.Err(c, 400, "VALIDATION_ERROR", "Invalid station ID")
+			return
+		}
+		id := int32(val)
+		stationID = &id
+	}
+
+	result, err := h.service.ForecastAccuracy(c.Request.Context(), actor, stationID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// GetCoinSettings handles GET /v1/company/coin-settings.
+func (h *Handler) GetCoinSettings(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	result, err := h.service.GetCoinSettings(c.Request.Context(), userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// UpdateCoinSettings handles PUT /v1/company/coin-settings.
+func (h *Handler) UpdateCoinSettings(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	var req UpdateCoinSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "Invalid request body")
+		return
+	}
+
+	result, err := h.service.UpdateCoinSettings(c.Request.Context(), actor, req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// GetCampaignSettings handles GET /v1/company/campaign-settings.
+func (h *Handler) GetCampaignSettings(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	result, err := h.service.GetCampaignSettings(c.Request.Context(), userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// UpdateCampaignSettings handles PUT /v1/company/campaign-settings.
+func (h *Handler) UpdateCampaignSettings(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	var req UpdateCampaignSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "Invalid request body")
+		return
+	}
+
+	result, err := h.service.UpdateCampaignSettings(c.Request.Context(), actor, req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
 // --- helpers ---
 
 func parseID(c *gin.Context) (int32, error) {
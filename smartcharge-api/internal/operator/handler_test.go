@@ -0,0 +1,91 @@
+package operator
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"smartcharge-api/db/generated"
+	"smartcharge-api/internal/apitest"
+	"smartcharge-api/internal/forecast"
+)
+
+func TestMutatingRoutesAuthorization(t *testing.T) {
+	station := map[string]any{"name": "Kadıköy", "lat": 40.99, "lng": 29.03, "price": 7.5}
+	readings := IngestOccupancyRequest{Readings: []OccupancyReading{
+		{Time: time.Now().Add(-time.Hour).Format(time.RFC3339), Load: 40},
+	}}
+
+	tests := []struct {
+		name  string
+		route apitest.Route
+		write string         // the query that makes the change
+		want  map[string]int // status by apitest.Actors name
+	}{
+		{
+			name:  "create station",
+			route: apitest.Route{Method: http.MethodPost, Path: "/v1/company/my-stations", Body: station},
+			write: "CreateStation",
+			want:  map[string]int{"driver": 403, "owning operator": 201, "other operator": 201, "admin": 201},
+		},
+		{
+			name:  "update station",
+			route: apitest.Route{Method: http.MethodPut, Path: "/v1/company/my-stations/5", Body: station},
+			write: "UpdateStation",
+			want:  map[string]int{"driver": 403, "owning operator": 200, "other operator": 403, "admin": 200},
+		},
+		{
+			name:  "delete station",
+			route: apitest.Route{Method: http.MethodDelete, Path: "/v1/company/my-stations/5"},
+			write: "DeleteStation",
+			want:  map[string]int{"driver": 403, "owning operator": 200, "other operator": 403, "admin": 200},
+		},
+		{
+			name:  "ingest occupancy",
+			route: apitest.Route{Method: http.MethodPost, Path: "/v1/company/my-stations/5/occupancy", Body: readings},
+			write: "UpsertOccupancy",
+			want:  map[string]int{"driver": 403, "owning operator": 200, "other operator": 403, "admin": 200},
+		},
+		{
+			name:  "set forecast model",
+			route: apitest.Route{Method: http.MethodPut, Path: "/v1/company/my-stations/5/forecast-model", Body: SetForecastModelRequest{Model: forecast.DefaultModel}},
+			write: "UpdateStationForecastModel",
+			want:  map[string]int{"driver": 403, "owning operator": 200, "other operator": 403, "admin": 200},
+		},
+		{
+			name:  "update coin settings",
+			route: apitest.Route{Method: http.MethodPut, Path: "/v1/company/coin-settings", Body: UpdateCoinSettingsRequest{}},
+			write: "UpsertOperatorSettings",
+			want:  map[string]int{"driver": 403, "owning operator": 200, "other operator": 200, "admin": 200},
+		},
+		{
+			name:  "update campaign settings",
+			route: apitest.Route{Method: http.MethodPut, Path: "/v1/company/campaign-settings", Body: UpdateCampaignSettingsRequest{MaxCombinedDiscount: 0.5}},
+			write: "UpsertOperatorSettings",
+			want:  map[string]int{"driver": 403, "owning operator": 200, "other operator": 200, "admin": 200},
+		},
+	}
+
+	for _, tt := range tests {
+		for name, want := range tt.want {
+			actor := apitest.Actors[name]
+			t.Run(tt.name+"/"+name, func(t *testing.T) {
+				db := apitest.NewDB().
+					On("GetStationByID", generated.Station{ID: 5, OwnerID: pgtype.Int4{Int32: apitest.Owner.UserID, Valid: true}})
+				h := NewHandler(&Service{queries: generated.New(db), pool: db})
+				r := apitest.Router(actor, func(v1 *gin.RouterGroup, auth gin.HandlerFunc) { h.RegisterRoutes(v1, auth) })
+
+				w := apitest.Do(r, tt.route)
+				if w.Code != want {
+					t.Fatalf("status = %d, want %d: %s", w.Code, want, w.Body)
+				}
+				if wrote := db.Called(tt.write); wrote != (want != http.StatusForbidden) {
+					t.Errorf("%s called = %v with status %d", tt.write, wrote, w.Code)
+				}
+			})
+		}
+	}
+}
@@ -2,22 +2,31 @@ package operator
 
 import (
 	"context"
+	"fmt"
 	"math"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 
 	"smartcharge-api/db/generated"
 	apperrors "smartcharge-api/internal/errors"
+	"smartcharge-api/internal/forecast"
+	"smartcharge-api/internal/policy"
+	"smartcharge-api/internal/pricing"
 )
 
 // Service handles operator business logic.
 type Service struct {
 	queries *generated.Queries
+	pool    forecast.TxBeginner
 }
 
 // NewService creates a new operator service.
-func NewService(queries *generated.Queries) *Service {
-	return &Service{queries: queries}
+func NewService(queries *generated.Queries, pool *pgxpool.Pool) *Service {
+	return &Service{queries: queries, pool: pool}
 }
 
 // densityStatus returns a status string based on density thresholds.
@@ -79,6 +88,7 @@ func (s *Service) ListMyStations(ctx context.Context, ownerID int32) (*MyStation
 			ReservationCount:      stats.TotalReservations,
 			GreenReservationCount: stats.GreenReservations,
 			Revenue:               roundTo2(revenue),
+			ForecastModel:         row.ForecastModel,
 		})
 
 		totalRevenue += revenue
@@ -109,7 +119,11 @@ func (s *Service) ListMyStations(ctx context.Context, ownerID int32) (*MyStation
 }
 
 // CreateStation creates a new station for the operator.
-func (s *Service) CreateStation(ctx context.Context, ownerID int32, req CreateStationRequest) (*StationResponse, error) {
+func (s *Service) CreateStation(ctx context.Context, actor policy.Actor, req CreateStationRequest) (*StationResponse, error) {
+	if err := policy.RequireRole(actor, policy.RoleOperator); err != nil {
+		return nil, err
+	}
+
 	var address pgtype.Text
 	if req.Address != nil {
 		address = pgtype.Text{String: *req.Address, Valid: true}
@@ -121,7 +135,7 @@ func (s *Service) CreateStation(ctx context.Context, ownerID int32, req CreateSt
 		Lng:            req.Lng,
 		Address:        address,
 		Price:          req.Price,
-		OwnerID:        pgtype.Int4{Int32: ownerID, Valid: true},
+		OwnerID:        pgtype.Int4{Int32: actor.UserID, Valid: true},
 		DensityProfile: "flat",
 	})
 	if err != nil {
@@ -132,12 +146,14 @@ func (s *Service) CreateStation(ctx context.Context, ownerID int32, req CreateSt
 }
 
 // UpdateStation updates an operator's station.
-func (s *Service) UpdateStation(ctx context.Context, stationID int32, req UpdateStationRequest) (*StationResponse, error) {
-	// Verify station exists
+func (s *Service) UpdateStation(ctx context.Context, actor policy.Actor, stationID int32, req UpdateStationRequest) (*StationResponse, error) {
 	existing, err := s.queries.GetStationByID(ctx, stationID)
 	if err != nil {
 		return nil, apperrors.NewNotFoundError("Station")
 	}
+	if err := policy.RequireStationOwner(actor, existing.OwnerID); err != nil {
+		return nil, err
+	}
 
 	// Build update params with fallback to existing values
 	name := existing.Name
@@ -181,11 +197,14 @@ func (s *Service) UpdateStation(ctx context.Context, stationID int32, req Update
 }
 
 // DeleteStation deletes an operator's station.
-func (s *Service) DeleteStation(ctx context.Context, stationID int32) error {
-	_, err := s.queries.GetStationByID(ctx, stationID)
+func (s *Service) DeleteStation(ctx context.Context, actor policy.Actor, stationID int32) error {
+	existing, err := s.queries.GetStationByID(ctx, stationID)
 	if err != nil {
 		return apperrors.NewNotFoundError("Station")
 	}
+	if err := policy.RequireStationOwner(actor, existing.OwnerID); err != nil {
+		return err
+	}
 
 	if err := s.queries.DeleteStation(ctx, stationID); err != nil {
 		return &apperrors.AppError{StatusCode: 500, Code: "DELETE_FAILED", Message: "Could not delete station. It may have linked reservations."}
@@ -193,8 +212,271 @@ func (s *Service) DeleteStation(ctx context.Context, stationID int32) error {
 	return nil
 }
 
+// maxOccupancyReadings caps a single ingestion request (a bit over two months of hourly readings).
+const maxOccupancyReadings = 1500
+
+// IngestOccupancy stores observed load readings for one of the operator's stations.
+// Forecasts pick them up on their next retraining.
+func (s *Service) IngestOccupancy(ctx context.Context, actor policy.Actor, stationID int32, req IngestOccupancyRequest) (*IngestOccupancyResponse, error) {
+	station, err := s.queries.GetStationByID(ctx, stationID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("Station")
+	}
+	if err := policy.RequireStationOwner(actor, station.OwnerID); err != nil {
+		return nil, err
+	}
+
+	if len(req.Readings) == 0 || len(req.Readings) > maxOccupancyReadings {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("Send between 1 and %d readings", maxOccupancyReadings))
+	}
+
+	now := time.Now()
+	samples := make([]forecast.Sample, len(req.Readings))
+	hours := make(map[time.Time]bool, len(req.Readings))
+	for i, r := range req.Readings {
+		t, err := time.Parse(time.RFC3339, r.Time)
+		if err != nil {
+			return nil, apperrors.NewValidationError(fmt.Sprintf("readings[%d].time must be an RFC 3339 timestamp", i))
+		}
+		if t.After(now) {
+			return nil, apperrors.NewValidationError(fmt.Sprintf("readings[%d].time is in the future", i))
+		}
+		if r.Load < 0 || r.Load > 100 {
+			return nil, apperrors.NewValidationError(fmt.Sprintf("readings[%d].load must be between 0 and 100", i))
+		}
+		samples[i] = forecast.Sample{Time: t, Load: float64(r.Load)}
+		hours[t.Truncate(time.Hour).UTC()] = true
+	}
+
+	if err := forecast.Record(ctx, s.queries, stationID, forecast.SourceOperator, samples); err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	return &IngestOccupancyResponse{StationID: stationID, Ingested: len(hours)}, nil
+}
+
+// SetForecastModel switches one of the operator's stations to another forecasting model
+// and retrains its forecast with it straight away.
+func (s *Service) SetForecastModel(ctx context.Context, actor policy.Actor, stationID int32, req SetForecastModelRequest) (*ForecastModelResponse, error) {
+	station, err := s.queries.GetStationByID(ctx, stationID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("Station")
+	}
+	if err := policy.RequireStationOwner(actor, station.OwnerID); err != nil {
+		return nil, err
+	}
+
+	model, ok := forecast.Models[req.Model]
+	if !ok {
+		return nil, apperrors.NewValidationError("model must be one of: " + strings.Join(forecast.ModelNames(), ", "))
+	}
+
+	if err := s.queries.UpdateStationForecastModel(ctx, generated.UpdateStationForecastModelParams{
+		ID:            stationID,
+		ForecastModel: model.Name(),
+	}); err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	trained, err := forecast.Train(ctx, s.pool, s.queries, model, stationID, time.Now())
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	return &ForecastModelResponse{StationID: stationID, Model: model.Name(), Trained: trained}, nil
+}
+
+// ForecastAccuracy returns the latest forecast accuracy of the operator's stations, or of every station
+// for admins, worst forecast first. stationID, if set, narrows it to that station.
+func (s *Service) ForecastAccuracy(ctx context.Context, actor policy.Actor, stationID *int32) ([]ForecastAccuracyResponse, error) {
+	if err := policy.RequireRole(actor, policy.RoleOperator); err != nil {
+		return nil, err
+	}
+
+	var params generated.ListForecastAccuracyParams
+	if !actor.IsAdmin() {
+		params.OwnerID = pgtype.Int4{Int32: actor.UserID, Valid: true}
+	}
+	if stationID != nil {
+		params.StationID = pgtype.Int4{Int32: *stationID, Valid: true}
+	}
+	rows, err := s.queries.ListForecastAccuracy(ctx, params)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	// Rows come ordered by station, one per hour
+	result := []ForecastAccuracyResponse{}
+	var overall accuracySum
+	for i, row := range rows {
+		if i == 0 || row.StationID != rows[i-1].StationID {
+			result = append(result, ForecastAccuracyResponse{
+				StationID:    row.StationID,
+				StationName:  row.StationName,
+				Model:        row.Model,
+				CurrentModel: row.ForecastModel,
+				WindowStart:  row.WindowStart.Time.UTC().Format(time.RFC3339),
+				WindowEnd:    row.WindowEnd.Time.UTC().Format(time.RFC3339),
+				EvaluatedAt:  row.EvaluatedAt.Time.UTC().Format(time.RFC3339),
+				ByHour:       []HourAccuracy{},
+			})
+			overall = accuracySum{}
+		}
+		r := &result[len(result)-1]
+
+		var hour accuracySum
+		hour.add(row)
+		overall.add(row)
+		r.ByHour = append(r.ByHour, HourAccuracy{Hour: row.Hour, AccuracyMetrics: hour.metrics()})
+		r.Overall = overall.metrics()
+	}
+
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Overall.MAE > result[j].Overall.MAE })
+	return result, nil
+}
+
+// accuracySum pools per-hour forecast accuracy rows, weighting each by its sample counts.
+type accuracySum struct {
+	samples, mapeSamples int32
+	abs, signed, pct     float64
+}
+
+func (a *accuracySum) add(row generated.ListForecastAccuracyRow) {
+	a.samples += row.Samples
+	a.abs += row.Mae * float64(row.Samples)
+	a.signed += row.Bias * float64(row.Samples)
+	a.mapeSamples += row.MapeSamples
+	a.pct += row.Mape * float64(row.MapeSamples)
+}
+
+func (a accuracySum) metrics() AccuracyMetrics {
+	m := AccuracyMetrics{Samples: a.samples}
+	if a.samples > 0 {
+		m.MAE = roundTo2(a.abs / float64(a.samples))
+		m.Bias = roundTo2(a.signed / float64(a.samples))
+	}
+	if a.mapeSamples > 0 {
+		mape := roundTo2(a.pct / float64(a.mapeSamples))
+		m.MAPE = &mape
+	}
+	return m
+}
+
+// GetCoinSettings returns the operator's coin redemption policy (defaults if never configured).
+func (s *Service) GetCoinSettings(ctx context.Context, operatorID int32) (*CoinSettingsResponse, error) {
+	policy, err := pricing.LoadCoinPolicy(ctx, s.queries, pgtype.Int4{Int32: operatorID, Valid: true})
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	return coinPolicyToResponse(policy), nil
+}
+
+// UpdateCoinSettings updates the operator's coin redemption policy.
+func (s *Service) UpdateCoinSettings(ctx context.Context, actor policy.Actor, req UpdateCoinSettingsRequest) (*CoinSettingsResponse, error) {
+	if err := policy.RequireRole(actor, policy.RoleOperator); err != nil {
+		return nil, err
+	}
+	operatorID := actor.UserID
+
+	coinPolicy, err := pricing.LoadCoinPolicy(ctx, s.queries, pgtype.Int4{Int32: operatorID, Valid: true})
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	if req.CoinValue != nil {
+		if *req.CoinValue <= 0 {
+			return nil, apperrors.NewValidationError("coinValue must be greater than 0")
+		}
+		coinPolicy.CoinValue = *req.CoinValue
+	}
+	if req.MaxCoinShare != nil {
+		if *req.MaxCoinShare < 0 || *req.MaxCoinShare > 1 {
+			return nil, apperrors.NewValidationError("maxCoinShare must be between 0 and 1")
+		}
+		coinPolicy.MaxCoinShare = *req.MaxCoinShare
+	}
+	if req.RefundWindowHours != nil {
+		if *req.RefundWindowHours < 0 {
+			return nil, apperrors.NewValidationError("refundWindowHours cannot be negative")
+		}
+		coinPolicy.RefundWindowHours = *req.RefundWindowHours
+	}
+	if req.LateCancelRefundShare != nil {
+		if *req.LateCancelRefundShare < 0 || *req.LateCancelRefundShare > 1 {
+			return nil, apperrors.NewValidationError("lateCancelRefundShare must be between 0 and 1")
+		}
+		coinPolicy.LateCancelRefundShare = *req.LateCancelRefundShare
+	}
+
+	maxCombined, err := pricing.LoadMaxCombinedDiscount(ctx, s.queries, pgtype.Int4{Int32: operatorID, Valid: true})
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	settings, err := s.saveSettings(ctx, operatorID, coinPolicy, maxCombined)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	return coinPolicyToResponse(pricing.PolicyFromSettings(settings)), nil
+}
+
+// GetCampaignSettings returns the operator's campaign stacking policy (defaults if never configured).
+func (s *Service) GetCampaignSettings(ctx context.Context, operatorID int32) (*CampaignSettingsResponse, error) {
+	maxCombined, err := pricing.LoadMaxCombinedDiscount(ctx, s.queries, pgtype.Int4{Int32: operatorID, Valid: true})
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	return &CampaignSettingsResponse{MaxCombinedDiscount: maxCombined}, nil
+}
+
+// UpdateCampaignSettings updates the operator's campaign stacking policy.
+func (s *Service) UpdateCampaignSettings(ctx context.Context, actor policy.Actor, req UpdateCampaignSettingsRequest) (*CampaignSettingsResponse, error) {
+	if err := policy.RequireRole(actor, policy.RoleOperator); err != nil {
+		return nil, err
+	}
+	operatorID := actor.UserID
+
+	if req.MaxCombinedDiscount < 0 || req.MaxCombinedDiscount > 1 {
+		return nil, apperrors.NewValidationError("maxCombinedDiscount must be between 0 and 1")
+	}
+
+	coinPolicy, err := pricing.LoadCoinPolicy(ctx, s.queries, pgtype.Int4{Int32: operatorID, Valid: true})
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	settings, err := s.saveSettings(ctx, operatorID, coinPolicy, req.MaxCombinedDiscount)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	return &CampaignSettingsResponse{MaxCombinedDiscount: settings.MaxCombinedDiscount}, nil
+}
+
+// saveSettings writes the operator's full settings row; coin and campaign settings share it.
+func (s *Service) saveSettings(ctx context.Context, operatorID int32, policy pricing.CoinPolicy, maxCombined float64) (generated.OperatorSetting, error) {
+	return s.queries.UpsertOperatorSettings(ctx, generated.UpsertOperatorSettingsParams{
+		OperatorID:            operatorID,
+		CoinValue:             policy.CoinValue,
+		MaxCoinShare:          policy.MaxCoinShare,
+		RefundWindowHours:     policy.RefundWindowHours,
+		LateCancelRefundShare: policy.LateCancelRefundShare,
+		MaxCombinedDiscount:   maxCombined,
+	})
+}
+
 // --- helpers ---
 
+func coinPolicyToResponse(p pricing.CoinPolicy) *CoinSettingsResponse {
+	return &CoinSettingsResponse{
+		CoinValue:             p.CoinValue,
+		MaxCoinShare:          p.MaxCoinShare,
+		RefundWindowHours:     p.RefundWindowHours,
+		LateCancelRefundShare: p.LateCancelRefundShare,
+	}
+}
+
 func stationToResponse(s generated.Station) *StationResponse {
 	var address *string
 	if s.Address.Valid {
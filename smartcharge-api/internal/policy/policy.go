@@ -0,0 +1,81 @@
+// Package policy decides who may change what.
+// Handlers build an Actor from the request; services check it against the resource they loaded,
+// so every mutating endpoint applies the same role and ownership rules and fails with ErrForbidden.
+package policy
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	apperrors "smartcharge-api/internal/errors"
+	"smartcharge-api/internal/middleware"
+)
+
+// User roles, as stored on users.role and carried in the JWT.
+const (
+	RoleDriver   = "DRIVER"
+	RoleOperator = "OPERATOR"
+	RoleAdmin    = "ADMIN"
+)
+
+// Actor is the authenticated user making a request.
+type Actor struct {
+	UserID int32
+	Role   string
+}
+
+// FromContext returns the actor set by middleware.AuthRequired; ok is false for anonymous requests.
+func FromContext(c *gin.Context) (Actor, bool) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return Actor{}, false
+	}
+	role, _ := middleware.GetUserRole(c)
+	return Actor{UserID: userID, Role: role}, true
+}
+
+// IsAdmin reports whether the actor is an administrator. Admins pass every role and ownership check.
+func (a Actor) IsAdmin() bool {
+	return a.Role == RoleAdmin
+}
+
+// RequireRole allows actors with one of the given roles.
+func RequireRole(a Actor, roles ...string) error {
+	if a.IsAdmin() {
+		return nil
+	}
+	for _, r := range roles {
+		if a.Role == r {
+			return nil
+		}
+	}
+	return apperrors.ErrForbidden
+}
+
+// RequireOwner allows the owner of a resource.
+func RequireOwner(a Actor, ownerID int32) error {
+	if a.IsAdmin() || a.UserID == ownerID {
+		return nil
+	}
+	return apperrors.ErrForbidden
+}
+
+// RequireOperator allows operators acting on a resource they own, such as a campaign.
+func RequireOperator(a Actor, ownerID int32) error {
+	if err := RequireRole(a, RoleOperator); err != nil {
+		return err
+	}
+	return RequireOwner(a, ownerID)
+}
+
+// RequireStationOwner allows operators acting on one of their stations.
+// Stations without an owner can only be changed by admins.
+func RequireStationOwner(a Actor, ownerID pgtype.Int4) error {
+	if a.IsAdmin() {
+		return nil
+	}
+	if !ownerID.Valid {
+		return apperrors.ErrForbidden
+	}
+	return RequireOperator(a, ownerID.Int32)
+}
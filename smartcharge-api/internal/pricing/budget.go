@@ -0,0 +1,131 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"smartcharge-api/db/generated"
+	apperrors "smartcharge-api/internal/errors"
+)
+
+// Reasons a campaign paused itself.
+const (
+	PauseCoinBudget     = "COIN_BUDGET"
+	PauseDiscountBudget = "DISCOUNT_BUDGET"
+	PauseMaxRedemptions = "MAX_REDEMPTIONS"
+)
+
+// SessionDiscount converts a per-kWh discount into its value over a session, the unit discount budgets are kept in.
+func SessionDiscount(perKWh float64) float64 {
+	return SessionAmount(perKWh)
+}
+
+// ExhaustedReason returns which cap the campaign has reached, or "" if it still has room.
+func ExhaustedReason(c generated.Campaign) string {
+	switch {
+	case c.MaxRedemptions.Valid && c.Redemptions >= c.MaxRedemptions.Int32:
+		return PauseMaxRedemptions
+	case c.CoinBudget.Valid && c.CoinsSpent >= c.CoinBudget.Int32:
+		return PauseCoinBudget
+	case c.DiscountBudget.Valid && c.DiscountSpent >= c.DiscountBudget.Float64:
+		return PauseDiscountBudget
+	}
+	return ""
+}
+
+// ShortReason returns which cap leaves the campaign no room for the application, or "" if it can cover it.
+func ShortReason(c generated.Campaign, a AppliedCampaign) string {
+	switch {
+	case c.MaxRedemptions.Valid && c.Redemptions >= c.MaxRedemptions.Int32:
+		return PauseMaxRedemptions
+	case c.CoinBudget.Valid && c.CoinsSpent+a.CoinBonus > c.CoinBudget.Int32:
+		return PauseCoinBudget
+	case c.DiscountBudget.Valid && c.DiscountSpent+SessionDiscount(a.Discount) > c.DiscountBudget.Float64:
+		return PauseDiscountBudget
+	}
+	return ""
+}
+
+// Redeem charges one application of a campaign to its budget. q must be bound to the booking's
+// transaction, so the budget moves together with the reservation. Fails with
+// apperrors.ErrCampaignExhausted when the campaign can no longer cover the application or the
+// user has reached the per-user cap. When the redemption uses up a cap, the campaign is paused
+// and the updated row is returned with paused set.
+func Redeem(ctx context.Context, q *generated.Queries, userID int32, a AppliedCampaign) (c generated.Campaign, paused bool, err error) {
+	// The budget update locks the campaign row, so the per-user count below can't race another booking
+	c, err = q.ConsumeCampaignBudget(ctx, generated.ConsumeCampaignBudgetParams{
+		ID:       a.Campaign.ID,
+		Coins:    a.CoinBonus,
+		Discount: SessionDiscount(a.Discount),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c, false, apperrors.ErrCampaignExhausted
+	}
+	if err != nil {
+		return c, false, err
+	}
+
+	if c.MaxRedemptionsPerUser.Valid {
+		uses, err := q.CountUserCampaignRedemptions(ctx, generated.CountUserCampaignRedemptionsParams{
+			UserID:     userID,
+			CampaignID: c.ID,
+		})
+		if err != nil {
+			return c, false, err
+		}
+		if uses >= int64(c.MaxRedemptionsPerUser.Int32) {
+			return c, false, apperrors.ErrCampaignExhausted
+		}
+	}
+
+	reason := ExhaustedReason(c)
+	if reason == "" {
+		return c, false, nil
+	}
+	if err := q.PauseCampaign(ctx, generated.PauseCampaignParams{
+		ID:           c.ID,
+		PausedReason: pgtype.Text{String: reason, Valid: true},
+	}); err != nil {
+		return c, false, fmt.Errorf("pause campaign %d: %w", c.ID, err)
+	}
+	return c, true, nil
+}
+
+// PauseShort pauses a campaign Redeem turned away because a cap leaves no room for the application,
+// so it is no longer offered at a price its budget can't honour. A campaign turned away for another
+// reason (the per-user cap, or it was paused or ended meanwhile) is left as it is. paused reports
+// whether it was paused; the campaign is returned either way.
+func PauseShort(ctx context.Context, q *generated.Queries, a AppliedCampaign) (c generated.Campaign, paused bool, err error) {
+	c, err = q.GetCampaignForUpdate(ctx, a.Campaign.ID)
+	if err != nil {
+		return c, false, err
+	}
+	if c.Status != "ACTIVE" && c.Status != "DRAFT" {
+		return c, false, nil
+	}
+	reason := ShortReason(c, a)
+	if reason == "" {
+		return c, false, nil
+	}
+	if err := q.PauseCampaign(ctx, generated.PauseCampaignParams{
+		ID:           c.ID,
+		PausedReason: pgtype.Text{String: reason, Valid: true},
+	}); err != nil {
+		return c, false, fmt.Errorf("pause campaign %d: %w", c.ID, err)
+	}
+	return c, true, nil
+}
+
+// Release returns a cancelled reservation's share of the campaign budget.
+// A campaign that paused itself stays paused until its operator resumes it.
+func Release(ctx context.Context, q *generated.Queries, rc generated.ReservationCampaign) error {
+	return q.ReleaseCampaignBudget(ctx, generated.ReleaseCampaignBudgetParams{
+		ID:       rc.CampaignID,
+		Coins:    rc.CoinBonus,
+		Discount: SessionDiscount(rc.Discount),
+	})
+}
@@ -0,0 +1,87 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"smartcharge-api/db/generated"
+)
+
+// CoinPolicy is an operator's SmartCoin redemption policy.
+type CoinPolicy struct {
+	// CoinValue is the currency amount one coin is worth at checkout.
+	CoinValue float64
+	// MaxCoinShare is the largest share (0–1) of a session price payable with coins.
+	MaxCoinShare float64
+	// RefundWindowHours is how long before the session a cancellation still gets a full refund.
+	RefundWindowHours int32
+	// LateCancelRefundShare is the share (0–1) of redeemed coins refunded for later cancellations.
+	LateCancelRefundShare float64
+}
+
+// DefaultCoinPolicy applies to stations whose operator hasn't configured one.
+// Keep in sync with the column defaults of operator_settings.
+var DefaultCoinPolicy = CoinPolicy{
+	CoinValue:             0.1,
+	MaxCoinShare:          0.5,
+	RefundWindowHours:     2,
+	LateCancelRefundShare: 0.5,
+}
+
+// LoadCoinPolicy returns the coin policy of a station's operator, falling back to the default.
+func LoadCoinPolicy(ctx context.Context, q *generated.Queries, operatorID pgtype.Int4) (CoinPolicy, error) {
+	if !operatorID.Valid {
+		return DefaultCoinPolicy, nil
+	}
+
+	settings, err := q.GetOperatorSettings(ctx, operatorID.Int32)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return DefaultCoinPolicy, nil
+	}
+	if err != nil {
+		return CoinPolicy{}, err
+	}
+	return PolicyFromSettings(settings), nil
+}
+
+// PolicyFromSettings converts a stored settings row into a CoinPolicy.
+func PolicyFromSettings(s generated.OperatorSetting) CoinPolicy {
+	return CoinPolicy{
+		CoinValue:             s.CoinValue,
+		MaxCoinShare:          s.MaxCoinShare,
+		RefundWindowHours:     s.RefundWindowHours,
+		LateCancelRefundShare: s.LateCancelRefundShare,
+	}
+}
+
+// MaxRedeemable returns the most coins that can be applied to a session of the given price.
+func (p CoinPolicy) MaxRedeemable(price float64) int32 {
+	if p.CoinValue <= 0 || price <= 0 {
+		return 0
+	}
+	// Small epsilon so exact multiples aren't lost to float error
+	return int32(math.Floor(price*p.MaxCoinShare/p.CoinValue + 1e-9))
+}
+
+// Discount returns the currency value of the given number of coins.
+func (p CoinPolicy) Discount(coins int32) float64 {
+	return RoundTo2(float64(coins) * p.CoinValue)
+}
+
+// Refund returns how many of the redeemed coins are returned when cancelling at now
+// a session that starts at startsAt.
+func (p CoinPolicy) Refund(redeemed int32, startsAt, now time.Time) int32 {
+	if redeemed <= 0 {
+		return 0
+	}
+	window := time.Duration(p.RefundWindowHours) * time.Hour
+	if !now.After(startsAt.Add(-window)) {
+		return redeemed
+	}
+	return int32(math.Floor(float64(redeemed) * p.LateCancelRefundShare))
+}
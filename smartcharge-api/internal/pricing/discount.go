@@ -0,0 +1,153 @@
+package pricing
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"smartcharge-api/db/generated"
+)
+
+// Campaign discount types.
+const (
+	DiscountNone           = "NONE"
+	DiscountPercentage     = "PERCENTAGE"      // value: percent off the slot price (0–100]
+	DiscountPerKWh         = "PER_KWH"         // value: ₺ off every kWh
+	DiscountPerSession     = "PER_SESSION"     // value: ₺ off the whole session
+	DiscountFreeHours      = "FREE_HOURS"      // value: free charging hours per driver
+	DiscountCoinMultiplier = "COIN_MULTIPLIER" // value: multiplier on the slot's base coins
+)
+
+// SessionEnergyKWh is the energy assumed per charging session.
+// Reservations don't record metered energy yet, so per-session amounts are spread over an average session.
+const SessionEnergyKWh = 20.0
+
+// SessionAmount is what a session costs at the given per-kWh rate.
+func SessionAmount(perKWh float64) float64 {
+	return RoundTo2(perKWh * SessionEnergyKWh)
+}
+
+// DiscountRule is a campaign's typed discount.
+type DiscountRule struct {
+	Type  string
+	Value float64
+}
+
+// CampaignDiscount returns the campaign's discount rule.
+func CampaignDiscount(c generated.Campaign) DiscountRule {
+	return DiscountRule{Type: c.DiscountType, Value: c.DiscountValue}
+}
+
+// Validate checks the value is sensible for the discount type.
+func (d DiscountRule) Validate() error {
+	switch d.Type {
+	case DiscountNone:
+		if d.Value != 0 {
+			return fmt.Errorf("discountValue must be 0 when discountType is %s", DiscountNone)
+		}
+	case DiscountPercentage:
+		if d.Value <= 0 || d.Value > 100 {
+			return fmt.Errorf("percentage discount must be between 0 and 100")
+		}
+	case DiscountPerKWh, DiscountPerSession:
+		if d.Value <= 0 {
+			return fmt.Errorf("fixed discount must be greater than 0")
+		}
+	case DiscountFreeHours:
+		if d.Value < 1 || d.Value != math.Trunc(d.Value) {
+			return fmt.Errorf("free hours must be a whole number of at least 1")
+		}
+	case DiscountCoinMultiplier:
+		if d.Value <= 1 {
+			return fmt.Errorf("coin multiplier must be greater than 1")
+		}
+	default:
+		return fmt.Errorf("unknown discountType %q", d.Type)
+	}
+	return nil
+}
+
+// Label renders the discount for display, e.g. "%20" or "2x Coin".
+func (d DiscountRule) Label() string {
+	v := strconv.FormatFloat(d.Value, 'f', -1, 64)
+	switch d.Type {
+	case DiscountPercentage:
+		return "%" + v
+	case DiscountPerKWh:
+		return "₺" + v + "/kWh"
+	case DiscountPerSession:
+		return "₺" + v + " indirim"
+	case DiscountFreeHours:
+		if d.Value == 1 {
+			return "İlk saat ücretsiz"
+		}
+		return "İlk " + v + " saat ücretsiz"
+	case DiscountCoinMultiplier:
+		return v + "x Coin"
+	}
+	return ""
+}
+
+// apply applies the discount to a per-kWh price and base coin reward.
+func (d DiscountRule) apply(price float64, coins int32) (float64, int32) {
+	switch d.Type {
+	case DiscountPercentage:
+		price *= 1 - d.Value/100
+	case DiscountPerKWh:
+		price -= d.Value
+	case DiscountPerSession:
+		price -= d.Value / SessionEnergyKWh
+	case DiscountFreeHours:
+		price = 0
+	case DiscountCoinMultiplier:
+		coins = int32(math.Round(float64(coins) * d.Value))
+	}
+	return math.Max(price, 0), coins
+}
+
+var (
+	legacyPercent    = regexp.MustCompile(`^%\s*(\d+(?:[.,]\d+)?)$|^(\d+(?:[.,]\d+)?)\s*%$`)
+	legacyMultiplier = regexp.MustCompile(`(?i)^(\d+(?:[.,]\d+)?)\s*x(?:\s|$)`)
+	legacyPerKWh     = regexp.MustCompile(`(?i)^₺?\s*(\d+(?:[.,]\d+)?)\s*(?:₺|tl)?\s*/\s*kwh$`)
+	legacyFreeHours  = regexp.MustCompile(`(?i)ücretsiz|free`)
+	legacyNumber     = regexp.MustCompile(`\d+`)
+)
+
+// ParseLegacyDiscount converts an old free-text discount ("%20", "2x Coin", "İlk saat ücretsiz")
+// into a typed rule, following the same rules as the 000010 migration. Unrecognized text is an error.
+func ParseLegacyDiscount(s string) (DiscountRule, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return DiscountRule{Type: DiscountNone}, nil
+	}
+
+	number := func(m []string) float64 {
+		for _, g := range m[1:] {
+			if g != "" {
+				v, _ := strconv.ParseFloat(strings.ReplaceAll(g, ",", "."), 64)
+				return v
+			}
+		}
+		return 0
+	}
+
+	var d DiscountRule
+	switch {
+	case legacyPercent.MatchString(s):
+		d = DiscountRule{Type: DiscountPercentage, Value: number(legacyPercent.FindStringSubmatch(s))}
+	case legacyMultiplier.MatchString(s):
+		d = DiscountRule{Type: DiscountCoinMultiplier, Value: number(legacyMultiplier.FindStringSubmatch(s))}
+	case legacyPerKWh.MatchString(s):
+		d = DiscountRule{Type: DiscountPerKWh, Value: number(legacyPerKWh.FindStringSubmatch(s))}
+	case legacyFreeHours.MatchString(s):
+		d = DiscountRule{Type: DiscountFreeHours, Value: 1}
+		if n := legacyNumber.FindString(s); n != "" {
+			d.Value, _ = strconv.ParseFloat(n, 64)
+		}
+	default:
+		return DiscountRule{}, fmt.Errorf("unrecognized discount %q", s)
+	}
+	return d, d.Validate()
+}
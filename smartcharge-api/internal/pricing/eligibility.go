@@ -0,0 +1,144 @@
+package pricing
+
+import (
+	"context"
+	"strings"
+
+	"smartcharge-api/db/generated"
+)
+
+// Vehicle types a driver can declare and a campaign can target.
+var VehicleTypes = []string{"CAR", "SUV", "VAN", "MOTORCYCLE", "COMMERCIAL"}
+
+// Reasons a user is not eligible for a campaign.
+const (
+	ReasonLoginRequired    = "LOGIN_REQUIRED"
+	ReasonBadgeRequired    = "BADGE_REQUIRED"
+	ReasonNewCustomersOnly = "NEW_CUSTOMERS_ONLY"
+	ReasonVehicleType      = "VEHICLE_TYPE"
+	ReasonFreeHoursUsed    = "FREE_HOURS_USED"
+	ReasonRedemptionLimit  = "REDEMPTION_LIMIT"
+)
+
+// Profile holds the user facts campaign eligibility is decided on.
+// A nil *Profile is an anonymous visitor, who only qualifies for unrestricted campaigns.
+type Profile struct {
+	UserID            int32
+	BadgeIDs          map[int32]bool
+	VehicleType       string
+	CompletedSessions int64
+	// CampaignUses counts the user's non-cancelled reservations per campaign.
+	CampaignUses map[int32]int64
+}
+
+// Eligibility is the outcome of checking one campaign against a profile.
+type Eligibility struct {
+	Eligible      bool
+	MatchedBadges []generated.Badge
+	Reasons       []string
+}
+
+// NormalizeVehicleType upper-cases a vehicle type and reports whether it is known.
+func NormalizeVehicleType(v string) (string, bool) {
+	v = strings.ToUpper(strings.TrimSpace(v))
+	for _, t := range VehicleTypes {
+		if v == t {
+			return v, true
+		}
+	}
+	return v, false
+}
+
+// LoadProfile gathers the user's badges, declared vehicle type and completed session count.
+func LoadProfile(ctx context.Context, q *generated.Queries, userID int32) (*Profile, error) {
+	user, err := q.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	badges, err := q.GetUserBadges(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	completed, err := q.CountUserCompletedReservations(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := q.ListUserCampaignUsage(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Profile{
+		UserID:            userID,
+		BadgeIDs:          make(map[int32]bool, len(badges)),
+		CompletedSessions: completed,
+		CampaignUses:      make(map[int32]int64, len(usage)),
+	}
+	for _, b := range badges {
+		p.BadgeIDs[b.ID] = true
+	}
+	for _, u := range usage {
+		p.CampaignUses[u.CampaignID] = u.Uses
+	}
+	if user.VehicleType.Valid {
+		p.VehicleType = user.VehicleType.String
+	}
+	return p, nil
+}
+
+// CheckEligibility decides whether the profile qualifies for the campaign.
+// Every restriction the campaign sets must be met; holding any one target badge satisfies the badge restriction.
+// Free-hour campaigns and campaigns with a per-user redemption cap are capped per driver,
+// so they always need a logged-in user.
+func CheckEligibility(c generated.Campaign, targets []generated.Badge, p *Profile) Eligibility {
+	res := Eligibility{MatchedBadges: []generated.Badge{}, Reasons: []string{}}
+	restricted := len(targets) > 0 || c.NewCustomersOnly || len(c.VehicleTypes) > 0 ||
+		c.DiscountType == DiscountFreeHours || c.MaxRedemptionsPerUser.Valid
+
+	if p == nil {
+		if restricted {
+			res.Reasons = append(res.Reasons, ReasonLoginRequired)
+		}
+		res.Eligible = !restricted
+		return res
+	}
+
+	if len(targets) > 0 {
+		for _, b := range targets {
+			if p.BadgeIDs[b.ID] {
+				res.MatchedBadges = append(res.MatchedBadges, b)
+			}
+		}
+		if len(res.MatchedBadges) == 0 {
+			res.Reasons = append(res.Reasons, ReasonBadgeRequired)
+		}
+	}
+
+	if c.NewCustomersOnly && p.CompletedSessions > 0 {
+		res.Reasons = append(res.Reasons, ReasonNewCustomersOnly)
+	}
+
+	if len(c.VehicleTypes) > 0 {
+		matched := false
+		for _, v := range c.VehicleTypes {
+			if v == p.VehicleType {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			res.Reasons = append(res.Reasons, ReasonVehicleType)
+		}
+	}
+
+	if c.DiscountType == DiscountFreeHours && float64(p.CampaignUses[c.ID]) >= c.DiscountValue {
+		res.Reasons = append(res.Reasons, ReasonFreeHoursUsed)
+	}
+
+	if c.MaxRedemptionsPerUser.Valid && p.CampaignUses[c.ID] >= int64(c.MaxRedemptionsPerUser.Int32) {
+		res.Reasons = append(res.Reasons, ReasonRedemptionLimit)
+	}
+
+	res.Eligible = len(res.Reasons) == 0
+	return res
+}
@@ -0,0 +1,77 @@
+package pricing
+
+import (
+	"context"
+	"hash/fnv"
+	"strconv"
+
+	"smartcharge-api/db/generated"
+)
+
+// AssignVariant deterministically picks the variant a user sees, in proportion to the variants' weights.
+// The same user always lands in the same variant of a campaign as long as the variants don't change.
+func AssignVariant(variants []generated.CampaignVariant, campaignID, userID int32) *generated.CampaignVariant {
+	total := uint32(0)
+	for _, v := range variants {
+		total += uint32(v.Weight)
+	}
+	if total == 0 {
+		return nil
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(strconv.Itoa(int(campaignID)) + ":" + strconv.Itoa(int(userID))))
+	bucket := h.Sum32() % total
+
+	for i := range variants {
+		w := uint32(variants[i].Weight)
+		if bucket < w {
+			return &variants[i]
+		}
+		bucket -= w
+	}
+	return nil
+}
+
+// LoadVariant returns the profile's variant of the campaign, or nil when the campaign runs no
+// experiment or the visitor is anonymous (anonymous visitors see the campaign as defined).
+func LoadVariant(ctx context.Context, q *generated.Queries, c generated.Campaign, p *Profile) (*generated.CampaignVariant, error) {
+	if p == nil {
+		return nil, nil
+	}
+	variants, err := q.ListCampaignVariants(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	return AssignVariant(variants, c.ID, p.UserID), nil
+}
+
+// WithVariant returns the campaign as the variant's drivers see it: the variant's discount and coin reward.
+func WithVariant(c generated.Campaign, v *generated.CampaignVariant) generated.Campaign {
+	if v != nil {
+		c.DiscountType = v.DiscountType
+		c.DiscountValue = v.DiscountValue
+		c.CoinReward = v.CoinReward
+	}
+	return c
+}
+
+// RecordExposures notes that the profile was shown the variants of the given offers.
+// Repeat exposures are ignored, so only the first one counts.
+func RecordExposures(ctx context.Context, q *generated.Queries, offers []Offer, p *Profile) error {
+	if p == nil {
+		return nil
+	}
+	for _, o := range offers {
+		if o.Variant == nil {
+			continue
+		}
+		if err := q.RecordCampaignExposure(ctx, generated.RecordCampaignExposureParams{
+			VariantID: o.Variant.ID,
+			UserID:    p.UserID,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package pricing
+
+import (
+	"math"
+)
+
+const (
+	// Green window is 23:00–06:00.
+	greenStart = 23
+	greenEnd   = 6
+
+	// greenPriceFactor applies the 20% green-hour discount.
+	greenPriceFactor = 0.8
+
+	baseCoins  = int32(10)
+	greenCoins = int32(50)
+)
+
+// Quote is the price and coin reward for a single charging slot.
+type Quote struct {
+	Price float64
+	Coins int32
+	// Applied lists the campaigns that contributed, in application order.
+	Applied []AppliedCampaign
+}
+
+// IsGreenHour returns true if the hour falls in the green window (23:00–06:00).
+func IsGreenHour(hour int32) bool {
+	return hour >= greenStart || hour <= greenEnd
+}
+
+// BaseCoins returns the coins a slot earns before any campaign bonus.
+func BaseCoins(green bool) int32 {
+	if green {
+		return greenCoins
+	}
+	return baseCoins
+}
+
+// QuoteSlot prices a slot: green-hour discount first, then each campaign's discount and coin reward
+// in order (see ResolveCampaigns). When campaigns are stacked, their combined discount is capped at
+// maxCombinedDiscount (0–1) of the green-adjusted price; the cap trims the last-applied campaigns first.
+// Price is per kWh and never goes below zero.
+func QuoteSlot(basePrice float64, green bool, offers []Offer, maxCombinedDiscount float64) Quote {
+	price := basePrice
+	base := BaseCoins(green)
+	if green {
+		price *= greenPriceFactor
+	}
+	start := price
+
+	coins := base
+	applied := make([]AppliedCampaign, 0, len(offers))
+	for _, o := range offers {
+		c := o.Campaign
+		// Coin multipliers apply to the base reward, so stacked campaigns don't compound
+		discounted, multiplied := CampaignDiscount(c).apply(price, base)
+		bonus := multiplied - base
+		if c.CoinReward > 0 {
+			bonus += c.CoinReward
+		}
+		applied = append(applied, AppliedCampaign{Campaign: c, Variant: o.Variant, Discount: price - discounted, CoinBonus: bonus})
+		price = discounted
+		coins += bonus
+	}
+
+	if len(applied) > 1 {
+		floor := start * (1 - maxCombinedDiscount)
+		if excess := floor - price; excess > 0 {
+			price = floor
+			for i := len(applied) - 1; i >= 0 && excess > 0; i-- {
+				cut := math.Min(excess, applied[i].Discount)
+				applied[i].Discount -= cut
+				excess -= cut
+			}
+		}
+	}
+
+	for i := range applied {
+		applied[i].Discount = RoundTo2(applied[i].Discount)
+	}
+	return Quote{Price: RoundTo2(price), Coins: coins, Applied: applied}
+}
+
+// RoundTo2 rounds a float to 2 decimal places.
+func RoundTo2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,150 @@
+package pricing
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // campaign time zones must resolve even on hosts without zoneinfo
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"smartcharge-api/db/generated"
+)
+
+// DefaultTimezone is the zone campaign windows are evaluated in when none is given.
+// Keep in sync with the column default of campaigns.
+const DefaultTimezone = "Europe/Istanbul"
+
+// Schedule is when a campaign applies: between its start and end dates and,
+// if it has any windows, only during one of them.
+type Schedule struct {
+	Start    pgtype.Timestamptz
+	End      pgtype.Timestamptz
+	Location *time.Location
+	Windows  []generated.CampaignWindow
+}
+
+// SlotLocation is the zone charging slots are laid out in, whatever zone the server runs in:
+// the slot labelled "14:00" starts at 14:00 here. Set it with SetSlotLocation at startup.
+var SlotLocation, _ = LoadLocation(DefaultTimezone)
+
+// SetSlotLocation sets SlotLocation from a time zone name, defaulting to DefaultTimezone.
+func SetSlotLocation(name string) error {
+	loc, err := LoadLocation(name)
+	if err != nil {
+		return err
+	}
+	SlotLocation = loc
+	return nil
+}
+
+// LoadLocation resolves a campaign time zone, defaulting to DefaultTimezone.
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		name = DefaultTimezone
+	}
+	return time.LoadLocation(name)
+}
+
+// CampaignSchedule builds the schedule of a campaign from its row and windows.
+// An unknown stored time zone falls back to DefaultTimezone.
+func CampaignSchedule(c generated.Campaign, windows []generated.CampaignWindow) Schedule {
+	loc, err := LoadLocation(c.Timezone)
+	if err != nil {
+		loc, _ = LoadLocation(DefaultTimezone)
+	}
+	return Schedule{Start: c.StartDate, End: c.EndDate, Location: loc, Windows: windows}
+}
+
+// ActiveAt reports whether the campaign applies at t.
+func (s Schedule) ActiveAt(t time.Time) bool {
+	if s.Start.Valid && t.Before(s.Start.Time) {
+		return false
+	}
+	if s.End.Valid && t.After(s.End.Time) {
+		return false
+	}
+	if len(s.Windows) == 0 {
+		return true
+	}
+
+	local := t.In(s.Location)
+	for _, w := range s.Windows {
+		if windowContains(w, local) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateWindow checks a weekly window: days are 0=Monday … 6=Sunday, hours are
+// start 0–23 and exclusive end 1–24. An end before the start runs past midnight.
+func ValidateWindow(days []int32, startHour, endHour int32) error {
+	for _, d := range days {
+		if d < 0 || d > 6 {
+			return fmt.Errorf("window days must be between 0 (Monday) and 6 (Sunday)")
+		}
+	}
+	if startHour < 0 || startHour > 23 {
+		return fmt.Errorf("window startHour must be between 0 and 23")
+	}
+	if endHour < 1 || endHour > 24 {
+		return fmt.Errorf("window endHour must be between 1 and 24")
+	}
+	if startHour == endHour {
+		return fmt.Errorf("window startHour and endHour cannot be equal")
+	}
+	return nil
+}
+
+// SlotHour parses the hour of an "HH:MM" hour label. It reports false if the label isn't an hour of the day.
+func SlotHour(hourLabel string) (int32, bool) {
+	h, err := strconv.Atoi(strings.SplitN(hourLabel, ":", 2)[0])
+	if err != nil || h < 0 || h > 23 {
+		return 0, false
+	}
+	return int32(h), true
+}
+
+// SlotStart combines a booking date with an "HH:MM" hour label, in SlotLocation.
+func SlotStart(date time.Time, hourLabel string) time.Time {
+	day := date.In(SlotLocation)
+	hour, _ := SlotHour(hourLabel)
+	return time.Date(day.Year(), day.Month(), day.Day(), int(hour), 0, 0, 0, SlotLocation)
+}
+
+// windowContains checks t (already in the campaign's zone) against a window.
+// A window past midnight belongs to the day it starts on, so its early hours match the following day.
+func windowContains(w generated.CampaignWindow, t time.Time) bool {
+	day := weekday(t)
+	hour := int32(t.Hour())
+
+	if w.StartHour < w.EndHour {
+		return hour >= w.StartHour && hour < w.EndHour && onDay(w.Days, day)
+	}
+	if hour >= w.StartHour {
+		return onDay(w.Days, day)
+	}
+	if hour < w.EndHour {
+		return onDay(w.Days, (day+6)%7)
+	}
+	return false
+}
+
+// weekday returns t's day of week with Monday as 0, the convention used across the database.
+func weekday(t time.Time) int32 {
+	return int32((int(t.Weekday()) + 6) % 7)
+}
+
+func onDay(days []int32, day int32) bool {
+	if len(days) == 0 {
+		return true
+	}
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"smartcharge-api/db/generated"
+)
+
+// DefaultMaxCombinedDiscount caps the price discount of stacked campaigns for operators
+// who haven't configured one. Keep in sync with the column default of operator_settings.
+const DefaultMaxCombinedDiscount = 0.4
+
+// AppliedCampaign is one campaign's contribution to a slot quote.
+type AppliedCampaign struct {
+	Campaign generated.Campaign
+	// Variant is the experiment variant the campaign was priced with, if it runs one.
+	Variant *generated.CampaignVariant
+	// Discount is the amount (per kWh) the campaign took off the price, after the combined cap.
+	Discount float64
+	// CoinBonus is the coins the campaign added on top of the slot's base reward.
+	CoinBonus int32
+}
+
+// LoadMaxCombinedDiscount returns the stacking cap of a station's operator, falling back to the default.
+func LoadMaxCombinedDiscount(ctx context.Context, q *generated.Queries, operatorID pgtype.Int4) (float64, error) {
+	if !operatorID.Valid {
+		return DefaultMaxCombinedDiscount, nil
+	}
+
+	settings, err := q.GetOperatorSettings(ctx, operatorID.Int32)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return DefaultMaxCombinedDiscount, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return settings.MaxCombinedDiscount, nil
+}
+
+// RankCampaigns orders campaigns the way they are applied: highest priority first,
+// station-specific before global, then most recent.
+func RankCampaigns(campaigns []generated.Campaign) {
+	sort.SliceStable(campaigns, func(i, j int) bool {
+		a, b := campaigns[i], campaigns[j]
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		if a.StationID.Valid != b.StationID.Valid {
+			return a.StationID.Valid
+		}
+		return a.CreatedAt.Time.After(b.CreatedAt.Time)
+	})
+}
+
+// Offer is a campaign a user is eligible for, with the schedule that decides when it applies.
+// When the campaign runs an experiment, Campaign already carries the user's variant terms.
+type Offer struct {
+	Campaign generated.Campaign
+	Variant  *generated.CampaignVariant
+	Schedule Schedule
+}
+
+// EligibleOffers returns the campaigns the profile is eligible for, ranked (see RankCampaigns),
+// with their schedules and the profile's experiment variants.
+func EligibleOffers(ctx context.Context, q *generated.Queries, campaigns []generated.Campaign, p *Profile) ([]Offer, error) {
+	ranked := append([]generated.Campaign(nil), campaigns...)
+	RankCampaigns(ranked)
+
+	offers := []Offer{}
+	for _, c := range ranked {
+		targets, err := q.GetCampaignTargetBadges(ctx, c.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !CheckEligibility(c, targets, p).Eligible {
+			continue
+		}
+		windows, err := q.ListCampaignWindows(ctx, c.ID)
+		if err != nil {
+			return nil, err
+		}
+		variant, err := LoadVariant(ctx, q, c, p)
+		if err != nil {
+			return nil, err
+		}
+		offers = append(offers, Offer{
+			Campaign: WithVariant(c, variant),
+			Variant:  variant,
+			Schedule: CampaignSchedule(c, windows),
+		})
+	}
+	return offers, nil
+}
+
+// StackAt returns the campaigns that apply at t, in application order.
+// The top-ranked offer live at t always applies. If it is exclusive or not stackable it applies alone;
+// otherwise every other live offer that is stackable and not exclusive is stacked on top of it.
+func StackAt(offers []Offer, t time.Time) []Offer {
+	applied := []Offer{}
+	for _, o := range offers {
+		c := o.Campaign
+		if len(applied) > 0 && (c.Exclusive || !c.Stackable) {
+			continue
+		}
+		if !o.Schedule.ActiveAt(t) {
+			continue
+		}
+		applied = append(applied, o)
+		if c.Exclusive || !c.Stackable {
+			break
+		}
+	}
+	return applied
+}
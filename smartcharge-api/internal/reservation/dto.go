@@ -7,7 +7,10 @@ type CreateReservationRequest struct {
 	StationID int32  `json:"stationId" binding:"required"`
 	Date      string `json:"date" binding:"required"`
 	Hour      string `json:"hour" binding:"required"`
-	IsGreen   bool   `json:"isGreen"`
+	// IsGreen is accepted for older clients and ignored; the server decides from the hour.
+	IsGreen bool `json:"isGreen"`
+	// RedeemCoins is the number of SmartCoins to apply against the session price.
+	RedeemCoins int32 `json:"redeemCoins"`
 }
 
 // UpdateStatusRequest is the request body for PATCH /v1/reservations/:id.
@@ -19,21 +22,36 @@ type UpdateStatusRequest struct {
 
 // ReservationResponse is the reservation data returned by create/update endpoints.
 type ReservationResponse struct {
-	ID          int32   `json:"id"`
-	UserID      int32   `json:"userId"`
-	StationID   int32   `json:"stationId"`
-	Date        string  `json:"date"`
-	Hour        string  `json:"hour"`
-	IsGreen     bool    `json:"isGreen"`
-	EarnedCoins int32   `json:"earnedCoins"`
-	SavedCo2    float64 `json:"savedCo2"`
-	Status      string  `json:"status"`
+	ID            int32   `json:"id"`
+	UserID        int32   `json:"userId"`
+	StationID     int32   `json:"stationId"`
+	Date          string  `json:"date"`
+	Hour          string  `json:"hour"`
+	IsGreen       bool    `json:"isGreen"`
+	EarnedCoins   int32   `json:"earnedCoins"`
+	SavedCo2      float64 `json:"savedCo2"`
+	Status        string  `json:"status"`
+	CampaignID    *int32  `json:"campaignId"`
+	Price         float64 `json:"price"` // per kWh
+	RedeemedCoins int32   `json:"redeemedCoins"`
+	CoinDiscount  float64 `json:"coinDiscount"`
+	// AmountDue is the session's cost less the redeemed coins.
+	AmountDue float64 `json:"amountDue"`
 }
 
 // CompleteResponse is the response for the complete endpoint.
 type CompleteResponse struct {
-	Reservation ReservationResponse `json:"reservation"`
-	User        UserStatsResponse   `json:"user"`
+	Reservation   ReservationResponse `json:"reservation"`
+	User          UserStatsResponse   `json:"user"`
+	AwardedBadges []AwardedBadge      `json:"awardedBadges"`
+}
+
+// AwardedBadge is a badge newly earned by completing the reservation.
+type AwardedBadge struct {
+	ID          int32  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Icon        string `json:"icon"`
 }
 
 // UserStatsResponse is the user stats snapshot returned after completing a reservation.
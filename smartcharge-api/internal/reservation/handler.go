@@ -7,6 +7,7 @@ import (
 
 	apperrors "smartcharge-api/internal/errors"
 	"smartcharge-api/internal/middleware"
+	"smartcharge-api/internal/policy"
 	"smartcharge-api/internal/response"
 )
 
@@ -53,6 +54,11 @@ func (h *Handler) Create(c *gin.Context) {
 
 // UpdateStatus handles PATCH /v1/reservations/:id.
 func (h *Handler) UpdateStatus(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
 	id, err := parseID(c)
 	if err != nil {
 		return
@@ -64,7 +70,7 @@ func (h *Handler) UpdateStatus(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.UpdateStatus(c.Request.Context(), id, req); err != nil {
+	if err := h.service.UpdateStatus(c.Request.Context(), actor, id, req); err != nil {
 		handleError(c, err)
 		return
 	}
@@ -73,12 +79,17 @@ func (h *Handler) UpdateStatus(c *gin.Context) {
 
 // Complete handles POST /v1/reservations/:id/complete.
 func (h *Handler) Complete(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
 	id, err := parseID(c)
 	if err != nil {
 		return
 	}
 
-	result, err := h.service.Complete(c.Request.Context(), id)
+	result, err := h.service.Complete(c.Request.Context(), actor, id)
 	if err != nil {
 		handleError(c, err)
 		return
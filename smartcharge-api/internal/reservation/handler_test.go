@@ -0,0 +1,70 @@
+package reservation
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"smartcharge-api/db/generated"
+	"smartcharge-api/internal/apitest"
+	"smartcharge-api/internal/badge"
+)
+
+func TestMutatingRoutesAuthorization(t *testing.T) {
+	booking := CreateReservationRequest{
+		StationID: 5,
+		Date:      time.Now().AddDate(0, 0, 1).Format("2006-01-02"),
+		Hour:      "14:00",
+	}
+
+	tests := []struct {
+		name  string
+		route apitest.Route
+		write string         // the query that makes the change
+		want  map[string]int // status by apitest.Actors name; the reservation is the driver's, at the owning operator's station
+	}{
+		{
+			name:  "create",
+			route: apitest.Route{Method: http.MethodPost, Path: "/v1/reservations", Body: booking},
+			write: "CreateReservation",
+			want:  map[string]int{"driver": 201, "other driver": 201, "owning operator": 201, "other operator": 201, "admin": 201},
+		},
+		{
+			name:  "cancel",
+			route: apitest.Route{Method: http.MethodPatch, Path: "/v1/reservations/7", Body: UpdateStatusRequest{Status: "CANCELLED"}},
+			write: "CancelReservation",
+			want:  map[string]int{"driver": 200, "other driver": 403, "owning operator": 403, "other operator": 403, "admin": 200},
+		},
+		{
+			name:  "complete",
+			route: apitest.Route{Method: http.MethodPost, Path: "/v1/reservations/7/complete"},
+			write: "CompleteReservation",
+			want:  map[string]int{"driver": 200, "other driver": 403, "owning operator": 403, "other operator": 403, "admin": 200},
+		},
+	}
+
+	for _, tt := range tests {
+		for name, want := range tt.want {
+			actor := apitest.Actors[name]
+			t.Run(tt.name+"/"+name, func(t *testing.T) {
+				db := apitest.NewDB().
+					On("GetStationByID", generated.Station{ID: 5, Price: 7.5, OwnerID: pgtype.Int4{Int32: apitest.Owner.UserID, Valid: true}}).
+					On("GetReservationByID", generated.Reservation{ID: 7, UserID: apitest.Driver.UserID, StationID: 5, Status: "CONFIRMED", Hour: "14:00"})
+				queries := generated.New(db)
+				h := NewHandler(&Service{queries: queries, pool: db, badges: badge.NewEngine(queries)})
+				r := apitest.Router(actor, func(v1 *gin.RouterGroup, auth gin.HandlerFunc) { h.RegisterRoutes(v1, auth) })
+
+				w := apitest.Do(r, tt.route)
+				if w.Code != want {
+					t.Fatalf("status = %d, want %d: %s", w.Code, want, w.Body)
+				}
+				if wrote := db.Called(tt.write); wrote != (want != http.StatusForbidden) {
+					t.Errorf("%s called = %v with status %d", tt.write, wrote, w.Code)
+				}
+			})
+		}
+	}
+}
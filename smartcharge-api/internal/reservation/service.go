@@ -2,6 +2,9 @@ package reservation
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -9,21 +12,39 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"smartcharge-api/db/generated"
+	"smartcharge-api/internal/badge"
 	apperrors "smartcharge-api/internal/errors"
+	"smartcharge-api/internal/notification"
+	"smartcharge-api/internal/policy"
+	"smartcharge-api/internal/pricing"
+	"smartcharge-api/internal/wallet"
+)
+
+const (
+	// completionXP is the XP awarded for every completed reservation.
+	completionXP = int32(100)
 )
 
 // Service handles reservation business logic.
 type Service struct {
 	queries *generated.Queries
-	pool    *pgxpool.Pool
+	pool    txBeginner
+	badges  *badge.Engine
+}
+
+// txBeginner starts the transactions the service writes in; *pgxpool.Pool implements it.
+type txBeginner interface {
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
 }
 
 // NewService creates a new reservation service.
-func NewService(queries *generated.Queries, pool *pgxpool.Pool) *Service {
-	return &Service{queries: queries, pool: pool}
+func NewService(queries *generated.Queries, pool *pgxpool.Pool, badges *badge.Engine) *Service {
+	return &Service{queries: queries, pool: pool, badges: badges}
 }
 
-// Create creates a new reservation with campaign coin bonus applied.
+// Create creates a new reservation with the stacked campaign discounts and coin bonuses applied.
+// A campaign whose budget can't cover the booking is left out of the price rather than failing it.
+// If the driver asks to redeem coins, they are debited atomically with the booking.
 func (s *Service) Create(ctx context.Context, userID int32, req CreateReservationRequest) (*ReservationResponse, error) {
 	// Parse date
 	reservationDate, err := time.Parse(time.RFC3339, req.Date)
@@ -35,79 +56,279 @@ func (s *Service) Create(ctx context.Context, userID int32, req CreateReservatio
 		}
 	}
 
-	// Check for active campaigns to apply bonus coins
-	campaigns, err := s.queries.GetActiveCampaignsForStation(ctx, pgtype.Int4{Int32: req.StationID, Valid: true})
+	hour, ok := pricing.SlotHour(req.Hour)
+	if !ok {
+		return nil, apperrors.NewValidationError("Invalid hour format")
+	}
+	// Whether the slot is green is the server's call, never the client's
+	green := pricing.IsGreenHour(hour)
+
+	if req.RedeemCoins < 0 {
+		return nil, apperrors.NewValidationError("redeemCoins cannot be negative")
+	}
+
+	station, err := s.queries.GetStationByID(ctx, req.StationID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("Station")
+	}
+
+	// Check for campaigns running when the session starts to apply bonus coins
+	slotStart := pricing.SlotStart(reservationDate, req.Hour)
+	campaigns, err := s.queries.GetActiveCampaignsForStation(ctx, generated.GetActiveCampaignsForStationParams{
+		WindowStart: pgtype.Timestamptz{Time: slotStart, Valid: true},
+		WindowEnd:   pgtype.Timestamptz{Time: slotStart.Add(time.Hour), Valid: true},
+		StationID:   pgtype.Int4{Int32: req.StationID, Valid: true},
+	})
 	if err != nil {
 		campaigns = []generated.Campaign{}
 	}
 
-	earnedCoins := int32(10)
-	if req.IsGreen {
-		earnedCoins = 50
+	profile, err := pricing.LoadProfile(ctx, s.queries, userID)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	// Price and coins follow the same rules as the station timeslots,
+	// stacking the campaigns the driver is eligible for that are live when the session starts
+	offers, err := pricing.EligibleOffers(ctx, s.queries, campaigns, profile)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	maxCombined, err := pricing.LoadMaxCombinedDiscount(ctx, s.queries, station.OwnerID)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.queries.WithTx(tx)
+
+	// Charge each applied campaign's budget with the booking. A campaign that can no longer cover it
+	// is dropped and the slot re-priced without it; one that runs out pauses itself.
+	var (
+		applied []pricing.Offer
+		quote   pricing.Quote
+		paused  []generated.Campaign
+	)
+	for {
+		applied = pricing.StackAt(offers, slotStart)
+		quote = pricing.QuoteSlot(station.Price, green, applied, maxCombined)
+		short, used, err := s.redeemCampaigns(ctx, tx, userID, quote.Applied)
+		if err != nil {
+			return nil, walletError(err)
+		}
+		if short == nil {
+			paused = append(paused, used...)
+			break
+		}
+		c, stopped, err := pricing.PauseShort(ctx, qtx, *short)
+		if err != nil {
+			return nil, apperrors.ErrInternal
+		}
+		if stopped {
+			paused = append(paused, c)
+		}
+		offers = withoutCampaign(offers, short.Campaign.ID)
+	}
+
+	// The reservation keeps the top-ranked campaign; every applied one is recorded below
+	var campaignID pgtype.Int4
+	if len(applied) > 0 {
+		campaignID = pgtype.Int4{Int32: applied[0].Campaign.ID, Valid: true}
 	}
 
-	// Apply campaign coin reward from the most recent active campaign
-	if len(campaigns) > 0 && campaigns[0].CoinReward > 0 {
-		earnedCoins += campaigns[0].CoinReward
+	// Apply redeemed coins within the operator's policy; the cap and the discount are on the whole session
+	sessionAmount := pricing.SessionAmount(quote.Price)
+	var coinDiscount float64
+	if req.RedeemCoins > 0 {
+		policy, err := pricing.LoadCoinPolicy(ctx, s.queries, station.OwnerID)
+		if err != nil {
+			return nil, apperrors.ErrInternal
+		}
+		if limit := policy.MaxRedeemable(sessionAmount); req.RedeemCoins > limit {
+			return nil, apperrors.NewValidationError(fmt.Sprintf("At most %d coins can be redeemed for this session", limit))
+		}
+		coinDiscount = policy.Discount(req.RedeemCoins)
 	}
 
-	reservation, err := s.queries.CreateReservation(ctx, generated.CreateReservationParams{
+	reservation, err := qtx.CreateReservation(ctx, generated.CreateReservationParams{
 		UserID:    userID,
 		StationID: req.StationID,
 		Date: pgtype.Timestamptz{
 			Time:  reservationDate,
 			Valid: true,
 		},
-		Hour:        req.Hour,
-		IsGreen:     req.IsGreen,
-		EarnedCoins: earnedCoins,
+		Hour:          req.Hour,
+		IsGreen:       green,
+		EarnedCoins:   quote.Coins,
+		CampaignID:    campaignID,
+		Price:         quote.Price,
+		RedeemedCoins: req.RedeemCoins,
+		CoinDiscount:  coinDiscount,
+		SessionAmount: sessionAmount,
 	})
 	if err != nil {
 		return nil, apperrors.ErrInternal
 	}
 
+	for i, a := range quote.Applied {
+		if err := qtx.AddReservationCampaign(ctx, generated.AddReservationCampaignParams{
+			ReservationID: reservation.ID,
+			CampaignID:    a.Campaign.ID,
+			Position:      int32(i),
+			Discount:      a.Discount,
+			CoinBonus:     a.CoinBonus,
+			VariantID:     variantID(a.Variant),
+		}); err != nil {
+			return nil, apperrors.ErrInternal
+		}
+	}
+	for _, c := range paused {
+		if err := notification.Send(ctx, qtx, notification.Message{
+			UserID:    c.OwnerID,
+			Type:      notification.TypeCampaignPaused,
+			Title:     "Kampanya bütçesi doldu",
+			Body:      fmt.Sprintf("%q kampanyası limitine ulaştığı için duraklatıldı.", c.Title),
+			DedupeKey: fmt.Sprintf("campaign-paused:%d:%d", c.ID, c.Redemptions),
+		}); err != nil {
+			return nil, apperrors.ErrInternal
+		}
+	}
+
+	// A driver may book without having opened the station page, so the booking counts as an exposure too
+	if err := pricing.RecordExposures(ctx, qtx, applied, profile); err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	if req.RedeemCoins > 0 {
+		rid := reservation.ID
+		if _, _, err := wallet.Post(ctx, qtx, wallet.Entry{
+			UserID:        userID,
+			Type:          wallet.TypeRedeem,
+			Coins:         -req.RedeemCoins,
+			ReservationID: &rid,
+			Description:   "Redeemed at checkout",
+		}); err != nil {
+			return nil, walletError(err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
 	return reservationToResponse(reservation), nil
 }
 
-// UpdateStatus updates a reservation's status (e.g. CANCELLED).
-func (s *Service) UpdateStatus(ctx context.Context, reservationID int32, req UpdateStatusRequest) error {
-	// Verify reservation exists
+// UpdateStatus updates a reservation's status. The only change a client can make is cancelling
+// a PENDING or CONFIRMED booking; completion goes through Complete.
+// Cancelling refunds redeemed coins according to the operator's refund policy
+// and releases the reservation's share of its campaigns' budgets.
+func (s *Service) UpdateStatus(ctx context.Context, actor policy.Actor, reservationID int32, req UpdateStatusRequest) error {
+	if req.Status != "CANCELLED" {
+		return apperrors.NewValidationError("status can only be changed to CANCELLED")
+	}
+
 	existing, err := s.queries.GetReservationByID(ctx, reservationID)
 	if err != nil {
 		return apperrors.NewNotFoundError("Reservation")
 	}
+	if err := policy.RequireOwner(actor, existing.UserID); err != nil {
+		return err
+	}
 
 	// Don't allow updating already completed reservations
 	if existing.Status == "COMPLETED" {
 		return apperrors.ErrAlreadyCompleted
 	}
+	if existing.Status == "CANCELLED" {
+		return apperrors.NewConflictError("Reservation is already cancelled")
+	}
 
-	_, err = s.queries.UpdateReservationStatus(ctx, generated.UpdateReservationStatusParams{
-		ID:     reservationID,
-		Status: req.Status,
-	})
+	refund := int32(0)
+	if existing.RedeemedCoins > 0 {
+		station, err := s.queries.GetStationByID(ctx, existing.StationID)
+		if err != nil {
+			return apperrors.ErrInternal
+		}
+		policy, err := pricing.LoadCoinPolicy(ctx, s.queries, station.OwnerID)
+		if err != nil {
+			return apperrors.ErrInternal
+		}
+		refund = policy.Refund(existing.RedeemedCoins, reservationStart(existing), time.Now())
+	}
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return apperrors.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.queries.WithTx(tx)
+
+	// The status guard makes the cancellation happen once, so coins and budgets are only given back once
+	_, err = qtx.CancelReservation(ctx, reservationID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apperrors.NewConflictError("Reservation was completed or cancelled by another request")
+	}
+	if err != nil {
+		return apperrors.ErrInternal
+	}
+
+	if refund > 0 {
+		rid := existing.ID
+		if _, _, err := wallet.Post(ctx, qtx, wallet.Entry{
+			UserID:        existing.UserID,
+			Type:          wallet.TypeRefund,
+			Coins:         refund,
+			ReservationID: &rid,
+			Description:   "Refund for cancelled reservation",
+		}); err != nil {
+			return apperrors.ErrInternal
+		}
+	}
+
+	// Cancelled bookings give their share of campaign budgets back
+	applied, err := qtx.ListReservationCampaigns(ctx, existing.ID)
 	if err != nil {
 		return apperrors.ErrInternal
 	}
+	for _, a := range applied {
+		if err := pricing.Release(ctx, qtx, a); err != nil {
+			return apperrors.ErrInternal
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return apperrors.ErrInternal
+	}
 
 	return nil
 }
 
 // Complete atomically completes a reservation and awards the user coins, XP, and CO2.
-func (s *Service) Complete(ctx context.Context, reservationID int32) (*CompleteResponse, error) {
-	// Get reservation
+func (s *Service) Complete(ctx context.Context, actor policy.Actor, reservationID int32) (*CompleteResponse, error) {
 	reservation, err := s.queries.GetReservationByID(ctx, reservationID)
 	if err != nil {
 		return nil, apperrors.NewNotFoundError("Reservation")
 	}
+	if err := policy.RequireOwner(actor, reservation.UserID); err != nil {
+		return nil, err
+	}
 
 	if reservation.Status == "COMPLETED" {
 		return nil, apperrors.ErrAlreadyCompleted
 	}
+	if reservation.Status == "CANCELLED" {
+		return nil, apperrors.NewConflictError("Cancelled reservations cannot be completed")
+	}
 
 	// Use stored reservation values — never allow client override
 	earnedCoins := reservation.EarnedCoins
-	xpDelta := int32(100)
 
 	co2Delta := 0.5
 	if reservation.IsGreen {
@@ -127,17 +348,65 @@ func (s *Service) Complete(ctx context.Context, reservationID int32) (*CompleteR
 	updatedReservation, err := qtx.CompleteReservation(ctx, generated.CompleteReservationParams{
 		ID:          reservationID,
 		EarnedCoins: earnedCoins,
+		SavedCo2:    co2Delta,
 	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// A concurrent request completed or cancelled it since it was read
+		return nil, apperrors.NewConflictError("Reservation was completed or cancelled by another request")
+	}
 	if err != nil {
 		return nil, apperrors.ErrInternal
 	}
 
-	// 2. Update user stats
-	updatedUser, err := qtx.UpdateUserStats(ctx, generated.UpdateUserStatsParams{
+	// 2. Record the earnings in the coin ledger (balances are derived from it).
+	// Each campaign's bonus is posted separately so it stays attributable to its campaign.
+	rid := reservation.ID
+	applied, err := qtx.ListReservationCampaigns(ctx, reservation.ID)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	baseCoins := earnedCoins
+	for _, a := range applied {
+		if a.CoinBonus > 0 {
+			baseCoins -= a.CoinBonus
+		}
+	}
+	if baseCoins < 0 {
+		baseCoins = 0
+	}
+
+	if _, _, err := wallet.Post(ctx, qtx, wallet.Entry{
+		UserID:        reservation.UserID,
+		Type:          wallet.TypeEarn,
+		Coins:         baseCoins,
+		XP:            completionXP,
+		ReservationID: &rid,
+		Description:   "Charging session completed",
+	}); err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	for _, a := range applied {
+		if a.CoinBonus <= 0 {
+			continue
+		}
+		cid := a.CampaignID
+		if _, _, err := wallet.Post(ctx, qtx, wallet.Entry{
+			UserID:        reservation.UserID,
+			Type:          wallet.TypeEarn,
+			Coins:         a.CoinBonus,
+			ReservationID: &rid,
+			CampaignID:    &cid,
+			Description:   "Campaign bonus",
+		}); err != nil {
+			return nil, apperrors.ErrInternal
+		}
+	}
+
+	// 3. Update CO2 savings
+	updatedUser, err := qtx.AddUserCo2Saved(ctx, generated.AddUserCo2SavedParams{
 		ID:       reservation.UserID,
-		Coins:    earnedCoins,
 		Co2Saved: co2Delta,
-		Xp:       xpDelta,
 	})
 	if err != nil {
 		return nil, apperrors.ErrInternal
@@ -148,6 +417,18 @@ func (s *Service) Complete(ctx context.Context, reservationID int32) (*CompleteR
 		return nil, apperrors.ErrInternal
 	}
 
+	// 4. Award any badges this completion unlocked. The completion stands even if this fails;
+	// the nightly backfill picks up missed awards.
+	awarded, err := s.badges.Evaluate(ctx, reservation.UserID)
+	if err != nil {
+		log.Printf("badge evaluation failed for user %d: %v", reservation.UserID, err)
+	}
+
+	awardedBadges := make([]AwardedBadge, len(awarded))
+	for i, b := range awarded {
+		awardedBadges[i] = AwardedBadge{ID: b.ID, Name: b.Name, Description: b.Description, Icon: b.Icon}
+	}
+
 	return &CompleteResponse{
 		Reservation: *reservationToResponse(updatedReservation),
 		User: UserStatsResponse{
@@ -156,26 +437,94 @@ func (s *Service) Complete(ctx context.Context, reservationID int32) (*CompleteR
 			Co2Saved: updatedUser.Co2Saved,
 			XP:       updatedUser.Xp,
 		},
+		AwardedBadges: awardedBadges,
 	}, nil
 }
 
 // --- helpers ---
 
+// reservationStart returns when the reserved session begins (reservation day at the booked hour, in pricing.SlotLocation).
+func reservationStart(r generated.Reservation) time.Time {
+	return pricing.SlotStart(r.Date.Time, r.Hour)
+}
+
+// redeemCampaigns charges the applied campaigns' budgets inside a savepoint of tx. If one of them can't
+// cover its application, nothing is charged and that one is returned as short; otherwise paused lists
+// the campaigns the booking used up.
+func (s *Service) redeemCampaigns(ctx context.Context, tx pgx.Tx, userID int32, applied []pricing.AppliedCampaign) (short *pricing.AppliedCampaign, paused []generated.Campaign, err error) {
+	sp, err := tx.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer sp.Rollback(ctx)
+
+	qsp := s.queries.WithTx(sp)
+	for i, a := range applied {
+		campaign, usedUp, err := pricing.Redeem(ctx, qsp, userID, a)
+		if errors.Is(err, apperrors.ErrCampaignExhausted) {
+			return &applied[i], nil, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if usedUp {
+			paused = append(paused, campaign)
+		}
+	}
+	return nil, paused, sp.Commit(ctx)
+}
+
+// withoutCampaign returns the offers other than the campaign's.
+func withoutCampaign(offers []pricing.Offer, campaignID int32) []pricing.Offer {
+	kept := make([]pricing.Offer, 0, len(offers))
+	for _, o := range offers {
+		if o.Campaign.ID != campaignID {
+			kept = append(kept, o)
+		}
+	}
+	return kept
+}
+
+// variantID returns the experiment variant a campaign was priced with, if any.
+func variantID(v *generated.CampaignVariant) pgtype.Int4 {
+	if v == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: v.ID, Valid: true}
+}
+
+// walletError passes wallet and campaign validation errors (e.g. insufficient coins) through and hides the rest.
+func walletError(err error) error {
+	if appErr, ok := err.(*apperrors.AppError); ok {
+		return appErr
+	}
+	return apperrors.ErrInternal
+}
+
 func reservationToResponse(r generated.Reservation) *ReservationResponse {
 	dateStr := ""
 	if r.Date.Valid {
 		dateStr = r.Date.Time.UTC().Format(time.RFC3339)
 	}
 
-	return &ReservationResponse{
-		ID:          r.ID,
-		UserID:      r.UserID,
-		StationID:   r.StationID,
-		Date:        dateStr,
-		Hour:        r.Hour,
-		IsGreen:     r.IsGreen,
-		EarnedCoins: r.EarnedCoins,
-		SavedCo2:    r.SavedCo2,
-		Status:      r.Status,
+	resp := &ReservationResponse{
+		ID:            r.ID,
+		UserID:        r.UserID,
+		StationID:     r.StationID,
+		Date:          dateStr,
+		Hour:          r.Hour,
+		IsGreen:       r.IsGreen,
+		EarnedCoins:   r.EarnedCoins,
+		SavedCo2:      r.SavedCo2,
+		Status:        r.Status,
+		Price:         r.Price,
+		RedeemedCoins: r.RedeemedCoins,
+		CoinDiscount:  r.CoinDiscount,
+		AmountDue:     pricing.RoundTo2(r.SessionAmount - r.CoinDiscount),
+	}
+	if r.CampaignID.Valid {
+		id := r.CampaignID.Int32
+		resp.CampaignID = &id
 	}
+	return resp
 }
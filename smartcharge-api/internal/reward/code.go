@@ -0,0 +1,33 @@
+package reward
+
+import (
+	"crypto/rand"
+	"strings"
+)
+
+// voucherAlphabet has 32 symbols and leaves out the easily confused 0/O and 1/I.
+const voucherAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// newVoucherCode returns a random code like "SC-7KQ2-MX9D-R4TB".
+// 12 symbols give 60 bits of entropy; the unique index on reward_vouchers.code is the backstop.
+func newVoucherCode() (string, error) {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("SC")
+	for i, b := range buf {
+		if i%4 == 0 {
+			sb.WriteByte('-')
+		}
+		sb.WriteByte(voucherAlphabet[int(b)%len(voucherAlphabet)])
+	}
+	return sb.String(), nil
+}
+
+// normalizeCode makes user-typed codes comparable with issued ones.
+func normalizeCode(raw string) string {
+	return strings.ToUpper(strings.TrimSpace(raw))
+}
@@ -0,0 +1,81 @@
+package reward
+
+// --- Request DTOs ---
+
+// CreateRewardRequest is the request body for POST /v1/company/rewards.
+type CreateRewardRequest struct {
+	Title               string  `json:"title" binding:"required"`
+	Description         string  `json:"description"`
+	Category            string  `json:"category"`
+	PartnerName         *string `json:"partnerName,omitempty"`
+	CostCoins           int32   `json:"costCoins" binding:"required"`
+	Stock               *int32  `json:"stock,omitempty"`
+	PerUserLimit        *int32  `json:"perUserLimit,omitempty"`
+	ValidFrom           *string `json:"validFrom,omitempty"`
+	ValidUntil          *string `json:"validUntil,omitempty"`
+	VoucherValidityDays *int32  `json:"voucherValidityDays,omitempty"`
+	IsActive            *bool   `json:"isActive,omitempty"`
+}
+
+// UpdateRewardRequest is the request body for PUT /v1/company/rewards/:id.
+// Omitted fields keep their current value.
+type UpdateRewardRequest struct {
+	Title               *string `json:"title,omitempty"`
+	Description         *string `json:"description,omitempty"`
+	Category            *string `json:"category,omitempty"`
+	PartnerName         *string `json:"partnerName,omitempty"`
+	CostCoins           *int32  `json:"costCoins,omitempty"`
+	Stock               *int32  `json:"stock,omitempty"`
+	PerUserLimit        *int32  `json:"perUserLimit,omitempty"`
+	ValidFrom           *string `json:"validFrom,omitempty"`
+	ValidUntil          *string `json:"validUntil,omitempty"`
+	VoucherValidityDays *int32  `json:"voucherValidityDays,omitempty"`
+	IsActive            *bool   `json:"isActive,omitempty"`
+}
+
+// --- Response DTOs ---
+
+// RewardResponse is a single catalog entry.
+type RewardResponse struct {
+	ID                  int32   `json:"id"`
+	OperatorID          int32   `json:"operatorId"`
+	Title               string  `json:"title"`
+	Description         string  `json:"description"`
+	Category            string  `json:"category"`
+	PartnerName         *string `json:"partnerName"`
+	CostCoins           int32   `json:"costCoins"`
+	Stock               *int32  `json:"stock"`
+	PerUserLimit        *int32  `json:"perUserLimit"`
+	ValidFrom           string  `json:"validFrom"`
+	ValidUntil          *string `json:"validUntil"`
+	VoucherValidityDays int32   `json:"voucherValidityDays"`
+	IsActive            bool    `json:"isActive"`
+}
+
+// VoucherResponse is an issued voucher.
+type VoucherResponse struct {
+	ID          int32   `json:"id"`
+	Code        string  `json:"code"`
+	RewardID    int32   `json:"rewardId"`
+	RewardTitle string  `json:"rewardTitle"`
+	Category    string  `json:"category"`
+	PartnerName *string `json:"partnerName"`
+	Coins       int32   `json:"coins"`
+	Status      string  `json:"status"`
+	ExpiresAt   string  `json:"expiresAt"`
+	ConsumedAt  *string `json:"consumedAt"`
+	CreatedAt   string  `json:"createdAt"`
+}
+
+// RedeemResponse is the response for POST /v1/rewards/:id/redeem.
+type RedeemResponse struct {
+	Voucher VoucherResponse `json:"voucher"`
+	Coins   int32           `json:"coins"`
+}
+
+// ValidateVoucherResponse is the response for POST /v1/company/vouchers/:code/validate.
+type ValidateVoucherResponse struct {
+	Voucher VoucherResponse `json:"voucher"`
+	Valid   bool            `json:"valid"`
+	Reason  string          `json:"reason,omitempty"`
+}
@@ -0,0 +1,211 @@
+package reward
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	apperrors "smartcharge-api/internal/errors"
+	"smartcharge-api/internal/middleware"
+	"smartcharge-api/internal/policy"
+	"smartcharge-api/internal/response"
+)
+
+// Handler handles HTTP requests for rewards and vouchers.
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new reward handler.
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers reward routes on the given router group.
+func (h *Handler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	// Driver-facing catalog and vouchers
+	rewards := rg.Group("/rewards", authMiddleware)
+	rewards.GET("", h.ListAvailable)
+	rewards.POST("/:id/redeem", h.Redeem)
+
+	vouchers := rg.Group("/users/:id/vouchers", authMiddleware)
+	vouchers.GET("", h.ListVouchers)
+
+	// Operator catalog management and counter-side voucher checks
+	company := rg.Group("/company", authMiddleware)
+	company.GET("/rewards", h.ListMyRewards)
+	company.POST("/rewards", h.Create)
+	company.PUT("/rewards/:id", h.Update)
+	company.POST("/vouchers/:code/validate", h.ValidateVoucher)
+	company.POST("/vouchers/:code/consume", h.ConsumeVoucher)
+}
+
+// ListAvailable handles GET /v1/rewards.
+func (h *Handler) ListAvailable(c *gin.Context) {
+	result, err := h.service.ListAvailable(c.Request.Context())
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// Redeem handles POST /v1/rewards/:id/redeem.
+func (h *Handler) Redeem(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	id, err := parseID(c, "Invalid reward ID")
+	if err != nil {
+		return
+	}
+
+	result, err := h.service.Redeem(c.Request.Context(), userID, id)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.Created(c, result)
+}
+
+// ListVouchers handles GET /v1/users/:id/vouchers.
+func (h *Handler) ListVouchers(c *gin.Context) {
+	id, err := parseID(c, "Invalid user ID")
+	if err != nil {
+		return
+	}
+
+	// Users can only see their own vouchers
+	userID, ok := middleware.GetUserID(c)
+	if !ok || userID != id {
+		response.Err(c, 403, "AUTH_FORBIDDEN", "You can only view your own vouchers")
+		return
+	}
+
+	result, err := h.service.ListVouchers(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// ListMyRewards handles GET /v1/company/rewards.
+func (h *Handler) ListMyRewards(c *gin.Context) {
+	operatorID, ok := middleware.GetUserID(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	result, err := h.service.ListByOperator(c.Request.Context(), operatorID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// Create handles POST /v1/company/rewards.
+func (h *Handler) Create(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	var req CreateRewardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "title and costCoins are required")
+		return
+	}
+
+	result, err := h.service.Create(c.Request.Context(), actor, req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.Created(c, result)
+}
+
+// Update handles PUT /v1/company/rewards/:id.
+func (h *Handler) Update(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	id, err := parseID(c, "Invalid reward ID")
+	if err != nil {
+		return
+	}
+
+	var req UpdateRewardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "Invalid request body")
+		return
+	}
+
+	result, err := h.service.Update(c.Request.Context(), actor, id, req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// ValidateVoucher handles POST /v1/company/vouchers/:code/validate.
+func (h *Handler) ValidateVoucher(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	result, err := h.service.ValidateVoucher(c.Request.Context(), actor, c.Param("code"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// ConsumeVoucher handles POST /v1/company/vouchers/:code/consume.
+func (h *Handler) ConsumeVoucher(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	result, err := h.service.ConsumeVoucher(c.Request.Context(), actor, c.Param("code"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// --- helpers ---
+
+func parseID(c *gin.Context, msg string) (int32, error) {
+	raw := c.Param("id")
+	val, err := strconv.Atoi(raw)
+	if err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", msg)
+		return 0, err
+	}
+	return int32(val), nil
+}
+
+func handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*apperrors.AppError); ok {
+		response.Err(c, appErr.StatusCode, appErr.Code, appErr.Message)
+		return
+	}
+	response.Err(c, 500, "INTERNAL_ERROR", "An unexpected error occurred")
+}
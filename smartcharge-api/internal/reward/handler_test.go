@@ -0,0 +1,96 @@
+package reward
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"smartcharge-api/db/generated"
+	"smartcharge-api/internal/apitest"
+)
+
+func TestMutatingRoutesAuthorization(t *testing.T) {
+	tests := []struct {
+		name  string
+		route apitest.Route
+		write string         // the query that makes the change, if any
+		want  map[string]int // status by apitest.Actors name
+	}{
+		{
+			name:  "redeem",
+			route: apitest.Route{Method: http.MethodPost, Path: "/v1/rewards/8/redeem"},
+			write: "CreateRewardVoucher",
+			want:  map[string]int{"driver": 201, "owning operator": 201, "other operator": 201, "admin": 201},
+		},
+		{
+			name:  "create",
+			route: apitest.Route{Method: http.MethodPost, Path: "/v1/company/rewards", Body: CreateRewardRequest{Title: "Bedava kahve", CostCoins: 100}},
+			write: "CreateReward",
+			want:  map[string]int{"driver": 403, "owning operator": 201, "other operator": 201, "admin": 201},
+		},
+		{
+			name:  "update",
+			route: apitest.Route{Method: http.MethodPut, Path: "/v1/company/rewards/8", Body: map[string]any{"title": "Bedava çay"}},
+			write: "UpdateReward",
+			want:  map[string]int{"driver": 403, "owning operator": 200, "other operator": 403, "admin": 200},
+		},
+		{
+			name:  "validate voucher",
+			route: apitest.Route{Method: http.MethodPost, Path: "/v1/company/vouchers/ABCD-1234/validate"},
+			want:  map[string]int{"driver": 403, "owning operator": 200, "other operator": 403, "admin": 200},
+		},
+		{
+			name:  "consume voucher",
+			route: apitest.Route{Method: http.MethodPost, Path: "/v1/company/vouchers/ABCD-1234/consume"},
+			write: "ConsumeVoucher",
+			want:  map[string]int{"driver": 403, "owning operator": 200, "other operator": 403, "admin": 200},
+		},
+	}
+
+	reward := generated.Reward{
+		ID:                  8,
+		OperatorID:          apitest.Owner.UserID,
+		Category:            "OTHER",
+		CostCoins:           100,
+		VoucherValidityDays: 30,
+		IsActive:            true,
+	}
+	voucher := generated.RewardVoucher{
+		ID:        9,
+		RewardID:  reward.ID,
+		UserID:    apitest.Driver.UserID,
+		Code:      "ABCD1234",
+		Status:    StatusIssued,
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(24 * time.Hour), Valid: true},
+	}
+
+	for _, tt := range tests {
+		for name, want := range tt.want {
+			actor := apitest.Actors[name]
+			t.Run(tt.name+"/"+name, func(t *testing.T) {
+				db := apitest.NewDB().
+					On("GetRewardByID", reward).
+					On("GetRewardForUpdate", reward).
+					On("GetVoucherByCode", voucher).
+					On("GetVoucherByCodeForUpdate", voucher).
+					On("GetUserForUpdate", generated.User{ID: actor.UserID, Coins: 1000})
+				h := NewHandler(&Service{queries: generated.New(db), pool: db})
+				r := apitest.Router(actor, func(v1 *gin.RouterGroup, auth gin.HandlerFunc) { h.RegisterRoutes(v1, auth) })
+
+				w := apitest.Do(r, tt.route)
+				if w.Code != want {
+					t.Fatalf("status = %d, want %d: %s", w.Code, want, w.Body)
+				}
+				if tt.write == "" {
+					return
+				}
+				if wrote := db.Called(tt.write); wrote != (want != http.StatusForbidden) {
+					t.Errorf("%s called = %v with status %d", tt.write, wrote, w.Code)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,513 @@
+package reward
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"smartcharge-api/db/generated"
+	apperrors "smartcharge-api/internal/errors"
+	"smartcharge-api/internal/policy"
+	"smartcharge-api/internal/wallet"
+)
+
+// Voucher statuses. EXPIRED is derived from expires_at and never stored.
+const (
+	StatusIssued   = "ISSUED"
+	StatusConsumed = "CONSUMED"
+	StatusExpired  = "EXPIRED"
+)
+
+// validCategories are the accepted reward categories.
+var validCategories = map[string]bool{
+	"FREE_SESSION": true,
+	"PARTNER":      true,
+	"MERCH":        true,
+	"OTHER":        true,
+}
+
+// Service handles the rewards catalog and voucher lifecycle.
+type Service struct {
+	queries *generated.Queries
+	pool    txBeginner
+}
+
+// txBeginner starts the transactions the service writes in; *pgxpool.Pool implements it.
+type txBeginner interface {
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+}
+
+// NewService creates a new reward service.
+func NewService(queries *generated.Queries, pool *pgxpool.Pool) *Service {
+	return &Service{queries: queries, pool: pool}
+}
+
+// ListAvailable returns rewards that are active, within their validity window and in stock.
+func (s *Service) ListAvailable(ctx context.Context) ([]RewardResponse, error) {
+	rows, err := s.queries.ListAvailableRewards(ctx)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	items := make([]RewardResponse, len(rows))
+	for i, r := range rows {
+		items[i] = rewardToResponse(r)
+	}
+	return items, nil
+}
+
+// Redeem buys a reward with coins and issues a voucher.
+// Stock, per-user limit and the coin debit are checked and applied in one transaction.
+func (s *Service) Redeem(ctx context.Context, userID, rewardID int32) (*RedeemResponse, error) {
+	code, err := newVoucherCode()
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.queries.WithTx(tx)
+
+	// Lock the reward so concurrent redemptions can't oversell stock
+	reward, err := qtx.GetRewardForUpdate(ctx, rewardID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("Reward")
+	}
+
+	now := time.Now()
+	if !reward.IsActive || (reward.ValidFrom.Valid && now.Before(reward.ValidFrom.Time)) ||
+		(reward.ValidUntil.Valid && !now.Before(reward.ValidUntil.Time)) {
+		return nil, apperrors.NewValidationError("Reward is not available")
+	}
+	if reward.Stock.Valid && reward.Stock.Int32 <= 0 {
+		return nil, apperrors.NewConflictError("Reward is out of stock")
+	}
+
+	if reward.PerUserLimit.Valid {
+		count, err := qtx.CountUserVouchersForReward(ctx, generated.CountUserVouchersForRewardParams{
+			RewardID: rewardID,
+			UserID:   userID,
+		})
+		if err != nil {
+			return nil, apperrors.ErrInternal
+		}
+		if count >= reward.PerUserLimit.Int32 {
+			return nil, apperrors.NewConflictError(fmt.Sprintf("You can redeem this reward at most %d times", reward.PerUserLimit.Int32))
+		}
+	}
+
+	_, user, err := wallet.Post(ctx, qtx, wallet.Entry{
+		UserID:      userID,
+		Type:        wallet.TypeRedeem,
+		Coins:       -reward.CostCoins,
+		Description: "Reward: " + reward.Title,
+	})
+	if err != nil {
+		if appErr, ok := err.(*apperrors.AppError); ok {
+			return nil, appErr
+		}
+		return nil, apperrors.ErrInternal
+	}
+
+	if err := qtx.DecrementRewardStock(ctx, rewardID); err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	voucher, err := qtx.CreateRewardVoucher(ctx, generated.CreateRewardVoucherParams{
+		RewardID:  rewardID,
+		UserID:    userID,
+		Code:      code,
+		Coins:     reward.CostCoins,
+		ExpiresAt: pgtype.Timestamptz{Time: now.AddDate(0, 0, int(reward.VoucherValidityDays)), Valid: true},
+	})
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	return &RedeemResponse{
+		Voucher: voucherToResponse(voucher, reward),
+		Coins:   user.Coins,
+	}, nil
+}
+
+// ListVouchers returns the vouchers issued to a user, newest first.
+func (s *Service) ListVouchers(ctx context.Context, userID int32) ([]VoucherResponse, error) {
+	rows, err := s.queries.ListVouchersByUser(ctx, userID)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	now := time.Now()
+	items := make([]VoucherResponse, len(rows))
+	for i, r := range rows {
+		item := VoucherResponse{
+			ID:          r.ID,
+			Code:        r.Code,
+			RewardID:    r.RewardID,
+			RewardTitle: r.RewardTitle,
+			Category:    r.RewardCategory,
+			Coins:       r.Coins,
+			Status:      voucherStatus(r.Status, r.ExpiresAt, now),
+			ExpiresAt:   formatTime(r.ExpiresAt),
+			ConsumedAt:  optionalTime(r.ConsumedAt),
+			CreatedAt:   formatTime(r.CreatedAt),
+		}
+		if r.PartnerName.Valid {
+			name := r.PartnerName.String
+			item.PartnerName = &name
+		}
+		items[i] = item
+	}
+	return items, nil
+}
+
+// ListByOperator returns every reward owned by the operator, including inactive ones.
+func (s *Service) ListByOperator(ctx context.Context, operatorID int32) ([]RewardResponse, error) {
+	rows, err := s.queries.ListRewardsByOperator(ctx, operatorID)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	items := make([]RewardResponse, len(rows))
+	for i, r := range rows {
+		items[i] = rewardToResponse(r)
+	}
+	return items, nil
+}
+
+// Create adds a reward to the operator's catalog.
+func (s *Service) Create(ctx context.Context, actor policy.Actor, req CreateRewardRequest) (*RewardResponse, error) {
+	if err := policy.RequireRole(actor, policy.RoleOperator); err != nil {
+		return nil, err
+	}
+
+	params := generated.CreateRewardParams{
+		OperatorID:          actor.UserID,
+		Title:               req.Title,
+		Description:         req.Description,
+		Category:            "OTHER",
+		CostCoins:           req.CostCoins,
+		ValidFrom:           pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		VoucherValidityDays: 30,
+		IsActive:            true,
+	}
+	if req.Category != "" {
+		params.Category = req.Category
+	}
+	if req.PartnerName != nil {
+		params.PartnerName = pgtype.Text{String: *req.PartnerName, Valid: true}
+	}
+	if req.Stock != nil {
+		params.Stock = pgtype.Int4{Int32: *req.Stock, Valid: true}
+	}
+	if req.PerUserLimit != nil {
+		params.PerUserLimit = pgtype.Int4{Int32: *req.PerUserLimit, Valid: true}
+	}
+	if req.VoucherValidityDays != nil {
+		params.VoucherValidityDays = *req.VoucherValidityDays
+	}
+	if req.IsActive != nil {
+		params.IsActive = *req.IsActive
+	}
+
+	var err error
+	if req.ValidFrom != nil && *req.ValidFrom != "" {
+		if params.ValidFrom, err = parseTime(*req.ValidFrom, "validFrom"); err != nil {
+			return nil, err
+		}
+	}
+	if req.ValidUntil != nil {
+		if params.ValidUntil, err = parseTime(*req.ValidUntil, "validUntil"); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := validateReward(params.Category, params.CostCoins, params.Stock, params.PerUserLimit,
+		params.VoucherValidityDays, params.ValidFrom, params.ValidUntil); err != nil {
+		return nil, err
+	}
+
+	reward, err := s.queries.CreateReward(ctx, params)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	resp := rewardToResponse(reward)
+	return &resp, nil
+}
+
+// Update changes one of the operator's rewards.
+func (s *Service) Update(ctx context.Context, actor policy.Actor, rewardID int32, req UpdateRewardRequest) (*RewardResponse, error) {
+	existing, err := s.queries.GetRewardByID(ctx, rewardID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("Reward")
+	}
+	if err := policy.RequireOperator(actor, existing.OperatorID); err != nil {
+		return nil, err
+	}
+
+	params := generated.UpdateRewardParams{
+		ID:                  rewardID,
+		Title:               existing.Title,
+		Description:         existing.Description,
+		Category:            existing.Category,
+		PartnerName:         existing.PartnerName,
+		CostCoins:           existing.CostCoins,
+		Stock:               existing.Stock,
+		PerUserLimit:        existing.PerUserLimit,
+		ValidFrom:           existing.ValidFrom,
+		ValidUntil:          existing.ValidUntil,
+		VoucherValidityDays: existing.VoucherValidityDays,
+		IsActive:            existing.IsActive,
+	}
+	if req.Title != nil {
+		params.Title = *req.Title
+	}
+	if req.Description != nil {
+		params.Description = *req.Description
+	}
+	if req.Category != nil {
+		params.Category = *req.Category
+	}
+	if req.PartnerName != nil {
+		params.PartnerName = pgtype.Text{String: *req.PartnerName, Valid: *req.PartnerName != ""}
+	}
+	if req.CostCoins != nil {
+		params.CostCoins = *req.CostCoins
+	}
+	if req.Stock != nil {
+		params.Stock = pgtype.Int4{Int32: *req.Stock, Valid: true}
+	}
+	if req.PerUserLimit != nil {
+		params.PerUserLimit = pgtype.Int4{Int32: *req.PerUserLimit, Valid: true}
+	}
+	if req.VoucherValidityDays != nil {
+		params.VoucherValidityDays = *req.VoucherValidityDays
+	}
+	if req.IsActive != nil {
+		params.IsActive = *req.IsActive
+	}
+	if req.ValidFrom != nil && *req.ValidFrom != "" {
+		if params.ValidFrom, err = parseTime(*req.ValidFrom, "validFrom"); err != nil {
+			return nil, err
+		}
+	}
+	if req.ValidUntil != nil {
+		if params.ValidUntil, err = parseTime(*req.ValidUntil, "validUntil"); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := validateReward(params.Category, params.CostCoins, params.Stock, params.PerUserLimit,
+		params.VoucherValidityDays, params.ValidFrom, params.ValidUntil); err != nil {
+		return nil, err
+	}
+
+	reward, err := s.queries.UpdateReward(ctx, params)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	resp := rewardToResponse(reward)
+	return &resp, nil
+}
+
+// ValidateVoucher checks a voucher presented at the operator's counter without consuming it.
+func (s *Service) ValidateVoucher(ctx context.Context, actor policy.Actor, code string) (*ValidateVoucherResponse, error) {
+	voucher, err := s.queries.GetVoucherByCode(ctx, normalizeCode(code))
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("Voucher")
+	}
+
+	reward, err := s.queries.GetRewardByID(ctx, voucher.RewardID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("Voucher")
+	}
+	if err := policy.RequireOperator(actor, reward.OperatorID); err != nil {
+		return nil, err
+	}
+
+	resp := &ValidateVoucherResponse{
+		Voucher: voucherToResponse(voucher, reward),
+		Valid:   true,
+	}
+	if err := checkRedeemable(voucher, time.Now()); err != nil {
+		resp.Valid = false
+		resp.Reason = err.Error()
+	}
+	return resp, nil
+}
+
+// ConsumeVoucher marks a voucher as used. A voucher can only be consumed once.
+func (s *Service) ConsumeVoucher(ctx context.Context, actor policy.Actor, code string) (*VoucherResponse, error) {
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.queries.WithTx(tx)
+
+	voucher, err := qtx.GetVoucherByCodeForUpdate(ctx, normalizeCode(code))
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("Voucher")
+	}
+
+	reward, err := qtx.GetRewardByID(ctx, voucher.RewardID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("Voucher")
+	}
+	if err := policy.RequireOperator(actor, reward.OperatorID); err != nil {
+		return nil, err
+	}
+
+	if err := checkRedeemable(voucher, time.Now()); err != nil {
+		return nil, err
+	}
+
+	consumed, err := qtx.ConsumeVoucher(ctx, generated.ConsumeVoucherParams{
+		ID:         voucher.ID,
+		ConsumedBy: pgtype.Int4{Int32: actor.UserID, Valid: true},
+	})
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	resp := voucherToResponse(consumed, reward)
+	return &resp, nil
+}
+
+// --- helpers ---
+
+// checkRedeemable returns why a voucher can't be used, or nil if it can.
+func checkRedeemable(v generated.RewardVoucher, now time.Time) *apperrors.AppError {
+	switch voucherStatus(v.Status, v.ExpiresAt, now) {
+	case StatusConsumed:
+		return apperrors.NewConflictError("Voucher has already been used")
+	case StatusExpired:
+		return apperrors.NewValidationError("Voucher has expired")
+	}
+	return nil
+}
+
+// voucherStatus reports ISSUED vouchers past their expiry as EXPIRED.
+func voucherStatus(status string, expiresAt pgtype.Timestamptz, now time.Time) string {
+	if status == StatusIssued && expiresAt.Valid && !now.Before(expiresAt.Time) {
+		return StatusExpired
+	}
+	return status
+}
+
+func validateReward(category string, costCoins int32, stock, perUserLimit pgtype.Int4,
+	validityDays int32, validFrom, validUntil pgtype.Timestamptz) error {
+	if !validCategories[category] {
+		return apperrors.NewValidationError("category must be one of FREE_SESSION, PARTNER, MERCH, OTHER")
+	}
+	if costCoins <= 0 {
+		return apperrors.NewValidationError("costCoins must be greater than 0")
+	}
+	if stock.Valid && stock.Int32 < 0 {
+		return apperrors.NewValidationError("stock cannot be negative")
+	}
+	if perUserLimit.Valid && perUserLimit.Int32 <= 0 {
+		return apperrors.NewValidationError("perUserLimit must be greater than 0")
+	}
+	if validityDays <= 0 {
+		return apperrors.NewValidationError("voucherValidityDays must be greater than 0")
+	}
+	if validFrom.Valid && validUntil.Valid && !validUntil.Time.After(validFrom.Time) {
+		return apperrors.NewValidationError("validUntil must be after validFrom")
+	}
+	return nil
+}
+
+// parseTime parses an RFC3339 timestamp; an empty string clears the value.
+func parseTime(raw, field string) (pgtype.Timestamptz, error) {
+	if raw == "" {
+		return pgtype.Timestamptz{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return pgtype.Timestamptz{}, apperrors.NewValidationError(field + " must be an RFC3339 timestamp")
+	}
+	return pgtype.Timestamptz{Time: t, Valid: true}, nil
+}
+
+func formatTime(t pgtype.Timestamptz) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.Time.UTC().Format(time.RFC3339)
+}
+
+func optionalTime(t pgtype.Timestamptz) *string {
+	if !t.Valid {
+		return nil
+	}
+	s := formatTime(t)
+	return &s
+}
+
+func rewardToResponse(r generated.Reward) RewardResponse {
+	resp := RewardResponse{
+		ID:                  r.ID,
+		OperatorID:          r.OperatorID,
+		Title:               r.Title,
+		Description:         r.Description,
+		Category:            r.Category,
+		CostCoins:           r.CostCoins,
+		ValidFrom:           formatTime(r.ValidFrom),
+		ValidUntil:          optionalTime(r.ValidUntil),
+		VoucherValidityDays: r.VoucherValidityDays,
+		IsActive:            r.IsActive,
+	}
+	if r.PartnerName.Valid {
+		name := r.PartnerName.String
+		resp.PartnerName = &name
+	}
+	if r.Stock.Valid {
+		stock := r.Stock.Int32
+		resp.Stock = &stock
+	}
+	if r.PerUserLimit.Valid {
+		limit := r.PerUserLimit.Int32
+		resp.PerUserLimit = &limit
+	}
+	return resp
+}
+
+func voucherToResponse(v generated.RewardVoucher, r generated.Reward) VoucherResponse {
+	resp := VoucherResponse{
+		ID:          v.ID,
+		Code:        v.Code,
+		RewardID:    r.ID,
+		RewardTitle: r.Title,
+		Category:    r.Category,
+		Coins:       v.Coins,
+		Status:      voucherStatus(v.Status, v.ExpiresAt, time.Now()),
+		ExpiresAt:   formatTime(v.ExpiresAt),
+		ConsumedAt:  optionalTime(v.ConsumedAt),
+		CreatedAt:   formatTime(v.CreatedAt),
+	}
+	if r.PartnerName.Valid {
+		name := r.PartnerName.String
+		resp.PartnerName = &name
+	}
+	return resp
+}
@@ -0,0 +1,87 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Job is a unit of periodic background work.
+type Job interface {
+	Name() string
+	Run(ctx context.Context) error
+}
+
+type entry struct {
+	interval time.Duration
+	job      Job
+}
+
+// Scheduler runs registered jobs on their own intervals until its context is cancelled.
+type Scheduler struct {
+	entries []entry
+	wg      sync.WaitGroup
+}
+
+// New creates an empty scheduler.
+func New() *Scheduler {
+	return &Scheduler{}
+}
+
+// Every registers job to run once at start and then every interval.
+// A job with a zero or negative interval is not scheduled.
+func (s *Scheduler) Every(interval time.Duration, job Job) {
+	if interval <= 0 {
+		log.Printf("scheduler: job %s has interval %s, not scheduling it", job.Name(), interval)
+		return
+	}
+	s.entries = append(s.entries, entry{interval: interval, job: job})
+}
+
+// Start launches one goroutine per job. Jobs stop when ctx is cancelled; use Wait to block until they have.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, e := range s.entries {
+		s.wg.Add(1)
+		go func(e entry) {
+			defer s.wg.Done()
+			s.loop(ctx, e)
+		}(e)
+	}
+}
+
+// Wait blocks until all job goroutines have exited.
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, e entry) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		run(ctx, e.job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// run executes a job once, logging failures and recovering from panics so one bad run doesn't stop the loop.
+func run(ctx context.Context, job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("scheduler: job %s panicked: %v", job.Name(), r)
+		}
+	}()
+
+	start := time.Now()
+	if err := job.Run(ctx); err != nil {
+		log.Printf("scheduler: job %s failed: %v", job.Name(), err)
+		return
+	}
+	log.Printf("scheduler: job %s finished in %s", job.Name(), time.Since(start).Round(time.Millisecond))
+}
@@ -0,0 +1,56 @@
+package statement
+
+// --- Response DTOs ---
+
+// StatementResponse is the monthly charging and carbon statement for a driver.
+type StatementResponse struct {
+	UserID        int32         `json:"userId"`
+	UserName      string        `json:"userName"`
+	Month         string        `json:"month"`
+	PeriodStart   string        `json:"periodStart"`
+	PeriodEnd     string        `json:"periodEnd"`
+	Summary       PeriodSummary `json:"summary"`
+	PreviousMonth PeriodSummary `json:"previousMonth"`
+	Change        SummaryChange `json:"change"`
+	Sessions      []SessionItem `json:"sessions"`
+	GeneratedAt   string        `json:"generatedAt"`
+}
+
+// PeriodSummary holds the aggregated totals for one month.
+type PeriodSummary struct {
+	Month         string  `json:"month"`
+	Sessions      int32   `json:"sessions"`
+	GreenSessions int32   `json:"greenSessions"`
+	EnergyKWh     float64 `json:"energyKwh"`
+	AmountSpent   float64 `json:"amountSpent"`
+	CoinsEarned   int32   `json:"coinsEarned"`
+	CoinsRedeemed int32   `json:"coinsRedeemed"`
+	Co2Saved      float64 `json:"co2Saved"`
+	GreenShare    float64 `json:"greenShare"`
+}
+
+// SummaryChange is the difference between the statement month and the previous month.
+type SummaryChange struct {
+	Sessions      int32   `json:"sessions"`
+	EnergyKWh     float64 `json:"energyKwh"`
+	AmountSpent   float64 `json:"amountSpent"`
+	CoinsEarned   int32   `json:"coinsEarned"`
+	CoinsRedeemed int32   `json:"coinsRedeemed"`
+	Co2Saved      float64 `json:"co2Saved"`
+	GreenShare    float64 `json:"greenShare"`
+}
+
+// SessionItem is a single completed charging session listed on the statement.
+type SessionItem struct {
+	ReservationID int32   `json:"reservationId"`
+	Date          string  `json:"date"`
+	Hour          string  `json:"hour"`
+	StationID     int32   `json:"stationId"`
+	StationName   string  `json:"stationName"`
+	IsGreen       bool    `json:"isGreen"`
+	EnergyKWh     float64 `json:"energyKwh"`
+	Amount        float64 `json:"amount"`
+	CoinsEarned   int32   `json:"coinsEarned"`
+	CoinsRedeemed int32   `json:"coinsRedeemed"`
+	Co2Saved      float64 `json:"co2Saved"`
+}
@@ -0,0 +1,114 @@
+package statement
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	apperrors "smartcharge-api/internal/errors"
+	"smartcharge-api/internal/policy"
+	"smartcharge-api/internal/response"
+)
+
+// Handler handles HTTP requests for monthly statements.
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new statement handler.
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers statement routes on the given router group.
+func (h *Handler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	statements := rg.Group("/users/:id/statements", authMiddleware)
+
+	statements.GET("/:month", h.Get)
+	statements.GET("/:month/download", h.Download)
+}
+
+// Get handles GET /v1/users/:id/statements/:month.
+func (h *Handler) Get(c *gin.Context) {
+	userID, ok := authorizeOwner(c)
+	if !ok {
+		return
+	}
+
+	monthStart, err := ParseMonth(c.Param("month"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	result, err := h.service.GetMonthly(c.Request.Context(), userID, monthStart)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// Download handles GET /v1/users/:id/statements/:month/download.
+// Returns the statement as a printable HTML attachment.
+func (h *Handler) Download(c *gin.Context) {
+	userID, ok := authorizeOwner(c)
+	if !ok {
+		return
+	}
+
+	monthStart, err := ParseMonth(c.Param("month"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	result, err := h.service.GetMonthly(c.Request.Context(), userID, monthStart)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	body, err := RenderHTML(result)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("smartcharge-statement-%s.html", result.Month)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Data(http.StatusOK, "text/html; charset=utf-8", body)
+}
+
+// --- helpers ---
+
+// authorizeOwner parses :id and ensures drivers can only read their own statements; admins can read anyone's.
+func authorizeOwner(c *gin.Context) (int32, bool) {
+	val, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "Invalid user ID")
+		return 0, false
+	}
+	id := int32(val)
+
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return 0, false
+	}
+	if err := policy.RequireOwner(actor, id); err != nil {
+		response.Err(c, 403, "AUTH_FORBIDDEN", "You can only view your own statements")
+		return 0, false
+	}
+	return id, true
+}
+
+func handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*apperrors.AppError); ok {
+		response.Err(c, appErr.StatusCode, appErr.Code, appErr.Message)
+		return
+	}
+	response.Err(c, 500, "INTERNAL_ERROR", "An unexpected error occurred")
+}
@@ -0,0 +1,72 @@
+package statement
+
+import (
+	"bytes"
+	"html/template"
+)
+
+// statementTemplate renders a self-contained, printable HTML statement.
+var statementTemplate = template.Must(template.New("statement").Parse(`<!DOCTYPE html>
+<html lang="tr">
+<head>
+<meta charset="utf-8">
+<title>SmartCharge Aylık Özet {{.Month}}</title>
+<style>
+  body { font-family: -apple-system, "Segoe UI", Roboto, sans-serif; color: #1f2937; margin: 32px; }
+  h1 { font-size: 22px; margin-bottom: 4px; }
+  .muted { color: #6b7280; font-size: 13px; }
+  table { border-collapse: collapse; width: 100%; margin-top: 16px; font-size: 13px; }
+  th, td { border-bottom: 1px solid #e5e7eb; padding: 6px 8px; text-align: left; }
+  th { background: #f9fafb; }
+  td.num, th.num { text-align: right; }
+  .green { color: #047857; font-weight: 600; }
+</style>
+</head>
+<body>
+<h1>SmartCharge Aylık Şarj ve Karbon Özeti</h1>
+<div class="muted">{{.UserName}} · {{.Month}} · Oluşturulma: {{.GeneratedAt}}</div>
+
+<table>
+  <tr><th></th><th class="num">{{.Summary.Month}}</th><th class="num">{{.PreviousMonth.Month}}</th><th class="num">Değişim</th></tr>
+  <tr><td>Şarj oturumu</td><td class="num">{{.Summary.Sessions}}</td><td class="num">{{.PreviousMonth.Sessions}}</td><td class="num">{{.Change.Sessions}}</td></tr>
+  <tr><td>Enerji (kWh)</td><td class="num">{{printf "%.2f" .Summary.EnergyKWh}}</td><td class="num">{{printf "%.2f" .PreviousMonth.EnergyKWh}}</td><td class="num">{{printf "%.2f" .Change.EnergyKWh}}</td></tr>
+  <tr><td>Harcama (₺)</td><td class="num">{{printf "%.2f" .Summary.AmountSpent}}</td><td class="num">{{printf "%.2f" .PreviousMonth.AmountSpent}}</td><td class="num">{{printf "%.2f" .Change.AmountSpent}}</td></tr>
+  <tr><td>Kazanılan SmartCoin</td><td class="num">{{.Summary.CoinsEarned}}</td><td class="num">{{.PreviousMonth.CoinsEarned}}</td><td class="num">{{.Change.CoinsEarned}}</td></tr>
+  <tr><td>Kullanılan SmartCoin</td><td class="num">{{.Summary.CoinsRedeemed}}</td><td class="num">{{.PreviousMonth.CoinsRedeemed}}</td><td class="num">{{.Change.CoinsRedeemed}}</td></tr>
+  <tr><td>Önlenen CO₂ (kg)</td><td class="num">{{printf "%.2f" .Summary.Co2Saved}}</td><td class="num">{{printf "%.2f" .PreviousMonth.Co2Saved}}</td><td class="num">{{printf "%.2f" .Change.Co2Saved}}</td></tr>
+  <tr><td>Yeşil saat payı (%)</td><td class="num">{{printf "%.2f" .Summary.GreenShare}}</td><td class="num">{{printf "%.2f" .PreviousMonth.GreenShare}}</td><td class="num">{{printf "%.2f" .Change.GreenShare}}</td></tr>
+</table>
+
+<h2 style="font-size:16px;margin-top:28px;">Oturumlar</h2>
+{{if .Sessions}}
+<table>
+  <tr><th>Tarih</th><th>Saat</th><th>İstasyon</th><th class="num">kWh</th><th class="num">Tutar (₺)</th><th class="num">SmartCoin</th><th class="num">CO₂ (kg)</th></tr>
+  {{range .Sessions}}
+  <tr>
+    <td>{{.Date}}</td>
+    <td>{{.Hour}}{{if .IsGreen}} <span class="green">yeşil</span>{{end}}</td>
+    <td>{{.StationName}}</td>
+    <td class="num">{{printf "%.2f" .EnergyKWh}}</td>
+    <td class="num">{{printf "%.2f" .Amount}}</td>
+    <td class="num">{{.CoinsEarned}}</td>
+    <td class="num">{{printf "%.2f" .Co2Saved}}</td>
+  </tr>
+  {{end}}
+</table>
+{{else}}
+<p class="muted">Bu ay tamamlanmış şarj oturumu yok.</p>
+{{end}}
+
+<p class="muted" style="margin-top:24px;">Enerji değerleri oturum başına ortalama tüketim üzerinden tahmini olarak hesaplanmıştır.</p>
+</body>
+</html>
+`))
+
+// RenderHTML renders the statement as a standalone HTML document.
+func RenderHTML(st *StatementResponse) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := statementTemplate.Execute(&buf, st); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,134 @@
+package statement
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"smartcharge-api/db/generated"
+	apperrors "smartcharge-api/internal/errors"
+	"smartcharge-api/internal/pricing"
+)
+
+// monthLayout is the format of the :month path parameter (e.g. "2026-03").
+const monthLayout = "2006-01"
+
+// Service builds monthly statements from reservation history.
+type Service struct {
+	queries *generated.Queries
+}
+
+// NewService creates a new statement service.
+func NewService(queries *generated.Queries) *Service {
+	return &Service{queries: queries}
+}
+
+// ParseMonth parses a "YYYY-MM" string into the first instant of that month, in pricing.SlotLocation.
+func ParseMonth(raw string) (time.Time, error) {
+	t, err := time.ParseInLocation(monthLayout, raw, pricing.SlotLocation)
+	if err != nil {
+		return time.Time{}, apperrors.NewValidationError("month must be in YYYY-MM format")
+	}
+	return t, nil
+}
+
+// GetMonthly returns the statement for the month starting at monthStart, compared with the previous month.
+func (s *Service) GetMonthly(ctx context.Context, userID int32, monthStart time.Time) (*StatementResponse, error) {
+	user, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("User")
+	}
+
+	monthEnd := monthStart.AddDate(0, 1, 0)
+	prevStart := monthStart.AddDate(0, -1, 0)
+
+	current, err := s.summarize(ctx, userID, monthStart, monthEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := s.summarize(ctx, userID, prevStart, monthStart)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListUserCompletedReservations(ctx, generated.ListUserCompletedReservationsParams{
+		UserID:      userID,
+		PeriodStart: pgtype.Timestamptz{Time: monthStart, Valid: true},
+		PeriodEnd:   pgtype.Timestamptz{Time: monthEnd, Valid: true},
+	})
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	sessions := make([]SessionItem, len(rows))
+	for i, r := range rows {
+		dateStr := ""
+		if r.Date.Valid {
+			dateStr = r.Date.Time.UTC().Format(time.RFC3339)
+		}
+		sessions[i] = SessionItem{
+			ReservationID: r.ID,
+			Date:          dateStr,
+			Hour:          r.Hour,
+			StationID:     r.StationID,
+			StationName:   r.StationName,
+			IsGreen:       r.IsGreen,
+			EnergyKWh:     pricing.SessionEnergyKWh,
+			Amount:        pricing.RoundTo2(r.SessionAmount - r.CoinDiscount),
+			CoinsEarned:   r.EarnedCoins,
+			CoinsRedeemed: r.RedeemedCoins,
+			Co2Saved:      pricing.RoundTo2(r.SavedCo2),
+		}
+	}
+
+	return &StatementResponse{
+		UserID:        user.ID,
+		UserName:      user.Name,
+		Month:         monthStart.Format(monthLayout),
+		PeriodStart:   monthStart.UTC().Format(time.RFC3339),
+		PeriodEnd:     monthEnd.UTC().Format(time.RFC3339),
+		Summary:       current,
+		PreviousMonth: previous,
+		Change: SummaryChange{
+			Sessions:    current.Sessions - previous.Sessions,
+			EnergyKWh:   pricing.RoundTo2(current.EnergyKWh - previous.EnergyKWh),
+			AmountSpent: pricing.RoundTo2(current.AmountSpent - previous.AmountSpent),
+			CoinsEarned: current.CoinsEarned - previous.CoinsEarned,
+			Co2Saved:    pricing.RoundTo2(current.Co2Saved - previous.Co2Saved),
+			GreenShare:  pricing.RoundTo2(current.GreenShare - previous.GreenShare),
+		},
+		Sessions:    sessions,
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// summarize aggregates completed reservations in [start, end).
+func (s *Service) summarize(ctx context.Context, userID int32, start, end time.Time) (PeriodSummary, error) {
+	row, err := s.queries.GetUserPeriodSummary(ctx, generated.GetUserPeriodSummaryParams{
+		UserID:      userID,
+		PeriodStart: pgtype.Timestamptz{Time: start, Valid: true},
+		PeriodEnd:   pgtype.Timestamptz{Time: end, Valid: true},
+	})
+	if err != nil {
+		return PeriodSummary{}, apperrors.ErrInternal
+	}
+
+	var greenShare float64
+	if row.Sessions > 0 {
+		greenShare = pricing.RoundTo2(float64(row.GreenSessions) / float64(row.Sessions) * 100)
+	}
+
+	return PeriodSummary{
+		Month:         start.Format(monthLayout),
+		Sessions:      row.Sessions,
+		GreenSessions: row.GreenSessions,
+		EnergyKWh:     pricing.RoundTo2(float64(row.Sessions) * pricing.SessionEnergyKWh),
+		AmountSpent:   pricing.RoundTo2(row.AmountSpent),
+		CoinsEarned:   row.CoinsEarned,
+		CoinsRedeemed: row.CoinsRedeemed,
+		Co2Saved:      pricing.RoundTo2(row.Co2Saved),
+		GreenShare:    greenShare,
+	}, nil
+}
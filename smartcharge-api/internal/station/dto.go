@@ -38,45 +38,74 @@ type StationListItem struct {
 
 // StationDetailResponse is the full station detail with timeslots.
 type StationDetailResponse struct {
-	ID             int32            `json:"id"`
-	Name           string           `json:"name"`
-	Lat            float64          `json:"lat"`
-	Lng            float64          `json:"lng"`
-	Address        *string          `json:"address"`
-	Price          float64          `json:"price"`
-	Density        int32            `json:"density"`
-	DensityProfile string           `json:"densityProfile"`
+	ID             int32   `json:"id"`
+	Name           string  `json:"name"`
+	Lat            float64 `json:"lat"`
+	Lng            float64 `json:"lng"`
+	Address        *string `json:"address"`
+	Price          float64 `json:"price"`
+	Density        int32   `json:"density"`
+	DensityProfile string  `json:"densityProfile"`
+
+	// Date is the day the slots are for (YYYY-MM-DD). PrevDate and NextDate page through the days
+	// slots can be shown for, and are nil at either end.
+	Date     string  `json:"date"`
+	PrevDate *string `json:"prevDate"`
+	NextDate *string `json:"nextDate"`
+
 	Slots          []TimeSlot       `json:"slots"`
 	ActiveCampaign *CampaignSummary `json:"activeCampaign"`
+
+	// AppliedCampaigns lists every campaign the viewer gets, in application order; the first is ActiveCampaign
+	AppliedCampaigns []CampaignSummary `json:"appliedCampaigns"`
 }
 
 // TimeSlot represents a single hourly slot in the station detail.
 type TimeSlot struct {
-	Hour            int32            `json:"hour"`
-	Label           string           `json:"label"`
-	StartTime       string           `json:"startTime"`
-	IsGreen         bool             `json:"isGreen"`
-	Coins           int32            `json:"coins"`
-	Price           float64          `json:"price"`
-	Status          string           `json:"status"`
-	Load            int32            `json:"load"`
+	Hour      int32   `json:"hour"`
+	Label     string  `json:"label"`
+	StartTime string  `json:"startTime"`
+	IsGreen   bool    `json:"isGreen"`
+	Coins     int32   `json:"coins"`
+	Price     float64 `json:"price"`
+	Status    string  `json:"status"`
+	Load      int32   `json:"load"`
+	// LoadP10–LoadP90 is the 80% band around the typical (median) Load
+	LoadP10 int32 `json:"loadP10"`
+	LoadP90 int32 `json:"loadP90"`
+	// Event is the holiday or event the load forecast was adjusted for, if any
+	Event           *string          `json:"event"`
 	CampaignApplied *CampaignApplied `json:"campaignApplied"`
+	// CampaignsApplied lists every campaign applied to the slot, in application order
+	CampaignsApplied []CampaignApplied `json:"campaignsApplied"`
 }
 
 // CampaignApplied is the minimal campaign info shown per-slot.
 type CampaignApplied struct {
-	Title    string `json:"title"`
-	Discount string `json:"discount"`
+	ID            int32   `json:"id"`
+	Title         string  `json:"title"`
+	Discount      string  `json:"discount"`
+	DiscountType  string  `json:"discountType"`
+	DiscountValue float64 `json:"discountValue"`
+	CoinReward    int32   `json:"coinReward"`
+	// What the campaign actually contributed to this slot, after the combined discount cap
+	PriceDiscount float64 `json:"priceDiscount"`
+	CoinBonus     int32   `json:"coinBonus"`
 }
 
 // CampaignSummary is the active campaign attached to a station detail.
 type CampaignSummary struct {
-	ID          int32  `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Discount    string `json:"discount"`
-	CoinReward  int32  `json:"coinReward"`
-	StationID   *int32 `json:"stationId"`
+	ID            int32   `json:"id"`
+	Title         string  `json:"title"`
+	Description   string  `json:"description"`
+	Discount      string  `json:"discount"`
+	DiscountType  string  `json:"discountType"`
+	DiscountValue float64 `json:"discountValue"`
+	CoinReward    int32   `json:"coinReward"`
+	StationID     *int32  `json:"stationId"`
+	Priority      int32   `json:"priority"`
+	Exclusive     bool    `json:"exclusive"`
+	Stackable     bool    `json:"stackable"`
 }
 
 // StationResponse is the response for create/update operations.
@@ -100,11 +129,47 @@ type ForecastItem struct {
 	Price          float64 `json:"price"`
 	Address        *string `json:"address"`
 	DensityProfile string  `json:"densityProfile"`
-	PredictedLoad  int32   `json:"predictedLoad"`
+	PredictedLoad  int32   `json:"predictedLoad"` // median (P50)
+	P10Load        int32   `json:"p10Load"`
+	P90Load        int32   `json:"p90Load"`
 	DayOfWeek      int32   `json:"dayOfWeek"`
 	Hour           int32   `json:"hour"`
 }
 
+// StationForecastResponse is a station's hourly forecast for the next days.
+type StationForecastResponse struct {
+	StationID int32          `json:"stationId"`
+	Model     string         `json:"model"`
+	From      string         `json:"from"`
+	To        string         `json:"to"`
+	Hours     []HourForecast `json:"hours"`
+}
+
+// HourForecast is the forecast load (0–100) for one hour. LoadP10–LoadP90 is the 80% band around the median Load.
+type HourForecast struct {
+	StartTime string  `json:"startTime"`
+	Load      int32   `json:"load"`
+	LoadP10   int32   `json:"loadP10"`
+	LoadP90   int32   `json:"loadP90"`
+	Event     *string `json:"event"` // the holiday or event the forecast was adjusted for, if any
+	// Weather is the weather the forecast was adjusted for; nil when it wasn't known
+	Weather *WeatherContribution `json:"weather"`
+	// Source is "external" for an uploaded forecast version, "dated" for the dated forecast, "weekly" for
+	// the usual week when the dated one doesn't reach that far, and "density" for stations without a forecast
+	Source string `json:"source"`
+	// Version is the uploaded forecast version (model@version) when Source is "external"
+	Version *string `json:"version"`
+}
+
+// WeatherContribution is the weather forecast for an hour and how far each part of it moved the forecast load,
+// in points; negative effects lower it.
+type WeatherContribution struct {
+	Temperature         float64 `json:"temperature"`   // °C
+	Precipitation       float64 `json:"precipitation"` // mm
+	TemperatureEffect   float64 `json:"temperatureEffect"`
+	PrecipitationEffect float64 `json:"precipitationEffect"`
+}
+
 // ForecastResponse wraps the forecast list with current time context.
 type ForecastResponse struct {
 	CurrentTime ForecastTime   `json:"currentTime"`
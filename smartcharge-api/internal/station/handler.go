@@ -8,6 +8,8 @@ import (
 
 	apperrors "smartcharge-api/internal/errors"
 	"smartcharge-api/internal/middleware"
+	"smartcharge-api/internal/policy"
+	"smartcharge-api/internal/pricing"
 	"smartcharge-api/internal/response"
 )
 
@@ -22,13 +24,15 @@ func NewHandler(service *Service) *Handler {
 }
 
 // RegisterRoutes registers station routes on the given router group.
-func (h *Handler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+// optionalAuth identifies logged-in viewers on public routes that personalize pricing.
+func (h *Handler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware, optionalAuth gin.HandlerFunc) {
 	stations := rg.Group("/stations")
 
 	// Public routes
 	stations.GET("", h.ListStations)
 	stations.GET("/forecast", h.GetForecasts)
-	stations.GET("/:id", h.GetStation)
+	stations.GET("/:id", optionalAuth, h.GetStation)
+	stations.GET("/:id/forecast", h.GetStationForecast)
 
 	// Protected routes
 	stations.POST("", authMiddleware, h.CreateStation)
@@ -45,14 +49,50 @@ func (h *Handler) ListStations(c *gin.Context) {
 	response.OK(c, items)
 }
 
-// GetStation handles GET /v1/stations/:id.
+// GetStation handles GET /v1/stations/:id?date=YYYY-MM-DD.
 func (h *Handler) GetStation(c *gin.Context) {
 	id, err := parseID(c, "id")
 	if err != nil {
 		return
 	}
 
-	result, err := h.service.GetStation(c.Request.Context(), id)
+	var date time.Time
+	if d := c.Query("date"); d != "" {
+		if date, err = time.ParseInLocation(dateLayout, d, pricing.SlotLocation); err != nil {
+			response.Err(c, 400, "VALIDATION_ERROR", "date must be YYYY-MM-DD")
+			return
+		}
+	}
+
+	var viewerID *int32
+	if userID, ok := middleware.GetUserID(c); ok {
+		viewerID = &userID
+	}
+
+	result, err := h.service.GetStation(c.Request.Context(), id, viewerID, date)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// GetStationForecast handles GET /v1/stations/:id/forecast?days=N.
+func (h *Handler) GetStationForecast(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		return
+	}
+
+	days := 7
+	if d := c.Query("days"); d != "" {
+		if days, err = strconv.Atoi(d); err != nil {
+			response.Err(c, 400, "VALIDATION_ERROR", "days must be a number")
+			return
+		}
+	}
+
+	result, err := h.service.GetStationForecast(c.Request.Context(), id, days)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -62,7 +102,7 @@ func (h *Handler) GetStation(c *gin.Context) {
 
 // CreateStation handles POST /v1/stations.
 func (h *Handler) CreateStation(c *gin.Context) {
-	userID, ok := middleware.GetUserID(c)
+	actor, ok := policy.FromContext(c)
 	if !ok {
 		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
 		return
@@ -74,7 +114,7 @@ func (h *Handler) CreateStation(c *gin.Context) {
 		return
 	}
 
-	result, err := h.service.CreateStation(c.Request.Context(), userID, req)
+	result, err := h.service.CreateStation(c.Request.Context(), actor, req)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -84,6 +124,11 @@ func (h *Handler) CreateStation(c *gin.Context) {
 
 // UpdateStation handles PUT /v1/stations/:id.
 func (h *Handler) UpdateStation(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
 	id, err := parseID(c, "id")
 	if err != nil {
 		return
@@ -95,7 +140,7 @@ func (h *Handler) UpdateStation(c *gin.Context) {
 		return
 	}
 
-	result, err := h.service.UpdateStation(c.Request.Context(), id, req)
+	result, err := h.service.UpdateStation(c.Request.Context(), actor, id, req)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -105,7 +150,7 @@ func (h *Handler) UpdateStation(c *gin.Context) {
 
 // GetForecasts handles GET /v1/stations/forecast.
 func (h *Handler) GetForecasts(c *gin.Context) {
-	now := time.Now()
+	now := time.Now().In(pricing.SlotLocation)
 	// Default: current day and hour
 	dayOfWeek := int32((int(now.Weekday()) + 6) % 7)
 	hour := int32(now.Hour())
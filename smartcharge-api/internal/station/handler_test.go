@@ -0,0 +1,56 @@
+package station
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"smartcharge-api/db/generated"
+	"smartcharge-api/internal/apitest"
+)
+
+func TestMutatingRoutesAuthorization(t *testing.T) {
+	station := map[string]any{"name": "Kadıköy", "latitude": 40.99, "longitude": 29.03, "price": 7.5}
+
+	tests := []struct {
+		name  string
+		route apitest.Route
+		write string         // the query that makes the change
+		want  map[string]int // status by apitest.Actors name
+	}{
+		{
+			name:  "create",
+			route: apitest.Route{Method: http.MethodPost, Path: "/v1/stations", Body: station},
+			write: "CreateStation",
+			want:  map[string]int{"driver": 403, "owning operator": 201, "other operator": 201, "admin": 201},
+		},
+		{
+			name:  "update",
+			route: apitest.Route{Method: http.MethodPut, Path: "/v1/stations/5", Body: station},
+			write: "UpdateStation",
+			want:  map[string]int{"driver": 403, "owning operator": 200, "other operator": 403, "admin": 200},
+		},
+	}
+
+	for _, tt := range tests {
+		for name, want := range tt.want {
+			actor := apitest.Actors[name]
+			t.Run(tt.name+"/"+name, func(t *testing.T) {
+				db := apitest.NewDB().
+					On("GetStationByID", generated.Station{ID: 5, OwnerID: pgtype.Int4{Int32: apitest.Owner.UserID, Valid: true}})
+				h := NewHandler(NewService(generated.New(db)))
+				r := apitest.Router(actor, func(v1 *gin.RouterGroup, auth gin.HandlerFunc) { h.RegisterRoutes(v1, auth, auth) })
+
+				w := apitest.Do(r, tt.route)
+				if w.Code != want {
+					t.Fatalf("status = %d, want %d: %s", w.Code, want, w.Body)
+				}
+				if wrote := db.Called(tt.write); wrote != (want != http.StatusForbidden) {
+					t.Errorf("%s called = %v with status %d", tt.write, wrote, w.Code)
+				}
+			})
+		}
+	}
+}
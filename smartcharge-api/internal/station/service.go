@@ -4,19 +4,15 @@ import (
 	"context"
 	"fmt"
 	"math"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"smartcharge-api/db/generated"
 	apperrors "smartcharge-api/internal/errors"
-)
-
-const (
-	greenStart = 23
-	greenEnd   = 6
+	"smartcharge-api/internal/forecast"
+	"smartcharge-api/internal/policy"
+	"smartcharge-api/internal/pricing"
 )
 
 // Service handles station business logic.
@@ -40,11 +36,6 @@ func loadStatus(load int32) string {
 	return "GREEN"
 }
 
-// isGreenHour returns true if the hour falls in the green window (23:00–06:00).
-func isGreenHour(hour int32) bool {
-	return hour >= greenStart || hour <= greenEnd
-}
-
 // ListStations returns all stations with density-based load status.
 func (s *Service) ListStations(ctx context.Context) ([]StationListItem, error) {
 	rows, err := s.queries.ListStations(ctx)
@@ -77,94 +68,111 @@ func (s *Service) ListStations(ctx context.Context) ([]StationListItem, error) {
 	return items, nil
 }
 
-// GetStation returns a station detail with 24h timeslots, campaign discount stacking,
-// and forecast-based load data.
-func (s *Service) GetStation(ctx context.Context, stationID int32) (*StationDetailResponse, error) {
+// GetStation returns a station detail with the 24 hourly timeslots of a date, campaign discount stacking,
+// and forecast-based load data. A zero date means today; otherwise it must be within the forecast horizon,
+// so future days can be browsed for booking ahead. Only campaigns the viewer is eligible for are applied;
+// anonymous viewers (viewerID nil) only get unrestricted campaigns.
+func (s *Service) GetStation(ctx context.Context, stationID int32, viewerID *int32, date time.Time) (*StationDetailResponse, error) {
 	station, err := s.queries.GetStationByID(ctx, stationID)
 	if err != nil {
 		return nil, apperrors.NewNotFoundError("Station")
 	}
 
-	// Fetch active campaigns for this station (station-specific + global)
-	campaigns, err := s.queries.GetActiveCampaignsForStation(ctx, pgtype.Int4{Int32: stationID, Valid: true})
+	now := time.Now().In(pricing.SlotLocation)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, pricing.SlotLocation)
+	day := today
+	if !date.IsZero() {
+		day = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, pricing.SlotLocation)
+	}
+	lastDay := today.AddDate(0, 0, forecastDays-1)
+	if day.Before(today) || day.After(lastDay) {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("date must be between %s and %s",
+			today.Format(dateLayout), lastDay.Format(dateLayout)))
+	}
+
+	// Fetch the campaigns running at some point of the day for this station (station-specific + global),
+	// including scheduled ones that start later
+	campaigns, err := s.queries.GetActiveCampaignsForStation(ctx, generated.GetActiveCampaignsForStationParams{
+		WindowStart: pgtype.Timestamptz{Time: day, Valid: true},
+		WindowEnd:   pgtype.Timestamptz{Time: day.AddDate(0, 0, 1), Valid: true},
+		StationID:   pgtype.Int4{Int32: stationID, Valid: true},
+	})
 	if err != nil {
 		campaigns = []generated.Campaign{}
 	}
 
-	// Use the most recent active campaign
-	var activeCampaign *generated.Campaign
-	var campaignDiscountRate float64
-	if len(campaigns) > 0 {
-		activeCampaign = &campaigns[0]
-		campaignDiscountRate = parseDiscountRate(activeCampaign.Discount)
+	var profile *pricing.Profile
+	if viewerID != nil {
+		if profile, err = pricing.LoadProfile(ctx, s.queries, *viewerID); err != nil {
+			return nil, apperrors.ErrInternal
+		}
 	}
 
-	// Get the current day of week (0=Monday in our DB convention)
-	now := time.Now()
-	// Go: Sunday=0, Monday=1, ..., Saturday=6
-	// DB:  Monday=0, Tuesday=1, ..., Sunday=6
-	dayOfWeek := int32((int(now.Weekday()) + 6) % 7)
+	// Campaigns the viewer is eligible for; each slot stacks the ones live at its start, the same way bookings are priced
+	offers, err := pricing.EligibleOffers(ctx, s.queries, campaigns, profile)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	if err := pricing.RecordExposures(ctx, s.queries, offers, profile); err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	maxCombined, err := pricing.LoadMaxCombinedDiscount(ctx, s.queries, station.OwnerID)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
 
-	// Build 24 hourly slots
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	// Build 24 hourly slots, with their forecast load
 	slots := make([]TimeSlot, 24)
+	loads, err := s.hourlyLoads(ctx, station, day, 24)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
 
 	for hour := int32(0); hour < 24; hour++ {
-		slotTime := today.Add(time.Duration(hour) * time.Hour)
-		green := isGreenHour(hour)
-
-		// Base price and coins
-		price := station.Price
-		coins := int32(10)
-		if green {
-			price *= 0.8 // 20% green discount
-			coins = 50
-		}
-
-		// Campaign discount stacking
-		if campaignDiscountRate > 0 {
-			price *= (1 - campaignDiscountRate)
-		}
+		slotTime := loads[hour].start
+		green := pricing.IsGreenHour(hour)
 
-		// Campaign coin reward
-		if activeCampaign != nil && activeCampaign.CoinReward > 0 {
-			coins += activeCampaign.CoinReward
-		}
-
-		// Get forecast-based load for this hour
-		load := station.Density // fallback to current density
-		forecastLoad, fErr := s.queries.GetForecastForStation(ctx, generated.GetForecastForStationParams{
-			StationID: stationID,
-			DayOfWeek: dayOfWeek,
-			Hour:      hour,
-		})
-		if fErr == nil {
-			load = forecastLoad
-		}
+		// Green discount, campaign discount stacking and coin reward
+		quote := pricing.QuoteSlot(station.Price, green, pricing.StackAt(offers, slotTime), maxCombined)
 
 		status := "RED"
 		if green {
 			status = "GREEN"
 		}
 
-		var campaignApplied *CampaignApplied
-		if activeCampaign != nil {
-			campaignApplied = &CampaignApplied{
-				Title:    activeCampaign.Title,
-				Discount: activeCampaign.Discount,
+		campaignsApplied := make([]CampaignApplied, len(quote.Applied))
+		for i, a := range quote.Applied {
+			campaignsApplied[i] = CampaignApplied{
+				ID:            a.Campaign.ID,
+				Title:         a.Campaign.Title,
+				Discount:      pricing.CampaignDiscount(a.Campaign).Label(),
+				DiscountType:  a.Campaign.DiscountType,
+				DiscountValue: a.Campaign.DiscountValue,
+				CoinReward:    a.Campaign.CoinReward,
+				PriceDiscount: a.Discount,
+				CoinBonus:     a.CoinBonus,
 			}
 		}
+		var campaignApplied *CampaignApplied
+		if len(campaignsApplied) > 0 {
+			campaignApplied = &campaignsApplied[0]
+		}
 
 		slots[hour] = TimeSlot{
 			Hour:            hour,
 			Label:           fmt.Sprintf("%02d:00", hour),
 			StartTime:       slotTime.UTC().Format(time.RFC3339),
 			IsGreen:         green,
-			Coins:           coins,
-			Price:           math.Round(price*100) / 100,
+			Coins:           quote.Coins,
+			Price:           quote.Price,
 			Status:          status,
-			Load:            load,
+			Load:            loads[hour].p50,
+			LoadP10:         loads[hour].p10,
+			LoadP90:         loads[hour].p90,
+			Event:           loads[hour].event,
 			CampaignApplied: campaignApplied,
+
+			CampaignsApplied: campaignsApplied,
 		}
 	}
 
@@ -177,39 +185,46 @@ func (s *Service) GetStation(ctx context.Context, stationID int32) (*StationDeta
 		Price:          station.Price,
 		Density:        station.Density,
 		DensityProfile: station.DensityProfile,
+		Date:           day.Format(dateLayout),
 		Slots:          slots,
 	}
+	if day.After(today) {
+		prev := day.AddDate(0, 0, -1).Format(dateLayout)
+		resp.PrevDate = &prev
+	}
+	if day.Before(lastDay) {
+		next := day.AddDate(0, 0, 1).Format(dateLayout)
+		resp.NextDate = &next
+	}
 
 	if station.Address.Valid {
 		resp.Address = &station.Address.String
 	}
 
-	if activeCampaign != nil {
-		summary := &CampaignSummary{
-			ID:          activeCampaign.ID,
-			Title:       activeCampaign.Title,
-			Description: activeCampaign.Description,
-			Discount:    activeCampaign.Discount,
-			CoinReward:  activeCampaign.CoinReward,
-		}
-		if activeCampaign.StationID.Valid {
-			sid := activeCampaign.StationID.Int32
-			summary.StationID = &sid
-		}
-		resp.ActiveCampaign = summary
+	applied := pricing.StackAt(offers, now)
+	resp.AppliedCampaigns = make([]CampaignSummary, len(applied))
+	for i, o := range applied {
+		resp.AppliedCampaigns[i] = campaignSummary(o.Campaign)
+	}
+	if len(applied) > 0 {
+		resp.ActiveCampaign = &resp.AppliedCampaigns[0]
 	}
 
 	return resp, nil
 }
 
-// CreateStation creates a new station.
-func (s *Service) CreateStation(ctx context.Context, ownerID int32, req CreateStationRequest) (*StationResponse, error) {
+// CreateStation creates a new station owned by the calling operator.
+func (s *Service) CreateStation(ctx context.Context, actor policy.Actor, req CreateStationRequest) (*StationResponse, error) {
+	if err := policy.RequireRole(actor, policy.RoleOperator); err != nil {
+		return nil, err
+	}
+
 	params := generated.CreateStationParams{
 		Name:           req.Name,
 		Lat:            req.Latitude,
 		Lng:            req.Longitude,
 		Price:          req.Price,
-		OwnerID:        pgtype.Int4{Int32: ownerID, Valid: true},
+		OwnerID:        pgtype.Int4{Int32: actor.UserID, Valid: true},
 		DensityProfile: "NORMAL",
 	}
 	if req.Address != "" {
@@ -224,13 +239,15 @@ func (s *Service) CreateStation(ctx context.Context, ownerID int32, req CreateSt
 	return stationToResponse(station), nil
 }
 
-// UpdateStation updates an existing station.
-func (s *Service) UpdateStation(ctx context.Context, stationID int32, req UpdateStationRequest) (*StationResponse, error) {
-	// Verify station exists
-	_, err := s.queries.GetStationByID(ctx, stationID)
+// UpdateStation updates one of the calling operator's stations.
+func (s *Service) UpdateStation(ctx context.Context, actor policy.Actor, stationID int32, req UpdateStationRequest) (*StationResponse, error) {
+	existing, err := s.queries.GetStationByID(ctx, stationID)
 	if err != nil {
 		return nil, apperrors.NewNotFoundError("Station")
 	}
+	if err := policy.RequireStationOwner(actor, existing.OwnerID); err != nil {
+		return nil, err
+	}
 
 	params := generated.UpdateStationParams{
 		ID:    stationID,
@@ -251,6 +268,45 @@ func (s *Service) UpdateStation(ctx context.Context, stationID int32, req Update
 	return stationToResponse(station), nil
 }
 
+// GetStationForecast returns the station's forecast load for each hour of the next `days` days,
+// starting with the current hour, with its P10–P90 band.
+func (s *Service) GetStationForecast(ctx context.Context, stationID int32, days int) (*StationForecastResponse, error) {
+	if days < 1 || days > forecastDays {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("days must be between 1 and %d", forecastDays))
+	}
+	station, err := s.queries.GetStationByID(ctx, stationID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("Station")
+	}
+
+	from := time.Now().Truncate(time.Hour)
+	loads, err := s.hourlyLoads(ctx, station, from, days*24)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	hours := make([]HourForecast, len(loads))
+	for i, l := range loads {
+		hours[i] = HourForecast{
+			StartTime: l.start.UTC().Format(time.RFC3339),
+			Load:      l.p50,
+			LoadP10:   l.p10,
+			LoadP90:   l.p90,
+			Event:     l.event,
+			Weather:   l.weather,
+			Source:    l.source,
+			Version:   l.version,
+		}
+	}
+	return &StationForecastResponse{
+		StationID: station.ID,
+		Model:     station.ForecastModel,
+		From:      from.UTC().Format(time.RFC3339),
+		To:        from.Add(time.Duration(days) * 24 * time.Hour).UTC().Format(time.RFC3339),
+		Hours:     hours,
+	}, nil
+}
+
 // GetForecasts returns density forecasts for all stations at a given day/hour.
 func (s *Service) GetForecasts(ctx context.Context, dayOfWeek, hour int32) (*ForecastResponse, error) {
 	rows, err := s.queries.GetForecastsByDayHour(ctx, generated.GetForecastsByDayHourParams{
@@ -271,6 +327,8 @@ func (s *Service) GetForecasts(ctx context.Context, dayOfWeek, hour int32) (*For
 			Price:          r.Price,
 			DensityProfile: r.DensityProfile,
 			PredictedLoad:  r.PredictedLoad,
+			P10Load:        r.P10Load,
+			P90Load:        r.P90Load,
 			DayOfWeek:      r.DayOfWeek,
 			Hour:           r.Hour,
 		}
@@ -288,15 +346,131 @@ func (s *Service) GetForecasts(ctx context.Context, dayOfWeek, hour int32) (*For
 
 // --- helpers ---
 
-// parseDiscountRate parses a discount string like "%20" into 0.20.
-func parseDiscountRate(discount string) float64 {
-	cleaned := strings.ReplaceAll(discount, "%", "")
-	cleaned = strings.TrimSpace(cleaned)
-	val, err := strconv.ParseFloat(cleaned, 64)
+// dateLayout is the format of the dates the slot view pages through.
+const dateLayout = "2006-01-02"
+
+// forecastDays is how many days ahead, today included, forecasts and slots can be looked up.
+const forecastDays = int(forecast.Horizon / (24 * time.Hour))
+
+// Forecast sources, from most to least specific.
+const (
+	sourceExternal = "external" // a forecast version uploaded by the data science team
+	sourceDated    = "dated"    // the dated forecast, which accounts for holidays and events
+	sourceWeekly   = "weekly"   // the usual week, for hours the dated forecast doesn't cover
+	sourceDensity  = "density"  // the station's current density, for stations never forecast
+)
+
+// hourLoad is the forecast load for one hour.
+type hourLoad struct {
+	start         time.Time
+	p10, p50, p90 int32
+	event         *string
+	weather       *WeatherContribution
+	source        string
+	version       *string // the external forecast version, as model@version
+}
+
+// slotAt returns the start of the slot i hours on the clock after from, built the way forecast uploads build
+// their slots, so the slot times match the ones forecasts are stored under.
+func slotAt(from time.Time, i int) time.Time {
+	return time.Date(from.Year(), from.Month(), from.Day(), from.Hour()+i, 0, 0, 0, pricing.SlotLocation)
+}
+
+// hourlyLoads returns the station's forecast load for each of the given number of hours from `from`.
+// Uploaded forecast versions take precedence; then dated forecasts, which account for holidays and events;
+// hours without one use the usual week, and failing that the station's current density.
+func (s *Service) hourlyLoads(ctx context.Context, station generated.Station, from time.Time, hours int) ([]hourLoad, error) {
+	from = from.In(pricing.SlotLocation)
+	to := slotAt(from, hours)
+	dated, err := s.queries.ListStationForecasts(ctx, generated.ListStationForecastsParams{
+		StationID:   station.ID,
+		WindowStart: pgtype.Timestamptz{Time: from, Valid: true},
+		WindowEnd:   pgtype.Timestamptz{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	datedByHour := make(map[int64]generated.ListStationForecastsRow, len(dated))
+	for _, f := range dated {
+		datedByHour[f.SlotStart.Time.Unix()] = f
+	}
+
+	external, err := s.queries.ListExternalForecasts(ctx, generated.ListExternalForecastsParams{
+		StationID:   station.ID,
+		WindowStart: pgtype.Timestamptz{Time: from, Valid: true},
+		WindowEnd:   pgtype.Timestamptz{Time: to, Valid: true},
+	})
 	if err != nil {
-		return 0
+		return nil, err
+	}
+	externalByHour := make(map[int64]generated.ListExternalForecastsRow, len(external))
+	for _, f := range external {
+		externalByHour[f.SlotStart.Time.Unix()] = f
+	}
+
+	rows, err := s.queries.ListWeeklyForecastForStation(ctx, station.ID)
+	if err != nil {
+		return nil, err
+	}
+	var weekly [7][24]*generated.ListWeeklyForecastForStationRow
+	for i, r := range rows {
+		weekly[r.DayOfWeek][r.Hour] = &rows[i]
+	}
+
+	loads := make([]hourLoad, hours)
+	for i := range loads {
+		t := slotAt(from, i)
+		l := hourLoad{start: t, p10: station.Density, p50: station.Density, p90: station.Density, source: sourceDensity}
+		day, hour := forecast.SlotOf(t)
+		if f, ok := externalByHour[t.Unix()]; ok {
+			l.p10, l.p50, l.p90, l.source = f.P10Load, f.PredictedLoad, f.P90Load, sourceExternal
+			version := f.Model + "@" + f.Version
+			l.version = &version
+		} else if f, ok := datedByHour[t.Unix()]; ok {
+			l.p10, l.p50, l.p90, l.source = f.P10Load, f.PredictedLoad, f.P90Load, sourceDated
+			if f.Event.Valid {
+				event := f.Event.String
+				l.event = &event
+			}
+			if f.Temperature.Valid {
+				l.weather = &WeatherContribution{
+					Temperature:         roundTo1(f.Temperature.Float64),
+					Precipitation:       roundTo1(f.Precipitation.Float64),
+					TemperatureEffect:   roundTo1(f.TemperatureEffect.Float64),
+					PrecipitationEffect: roundTo1(f.PrecipitationEffect.Float64),
+				}
+			}
+		} else if w := weekly[day][hour]; w != nil {
+			l.p10, l.p50, l.p90, l.source = w.P10Load, w.PredictedLoad, w.P90Load, sourceWeekly
+		}
+		loads[i] = l
+	}
+	return loads, nil
+}
+
+// roundTo1 rounds to one decimal place.
+func roundTo1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
+
+func campaignSummary(c generated.Campaign) CampaignSummary {
+	summary := CampaignSummary{
+		ID:            c.ID,
+		Title:         c.Title,
+		Description:   c.Description,
+		Discount:      pricing.CampaignDiscount(c).Label(),
+		DiscountType:  c.DiscountType,
+		DiscountValue: c.DiscountValue,
+		CoinReward:    c.CoinReward,
+		Priority:      c.Priority,
+		Exclusive:     c.Exclusive,
+		Stackable:     c.Stackable,
+	}
+	if c.StationID.Valid {
+		sid := c.StationID.Int32
+		summary.StationID = &sid
 	}
-	return val / 100
+	return summary
 }
 
 // stationToResponse converts a generated.Station to a StationResponse.
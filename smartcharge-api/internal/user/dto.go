@@ -3,9 +3,11 @@ package user
 // --- Request DTOs ---
 
 // UpdateProfileRequest is the request body for PUT /v1/users/:id.
+// VehicleType is used for campaign eligibility; omit it to keep the current value.
 type UpdateProfileRequest struct {
-	Name  string `json:"name" binding:"required"`
-	Email string `json:"email" binding:"required,email"`
+	Name        string  `json:"name" binding:"required"`
+	Email       string  `json:"email" binding:"required,email"`
+	VehicleType *string `json:"vehicleType,omitempty"`
 }
 
 // --- Response DTOs ---
@@ -19,6 +21,7 @@ type ProfileResponse struct {
 	Coins        int32             `json:"coins"`
 	Co2Saved     float64           `json:"co2Saved"`
 	XP           int32             `json:"xp"`
+	VehicleType  *string           `json:"vehicleType"`
 	Badges       []BadgeItem       `json:"badges"`
 	Stations     []StationItem     `json:"stations"`
 	Reservations []ReservationItem `json:"reservations"`
@@ -68,11 +71,12 @@ type LeaderboardEntry struct {
 
 // UserBasicResponse is the response for update operations.
 type UserBasicResponse struct {
-	ID       int32   `json:"id"`
-	Name     string  `json:"name"`
-	Email    string  `json:"email"`
-	Role     string  `json:"role"`
-	Coins    int32   `json:"coins"`
-	Co2Saved float64 `json:"co2Saved"`
-	XP       int32   `json:"xp"`
+	ID          int32   `json:"id"`
+	Name        string  `json:"name"`
+	Email       string  `json:"email"`
+	Role        string  `json:"role"`
+	Coins       int32   `json:"coins"`
+	Co2Saved    float64 `json:"co2Saved"`
+	XP          int32   `json:"xp"`
+	VehicleType *string `json:"vehicleType"`
 }
@@ -6,7 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	apperrors "smartcharge-api/internal/errors"
-	"smartcharge-api/internal/middleware"
+	"smartcharge-api/internal/policy"
 	"smartcharge-api/internal/response"
 )
 
@@ -52,10 +52,14 @@ func (h *Handler) UpdateProfile(c *gin.Context) {
 		return
 	}
 
-	// Verify the authenticated user is updating their own profile
-	userID, ok := middleware.GetUserID(c)
-	if !ok || userID != id {
-		response.Err(c, 403, "AUTH_FORBIDDEN", "You can only update your own profile")
+	// Users can only update their own profile
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+	if err := policy.RequireOwner(actor, id); err != nil {
+		handleError(c, err)
 		return
 	}
 
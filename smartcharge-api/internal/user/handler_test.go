@@ -0,0 +1,46 @@
+package user
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"smartcharge-api/db/generated"
+	"smartcharge-api/internal/apitest"
+)
+
+func TestMutatingRoutesAuthorization(t *testing.T) {
+	tests := []struct {
+		name  string
+		route apitest.Route
+		write string         // the query that makes the change
+		want  map[string]int // status by apitest.Actors name; the profile is the driver's
+	}{
+		{
+			name:  "update profile",
+			route: apitest.Route{Method: http.MethodPut, Path: "/v1/users/1", Body: UpdateProfileRequest{Name: "Ayşe", Email: "ayse@example.com"}},
+			write: "UpdateUserProfile",
+			want:  map[string]int{"driver": 200, "other driver": 403, "other operator": 403, "admin": 200},
+		},
+	}
+
+	for _, tt := range tests {
+		for name, want := range tt.want {
+			actor := apitest.Actors[name]
+			t.Run(tt.name+"/"+name, func(t *testing.T) {
+				db := apitest.NewDB()
+				h := NewHandler(NewService(generated.New(db)))
+				r := apitest.Router(actor, func(v1 *gin.RouterGroup, auth gin.HandlerFunc) { h.RegisterRoutes(v1, auth) })
+
+				w := apitest.Do(r, tt.route)
+				if w.Code != want {
+					t.Fatalf("status = %d, want %d: %s", w.Code, want, w.Body)
+				}
+				if wrote := db.Called(tt.write); wrote != (want != http.StatusForbidden) {
+					t.Errorf("%s called = %v with status %d", tt.write, wrote, w.Code)
+				}
+			})
+		}
+	}
+}
@@ -2,12 +2,15 @@ package user
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"smartcharge-api/db/generated"
 	apperrors "smartcharge-api/internal/errors"
+	"smartcharge-api/internal/pricing"
 )
 
 // Service handles user business logic.
@@ -90,34 +93,53 @@ func (s *Service) GetProfile(ctx context.Context, userID int32) (*ProfileRespons
 		Coins:        user.Coins,
 		Co2Saved:     user.Co2Saved,
 		XP:           user.Xp,
+		VehicleType:  optionalText(user.VehicleType),
 		Badges:       badgeItems,
 		Stations:     stationItems,
 		Reservations: reservationItems,
 	}, nil
 }
 
-// UpdateProfile updates a user's name and email.
+// UpdateProfile updates a user's name, email and optionally their vehicle type.
 func (s *Service) UpdateProfile(ctx context.Context, userID int32, req UpdateProfileRequest) (*UserBasicResponse, error) {
+	var vehicleType pgtype.Text
+	if req.VehicleType != nil {
+		v, ok := pricing.NormalizeVehicleType(*req.VehicleType)
+		if !ok {
+			return nil, apperrors.NewValidationError(fmt.Sprintf("Unknown vehicle type %q, expected one of %s", *req.VehicleType, strings.Join(pricing.VehicleTypes, ", ")))
+		}
+		vehicleType = pgtype.Text{String: v, Valid: true}
+	}
+
 	user, err := s.queries.UpdateUserProfile(ctx, generated.UpdateUserProfileParams{
-		ID:    userID,
-		Name:  req.Name,
-		Email: req.Email,
+		ID:          userID,
+		Name:        req.Name,
+		Email:       req.Email,
+		VehicleType: vehicleType,
 	})
 	if err != nil {
 		return nil, apperrors.ErrInternal
 	}
 
 	return &UserBasicResponse{
-		ID:       user.ID,
-		Name:     user.Name,
-		Email:    user.Email,
-		Role:     user.Role,
-		Coins:    user.Coins,
-		Co2Saved: user.Co2Saved,
-		XP:       user.Xp,
+		ID:          user.ID,
+		Name:        user.Name,
+		Email:       user.Email,
+		Role:        user.Role,
+		Coins:       user.Coins,
+		Co2Saved:    user.Co2Saved,
+		XP:          user.Xp,
+		VehicleType: optionalText(user.VehicleType),
 	}, nil
 }
 
+func optionalText(t pgtype.Text) *string {
+	if !t.Valid {
+		return nil
+	}
+	return &t.String
+}
+
 // GetLeaderboard returns the top N users by XP.
 func (s *Service) GetLeaderboard(ctx context.Context, limit int32) ([]LeaderboardEntry, error) {
 	if limit <= 0 || limit > 100 {
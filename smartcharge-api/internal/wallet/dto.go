@@ -0,0 +1,36 @@
+package wallet
+
+// --- Response DTOs ---
+
+// TransactionResponse is a single ledger entry in the wallet history.
+type TransactionResponse struct {
+	ID            int32  `json:"id"`
+	Type          string `json:"type"`
+	Coins         int32  `json:"coins"`
+	XP            int32  `json:"xp"`
+	ReservationID *int32 `json:"reservationId"`
+	CampaignID    *int32 `json:"campaignId"`
+	Description   string `json:"description"`
+	CreatedAt     string `json:"createdAt"`
+}
+
+// LiabilityResponse is the outstanding (unspent, unexpired) coins granted through an operator's campaigns.
+type LiabilityResponse struct {
+	OperatorID    int32               `json:"operatorId"`
+	CoinValue     float64             `json:"coinValue"`
+	TotalCoins    int32               `json:"totalCoins"`
+	TotalValue    float64             `json:"totalValue"`
+	ExpiringCoins int32               `json:"expiringCoins"`
+	Campaigns     []CampaignLiability `json:"campaigns"`
+	GeneratedAt   string              `json:"generatedAt"`
+}
+
+// CampaignLiability is the outstanding coin balance attributable to one campaign.
+type CampaignLiability struct {
+	CampaignID       int32   `json:"campaignId"`
+	Title            string  `json:"title"`
+	Status           string  `json:"status"`
+	OutstandingCoins int32   `json:"outstandingCoins"`
+	Value            float64 `json:"value"`
+	ExpiringCoins    int32   `json:"expiringCoins"`
+}
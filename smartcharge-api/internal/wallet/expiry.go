@@ -0,0 +1,120 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"smartcharge-api/db/generated"
+	"smartcharge-api/internal/notification"
+)
+
+// ExpiryJob expires coins older than the policy allows and warns users about coins expiring soon.
+// It implements scheduler.Job.
+type ExpiryJob struct {
+	queries *generated.Queries
+	pool    *pgxpool.Pool
+	policy  ExpiryPolicy
+}
+
+// NewExpiryJob creates the coin expiry job.
+func NewExpiryJob(queries *generated.Queries, pool *pgxpool.Pool, policy ExpiryPolicy) *ExpiryJob {
+	return &ExpiryJob{queries: queries, pool: pool, policy: policy}
+}
+
+// Name implements scheduler.Job.
+func (j *ExpiryJob) Name() string {
+	return "coin-expiry"
+}
+
+// Run implements scheduler.Job. Each user is processed in its own transaction;
+// a failure for one user is logged and doesn't stop the others.
+func (j *ExpiryJob) Run(ctx context.Context) error {
+	if !j.policy.Enabled() {
+		return nil
+	}
+
+	userIDs, err := j.queries.ListUserIDsWithCoins(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, id := range userIDs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := j.processUser(ctx, id, now); err != nil {
+			log.Printf("coin-expiry: user %d: %v", id, err)
+		}
+	}
+	return nil
+}
+
+func (j *ExpiryJob) processUser(ctx context.Context, userID int32, now time.Time) error {
+	tx, err := j.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := j.queries.WithTx(tx)
+
+	// Lock first so the ledger can't change between reading it and posting the expiry
+	user, err := qtx.GetUserForUpdate(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	entries, err := qtx.ListUserLedger(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	expired, expiring, firstExpiry := j.policy.expiryStatus(OpenLots(entries), now)
+	if expired > user.Coins {
+		expired = user.Coins
+	}
+
+	if expired > 0 {
+		entry, _, err := Post(ctx, qtx, Entry{
+			UserID:      userID,
+			Type:        TypeExpiry,
+			Coins:       -expired,
+			Description: fmt.Sprintf("Coins older than %d months expired", j.policy.Months),
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := notification.Send(ctx, qtx, notification.Message{
+			UserID:    userID,
+			Type:      notification.TypeCoinExpired,
+			Title:     "SmartCoin'lerinizin süresi doldu",
+			Body:      fmt.Sprintf("%d SmartCoin'in kullanım süresi doldu.", expired),
+			DedupeKey: fmt.Sprintf("coin-expired:%d", entry.ID),
+		}); err != nil {
+			return err
+		}
+	}
+
+	if expiring > 0 {
+		// One warning per user and expiry date
+		if err := notification.Send(ctx, qtx, notification.Message{
+			UserID: userID,
+			Type:   notification.TypeCoinExpiryWarning,
+			Title:  "SmartCoin'leriniz yakında sona eriyor",
+			Body: fmt.Sprintf("%d SmartCoin %s tarihinden itibaren sona ermeye başlayacak. Süresi dolmadan kullanın!",
+				expiring, firstExpiry.Format("02.01.2006")),
+			DedupeKey: fmt.Sprintf("coin-expiry-warning:%d:%s", userID, firstExpiry.Format("2006-01-02")),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
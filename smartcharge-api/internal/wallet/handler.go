@@ -0,0 +1,106 @@
+package wallet
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	apperrors "smartcharge-api/internal/errors"
+	"smartcharge-api/internal/middleware"
+	"smartcharge-api/internal/policy"
+	"smartcharge-api/internal/response"
+)
+
+// Handler handles HTTP requests for the driver wallet.
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new wallet handler.
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers wallet routes on the given router group.
+func (h *Handler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	wallet := rg.Group("/users/:id/wallet", authMiddleware)
+
+	wallet.GET("/transactions", h.ListTransactions)
+
+	company := rg.Group("/company", authMiddleware)
+	company.GET("/coin-liability", h.Liability)
+}
+
+// ListTransactions handles GET /v1/users/:id/wallet/transactions?page=1&perPage=20.
+func (h *Handler) ListTransactions(c *gin.Context) {
+	id, err := parseID(c)
+	if err != nil {
+		return
+	}
+
+	// Users can only see their own ledger
+	userID, ok := middleware.GetUserID(c)
+	if !ok || userID != id {
+		response.Err(c, 403, "AUTH_FORBIDDEN", "You can only view your own wallet")
+		return
+	}
+
+	page := queryInt(c, "page", 1)
+	perPage := queryInt(c, "perPage", 20)
+	if perPage > 100 {
+		perPage = 100
+	}
+
+	items, total, err := h.service.ListTransactions(c.Request.Context(), id, page, perPage)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.Paginated(c, items, response.Meta{Page: page, PerPage: perPage, TotalCount: total})
+}
+
+// Liability handles GET /v1/company/coin-liability.
+func (h *Handler) Liability(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	result, err := h.service.Liability(c.Request.Context(), actor)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// --- helpers ---
+
+func parseID(c *gin.Context) (int32, error) {
+	raw := c.Param("id")
+	val, err := strconv.Atoi(raw)
+	if err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "Invalid user ID")
+		return 0, err
+	}
+	return int32(val), nil
+}
+
+// queryInt reads a positive integer query parameter, falling back to def.
+func queryInt(c *gin.Context, key string, def int) int {
+	if raw := c.Query(key); raw != "" {
+		if val, err := strconv.Atoi(raw); err == nil && val > 0 {
+			return val
+		}
+	}
+	return def
+}
+
+func handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*apperrors.AppError); ok {
+		response.Err(c, appErr.StatusCode, appErr.Code, appErr.Message)
+		return
+	}
+	response.Err(c, 500, "INTERNAL_ERROR", "An unexpected error occurred")
+}
@@ -0,0 +1,40 @@
+package wallet
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"smartcharge-api/db/generated"
+	"smartcharge-api/internal/apitest"
+)
+
+func TestOperatorRoutesAuthorization(t *testing.T) {
+	tests := []struct {
+		name  string
+		route apitest.Route
+		want  map[string]int // status by apitest.Actors name
+	}{
+		{
+			name:  "coin liability",
+			route: apitest.Route{Method: http.MethodGet, Path: "/v1/company/coin-liability"},
+			want:  map[string]int{"driver": 403, "owning operator": 200, "other operator": 200, "admin": 200},
+		},
+	}
+
+	for _, tt := range tests {
+		for name, want := range tt.want {
+			actor := apitest.Actors[name]
+			t.Run(tt.name+"/"+name, func(t *testing.T) {
+				db := apitest.NewDB()
+				h := NewHandler(NewService(generated.New(db), ExpiryPolicy{Months: 12, WarningDays: 30}))
+				r := apitest.Router(actor, func(v1 *gin.RouterGroup, auth gin.HandlerFunc) { h.RegisterRoutes(v1, auth) })
+
+				if w := apitest.Do(r, tt.route); w.Code != want {
+					t.Fatalf("status = %d, want %d: %s", w.Code, want, w.Body)
+				}
+			})
+		}
+	}
+}
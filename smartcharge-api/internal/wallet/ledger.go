@@ -0,0 +1,75 @@
+package wallet
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"smartcharge-api/db/generated"
+	apperrors "smartcharge-api/internal/errors"
+)
+
+// Ledger entry types. Positive coin amounts credit the user, negative amounts debit them.
+const (
+	TypeEarn       = "EARN"
+	TypeRedeem     = "REDEEM"
+	TypePenalty    = "PENALTY"
+	TypeAdjustment = "ADJUSTMENT"
+	TypeExpiry     = "EXPIRY"
+	TypeRefund     = "REFUND"
+)
+
+// Entry is a single change to a user's coin/XP balance.
+type Entry struct {
+	UserID        int32
+	Type          string
+	Coins         int32
+	XP            int32
+	ReservationID *int32
+	CampaignID    *int32
+	Description   string
+}
+
+// Post appends an entry to the ledger and re-derives the user's coins and XP from it.
+// q must be bound to the caller's transaction so the entry and the balance change commit together.
+// Debits that would take the balance below zero fail with apperrors.ErrInsufficientCoins.
+func Post(ctx context.Context, q *generated.Queries, e Entry) (generated.CoinTransaction, generated.User, error) {
+	// Lock the user row so concurrent postings for the same user are serialized
+	current, err := q.GetUserForUpdate(ctx, e.UserID)
+	if err != nil {
+		return generated.CoinTransaction{}, generated.User{}, err
+	}
+
+	if e.Coins < 0 && current.Coins+e.Coins < 0 {
+		return generated.CoinTransaction{}, generated.User{}, apperrors.ErrInsufficientCoins
+	}
+
+	tx, err := q.CreateCoinTransaction(ctx, generated.CreateCoinTransactionParams{
+		UserID:        e.UserID,
+		Type:          e.Type,
+		Coins:         e.Coins,
+		Xp:            e.XP,
+		ReservationID: optionalInt4(e.ReservationID),
+		CampaignID:    optionalInt4(e.CampaignID),
+		Description:   e.Description,
+	})
+	if err != nil {
+		return generated.CoinTransaction{}, generated.User{}, err
+	}
+
+	user, err := q.SyncUserBalance(ctx, e.UserID)
+	if err != nil {
+		return generated.CoinTransaction{}, generated.User{}, err
+	}
+
+	return tx, user, nil
+}
+
+// --- helpers ---
+
+func optionalInt4(v *int32) pgtype.Int4 {
+	if v == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: *v, Valid: true}
+}
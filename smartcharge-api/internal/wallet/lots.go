@@ -0,0 +1,78 @@
+package wallet
+
+import (
+	"time"
+
+	"smartcharge-api/db/generated"
+)
+
+// Lot is the unspent remainder of one coin grant (any positive ledger entry).
+type Lot struct {
+	GrantedAt  time.Time
+	CampaignID *int32
+	Coins      int32
+}
+
+// OpenLots replays a user's ledger, oldest entry first, and returns the grants that are
+// still unspent. Debits (redemptions, penalties, expiries) consume the oldest grants first.
+func OpenLots(entries []generated.CoinTransaction) []Lot {
+	var lots []Lot
+	for _, e := range entries {
+		switch {
+		case e.Coins > 0:
+			lot := Lot{GrantedAt: e.CreatedAt.Time, Coins: e.Coins}
+			if e.CampaignID.Valid {
+				id := e.CampaignID.Int32
+				lot.CampaignID = &id
+			}
+			lots = append(lots, lot)
+		case e.Coins < 0:
+			debit := -e.Coins
+			for debit > 0 && len(lots) > 0 {
+				if lots[0].Coins > debit {
+					lots[0].Coins -= debit
+					debit = 0
+				} else {
+					debit -= lots[0].Coins
+					lots = lots[1:]
+				}
+			}
+		}
+	}
+	return lots
+}
+
+// ExpiryPolicy decides when granted coins expire and how early users are warned.
+type ExpiryPolicy struct {
+	Months      int
+	WarningDays int
+}
+
+// ExpiresAt returns when coins granted at grantedAt expire.
+func (p ExpiryPolicy) ExpiresAt(grantedAt time.Time) time.Time {
+	return grantedAt.AddDate(0, p.Months, 0)
+}
+
+// Enabled reports whether coins expire at all (COIN_EXPIRY_MONTHS=0 disables expiry).
+func (p ExpiryPolicy) Enabled() bool {
+	return p.Months > 0
+}
+
+// expiryStatus splits open lots into coins already expired at now and coins expiring within the warning window.
+// firstExpiry is the earliest upcoming expiry among the latter.
+func (p ExpiryPolicy) expiryStatus(lots []Lot, now time.Time) (expired, expiring int32, firstExpiry time.Time) {
+	warnUntil := now.AddDate(0, 0, p.WarningDays)
+	for _, l := range lots {
+		exp := p.ExpiresAt(l.GrantedAt)
+		switch {
+		case !now.Before(exp):
+			expired += l.Coins
+		case exp.Before(warnUntil):
+			expiring += l.Coins
+			if firstExpiry.IsZero() || exp.Before(firstExpiry) {
+				firstExpiry = exp
+			}
+		}
+	}
+	return expired, expiring, firstExpiry
+}
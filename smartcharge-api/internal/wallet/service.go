@@ -0,0 +1,131 @@
+package wallet
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"smartcharge-api/db/generated"
+	apperrors "smartcharge-api/internal/errors"
+	"smartcharge-api/internal/policy"
+	"smartcharge-api/internal/pricing"
+)
+
+// Service handles wallet (coin ledger) read operations.
+type Service struct {
+	queries *generated.Queries
+	expiry  ExpiryPolicy
+}
+
+// NewService creates a new wallet service.
+func NewService(queries *generated.Queries, expiry ExpiryPolicy) *Service {
+	return &Service{queries: queries, expiry: expiry}
+}
+
+// ListTransactions returns a page of the user's ledger entries (newest first) and the total count.
+func (s *Service) ListTransactions(ctx context.Context, userID int32, page, perPage int) ([]TransactionResponse, int, error) {
+	total, err := s.queries.CountCoinTransactionsByUser(ctx, userID)
+	if err != nil {
+		return nil, 0, apperrors.ErrInternal
+	}
+
+	rows, err := s.queries.ListCoinTransactionsByUser(ctx, generated.ListCoinTransactionsByUserParams{
+		UserID: userID,
+		Limit:  int32(perPage),
+		Offset: int32((page - 1) * perPage),
+	})
+	if err != nil {
+		return nil, 0, apperrors.ErrInternal
+	}
+
+	items := make([]TransactionResponse, len(rows))
+	for i, r := range rows {
+		items[i] = transactionToResponse(r)
+	}
+	return items, int(total), nil
+}
+
+// Liability reports the coins still outstanding from the operator's campaign bonuses.
+// Grants are matched against spending FIFO per user, the same way the expiry job does.
+func (s *Service) Liability(ctx context.Context, actor policy.Actor) (*LiabilityResponse, error) {
+	if err := policy.RequireRole(actor, policy.RoleOperator); err != nil {
+		return nil, err
+	}
+	operatorID := actor.UserID
+
+	campaigns, err := s.queries.ListCampaignsByOwner(ctx, operatorID)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	coinPolicy, err := pricing.LoadCoinPolicy(ctx, s.queries, pgtype.Int4{Int32: operatorID, Valid: true})
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	// Replaying the ledgers of the users holding the operator's grants happens in the database
+	now := time.Now()
+	rows, err := s.queries.ListCampaignCoinLiability(ctx, generated.ListCampaignCoinLiabilityParams{
+		OperatorID:   operatorID,
+		ExpiryMonths: int32(s.expiry.Months),
+		AsOf:         pgtype.Timestamptz{Time: now, Valid: true},
+		WarnUntil:    pgtype.Timestamptz{Time: now.AddDate(0, 0, s.expiry.WarningDays), Valid: true},
+	})
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+	outstanding := map[int32]int32{}
+	expiring := map[int32]int32{}
+	for _, r := range rows {
+		outstanding[r.CampaignID.Int32] = r.OutstandingCoins
+		expiring[r.CampaignID.Int32] = r.ExpiringCoins
+	}
+
+	resp := &LiabilityResponse{
+		OperatorID:  operatorID,
+		CoinValue:   coinPolicy.CoinValue,
+		Campaigns:   make([]CampaignLiability, 0, len(campaigns)),
+		GeneratedAt: now.UTC().Format(time.RFC3339),
+	}
+	for _, c := range campaigns {
+		coins := outstanding[c.ID]
+		resp.Campaigns = append(resp.Campaigns, CampaignLiability{
+			CampaignID:       c.ID,
+			Title:            c.Title,
+			Status:           c.Status,
+			OutstandingCoins: coins,
+			Value:            coinPolicy.Discount(coins),
+			ExpiringCoins:    expiring[c.ID],
+		})
+		resp.TotalCoins += coins
+		resp.ExpiringCoins += expiring[c.ID]
+	}
+	resp.TotalValue = coinPolicy.Discount(resp.TotalCoins)
+
+	return resp, nil
+}
+
+// --- helpers ---
+
+func transactionToResponse(t generated.CoinTransaction) TransactionResponse {
+	resp := TransactionResponse{
+		ID:          t.ID,
+		Type:        t.Type,
+		Coins:       t.Coins,
+		XP:          t.Xp,
+		Description: t.Description,
+	}
+	if t.ReservationID.Valid {
+		id := t.ReservationID.Int32
+		resp.ReservationID = &id
+	}
+	if t.CampaignID.Valid {
+		id := t.CampaignID.Int32
+		resp.CampaignID = &id
+	}
+	if t.CreatedAt.Valid {
+		resp.CreatedAt = t.CreatedAt.Time.UTC().Format(time.RFC3339)
+	}
+	return resp
+}
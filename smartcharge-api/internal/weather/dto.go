@@ -0,0 +1,22 @@
+package weather
+
+// --- Response DTOs ---
+
+// HourResponse is the weather during one hour.
+type HourResponse struct {
+	StationID     *int32  `json:"stationId"` // nil for the area-wide weather
+	Time          string  `json:"time"`
+	Temperature   float64 `json:"temperature"`   // °C
+	Precipitation float64 `json:"precipitation"` // mm
+	Forecast      bool    `json:"forecast"`      // false once observed
+	Source        string  `json:"source"`
+}
+
+// ImportResponse reports what a weather import stored.
+type ImportResponse struct {
+	Hours         int    `json:"hours"`         // hours in the file
+	ForecastHours int    `json:"forecastHours"` // of which in the future
+	Imported      int64  `json:"imported"`      // added or updated; forecasts never replace observations
+	From          string `json:"from"`
+	To            string `json:"to"`
+}
@@ -0,0 +1,134 @@
+package weather
+
+import (
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	apperrors "smartcharge-api/internal/errors"
+	"smartcharge-api/internal/forecast"
+	"smartcharge-api/internal/policy"
+	"smartcharge-api/internal/pricing"
+	"smartcharge-api/internal/response"
+)
+
+// Handler handles HTTP requests for weather data.
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new weather handler.
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers weather routes on the given router group.
+func (h *Handler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	weather := rg.Group("/weather", authMiddleware)
+	weather.GET("", h.List)
+	weather.POST("/import", h.Import)
+}
+
+// List handles GET /v1/weather.
+// ?stationId= uses the station's own weather where it has any; ?from= and ?to= (YYYY-MM-DD) default
+// to the forecast horizon from today.
+func (h *Handler) List(c *gin.Context) {
+	stationID, ok := parseStationID(c, c.Query("stationId"))
+	if !ok {
+		return
+	}
+
+	now := time.Now().In(pricing.SlotLocation)
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, pricing.SlotLocation)
+	if raw := c.Query("from"); raw != "" {
+		t, err := time.ParseInLocation("2006-01-02", raw, pricing.SlotLocation)
+		if err != nil {
+			response.Err(c, 400, "VALIDATION_ERROR", "from must be a date (YYYY-MM-DD)")
+			return
+		}
+		from = t
+	}
+	to := from.Add(forecast.Horizon)
+	if raw := c.Query("to"); raw != "" {
+		t, err := time.ParseInLocation("2006-01-02", raw, pricing.SlotLocation)
+		if err != nil || !t.After(from) {
+			response.Err(c, 400, "VALIDATION_ERROR", "to must be a date (YYYY-MM-DD) after from")
+			return
+		}
+		to = t
+	}
+
+	result, err := h.service.List(c.Request.Context(), stationID, from, to)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// Import handles POST /v1/weather/import.
+// Expects a multipart form with a CSV file under "weather" and optionally the "stationId" it is for;
+// without one it is the area-wide weather.
+func (h *Handler) Import(c *gin.Context) {
+	actor, ok := policy.FromContext(c)
+	if !ok {
+		response.Err(c, 401, "AUTH_UNAUTHORIZED", "Authentication required")
+		return
+	}
+	stationID, ok := parseStationID(c, c.PostForm("stationId"))
+	if !ok {
+		return
+	}
+
+	header, err := c.FormFile("weather")
+	if err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "weather file is required")
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "Could not read weather file")
+		return
+	}
+	defer file.Close()
+
+	// Read one byte past the limit so oversized files are rejected by the service
+	data, err := io.ReadAll(io.LimitReader(file, MaxImportSize+1))
+	if err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "Could not read weather file")
+		return
+	}
+
+	result, err := h.service.Import(c.Request.Context(), actor, data, stationID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	response.OK(c, result)
+}
+
+// --- helpers ---
+
+// parseStationID parses an optional station ID, writing the error response if it is invalid.
+func parseStationID(c *gin.Context, raw string) (*int32, bool) {
+	if raw == "" {
+		return nil, true
+	}
+	val, err := strconv.Atoi(raw)
+	if err != nil {
+		response.Err(c, 400, "VALIDATION_ERROR", "Invalid station ID")
+		return nil, false
+	}
+	id := int32(val)
+	return &id, true
+}
+
+func handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*apperrors.AppError); ok {
+		response.Err(c, appErr.StatusCode, appErr.Code, appErr.Message)
+		return
+	}
+	response.Err(c, 500, "INTERNAL_ERROR", "An unexpected error occurred")
+}
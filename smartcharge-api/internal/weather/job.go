@@ -0,0 +1,76 @@
+package weather
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"time"
+
+	"smartcharge-api/db/generated"
+)
+
+// FetchJob periodically fetches the area-wide weather, observed and forecast, from an HTTP weather service.
+// The response is either Open-Meteo style JSON (hourly=temperature_2m,precipitation) or, for anything
+// other than JSON, a CSV file in the upload format. It implements scheduler.Job.
+type FetchJob struct {
+	queries *generated.Queries
+	url     string
+	client  *http.Client
+}
+
+// NewFetchJob creates the weather fetch job for the given URL.
+func NewFetchJob(queries *generated.Queries, url string) *FetchJob {
+	return &FetchJob{queries: queries, url: url, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// Name implements scheduler.Job.
+func (j *FetchJob) Name() string {
+	return "weather-fetch"
+}
+
+// Run implements scheduler.Job. Forecasts pick the weather up on their next retraining.
+func (j *FetchJob) Run(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.url, nil)
+	if err != nil {
+		return err
+	}
+	res, err := j.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("weather service returned %s", res.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(res.Body, MaxImportSize+1))
+	if err != nil {
+		return err
+	}
+	if len(data) > MaxImportSize {
+		return fmt.Errorf("weather response is larger than %d KB", MaxImportSize/1024)
+	}
+
+	var readings []reading
+	if mediaType, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type")); mediaType == "application/json" {
+		readings, err = parseOpenMeteo(data)
+	} else {
+		readings, err = parseCSV(data)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid weather response: %w", err)
+	}
+	if len(readings) == 0 {
+		return nil
+	}
+
+	result, err := store(ctx, j.queries, nil, readings, SourceHTTP, time.Now())
+	if err != nil {
+		return err
+	}
+	log.Printf("weather-fetch: stored %d of %d hours (%d forecast)", result.Imported, result.Hours, result.ForecastHours)
+	return nil
+}
@@ -0,0 +1,177 @@
+package weather
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"smartcharge-api/internal/pricing"
+)
+
+// reading is the weather during one hour.
+type reading struct {
+	At            time.Time // start of the hour
+	Temperature   float64   // °C
+	Precipitation float64   // mm
+}
+
+// Plausible ranges; anything outside is a unit mix-up or a corrupt file.
+const (
+	minTemperature   = -60
+	maxTemperature   = 60
+	maxPrecipitation = 500
+)
+
+// csvTimeLayouts are the accepted timestamp formats. Those without an offset are in pricing.SlotLocation.
+var csvTimeLayouts = []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02 15:04"}
+
+// parseCSV reads hourly weather from a CSV file whose header names a time, a temperature (°C) and optionally
+// a precipitation (mm) column, in any order; other columns are ignored. Times are truncated to the hour.
+func parseCSV(data []byte) ([]reading, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.TrimLeadingSpace = true
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, errors.New("missing header row")
+	}
+	timeCol, tempCol, precipCol := -1, -1, -1
+	for i, name := range header {
+		switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))) {
+		case "time", "timestamp":
+			timeCol = i
+		case "temperature", "temperature_c":
+			tempCol = i
+		case "precipitation", "precipitation_mm":
+			precipCol = i
+		}
+	}
+	if timeCol < 0 || tempCol < 0 {
+		return nil, errors.New("header must name a time and a temperature column")
+	}
+
+	var readings []reading
+	for line := 2; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		if len(record) <= max(timeCol, tempCol, precipCol) {
+			return nil, fmt.Errorf("line %d: missing columns", line)
+		}
+
+		at, err := parseTime(record[timeCol])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid time %q", line, record[timeCol])
+		}
+		rd := reading{At: at}
+		if rd.Temperature, err = strconv.ParseFloat(strings.TrimSpace(record[tempCol]), 64); err != nil {
+			return nil, fmt.Errorf("line %d: invalid temperature %q", line, record[tempCol])
+		}
+		if precipCol >= 0 {
+			if v := strings.TrimSpace(record[precipCol]); v != "" {
+				if rd.Precipitation, err = strconv.ParseFloat(v, 64); err != nil {
+					return nil, fmt.Errorf("line %d: invalid precipitation %q", line, v)
+				}
+			}
+		}
+		if err := rd.validate(); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		readings = append(readings, rd)
+	}
+	return dedupe(readings), nil
+}
+
+// openMeteoResponse is the hourly part of an Open-Meteo forecast API response
+// (hourly=temperature_2m,precipitation), the format fetched weather is expected in.
+type openMeteoResponse struct {
+	UTCOffsetSeconds int `json:"utc_offset_seconds"`
+	Hourly           struct {
+		Time          []string   `json:"time"`
+		Temperature   []*float64 `json:"temperature_2m"`
+		Precipitation []*float64 `json:"precipitation"`
+	} `json:"hourly"`
+}
+
+// parseOpenMeteo reads hourly weather from an Open-Meteo style JSON response. Hours without a temperature
+// are skipped; a missing precipitation counts as none.
+func parseOpenMeteo(data []byte) ([]reading, error) {
+	var resp openMeteoResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+	h := resp.Hourly
+	if len(h.Temperature) != len(h.Time) || (h.Precipitation != nil && len(h.Precipitation) != len(h.Time)) {
+		return nil, errors.New("hourly series have different lengths")
+	}
+
+	zone := time.FixedZone("", resp.UTCOffsetSeconds)
+	readings := make([]reading, 0, len(h.Time))
+	for i, raw := range h.Time {
+		if h.Temperature[i] == nil {
+			continue
+		}
+		at, err := time.ParseInLocation("2006-01-02T15:04", raw, zone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time %q", raw)
+		}
+		rd := reading{At: at.Truncate(time.Hour), Temperature: *h.Temperature[i]}
+		if h.Precipitation != nil && h.Precipitation[i] != nil {
+			rd.Precipitation = *h.Precipitation[i]
+		}
+		if err := rd.validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", raw, err)
+		}
+		readings = append(readings, rd)
+	}
+	return dedupe(readings), nil
+}
+
+func parseTime(raw string) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	for _, layout := range csvTimeLayouts {
+		if t, err := time.ParseInLocation(layout, raw, pricing.SlotLocation); err == nil {
+			return t.Truncate(time.Hour), nil
+		}
+	}
+	return time.Time{}, errors.New("unrecognised time")
+}
+
+func (r reading) validate() error {
+	if r.Temperature < minTemperature || r.Temperature > maxTemperature {
+		return fmt.Errorf("temperature %.1f is outside %d to %d °C", r.Temperature, minTemperature, maxTemperature)
+	}
+	if r.Precipitation < 0 || r.Precipitation > maxPrecipitation {
+		return fmt.Errorf("precipitation %.1f is outside 0 to %d mm", r.Precipitation, maxPrecipitation)
+	}
+	return nil
+}
+
+// dedupe keeps the last reading of each hour, in time order; one import can't write an hour twice.
+func dedupe(readings []reading) []reading {
+	byHour := make(map[int64]reading, len(readings))
+	for _, r := range readings {
+		byHour[r.At.Unix()] = r
+	}
+	out := make([]reading, 0, len(byHour))
+	for _, r := range byHour {
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].At.Before(out[j].At) })
+	return out
+}
@@ -0,0 +1,180 @@
+// Package weather imports the hourly weather that load forecasts use as regressors,
+// from uploaded CSV files or periodically from an HTTP weather service.
+package weather
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"smartcharge-api/db/generated"
+	apperrors "smartcharge-api/internal/errors"
+	"smartcharge-api/internal/forecast"
+	"smartcharge-api/internal/policy"
+)
+
+// MaxImportSize caps an uploaded weather file or fetched response.
+const MaxImportSize = 2 << 20
+
+// maxImportHours caps the hours in one import: a little over a year.
+const maxImportHours = 400 * 24
+
+// Weather sources.
+const (
+	SourceCSV  = "csv"  // uploaded file
+	SourceHTTP = "http" // fetched by FetchJob
+)
+
+// Service handles weather imports.
+type Service struct {
+	queries *generated.Queries
+	pool    forecast.TxBeginner
+}
+
+// NewService creates a new weather service.
+func NewService(queries *generated.Queries, pool *pgxpool.Pool) *Service {
+	return &Service{queries: queries, pool: pool}
+}
+
+// List returns the weather during [from, to): the station's own where it has any, the area-wide otherwise.
+// Without a station, only the area-wide weather.
+func (s *Service) List(ctx context.Context, stationID *int32, from, to time.Time) ([]HourResponse, error) {
+	params := generated.ListWeatherParams{
+		WindowStart: pgtype.Timestamptz{Time: from, Valid: true},
+		WindowEnd:   pgtype.Timestamptz{Time: to, Valid: true},
+	}
+	if stationID != nil {
+		params.StationID = pgtype.Int4{Int32: *stationID, Valid: true}
+	}
+
+	rows, err := s.queries.ListWeather(ctx, params)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	// Rows are in time order with the area-wide row of an hour first; the station's own replaces it
+	result := make([]HourResponse, 0, len(rows))
+	for _, r := range rows {
+		h := toHourResponse(r)
+		if n := len(result); n > 0 && result[n-1].Time == h.Time {
+			result[n-1] = h
+			continue
+		}
+		result = append(result, h)
+	}
+	return result, nil
+}
+
+// Import stores the hourly weather of a CSV file (see parseCSV). Without a station it is the area-wide weather,
+// which only admins import; a station's own weather is imported by its owner and retrains its forecast
+// straight away. The area-wide weather waits for the scheduled retraining, as it affects every station.
+// Hours still to come are stored as weather forecasts, to be replaced once observed.
+func (s *Service) Import(ctx context.Context, actor policy.Actor, data []byte, stationID *int32) (*ImportResponse, error) {
+	if len(data) > MaxImportSize {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("Weather file must be at most %d KB", MaxImportSize/1024))
+	}
+
+	var station *generated.Station
+	if stationID == nil {
+		if err := policy.RequireRole(actor, policy.RoleAdmin); err != nil {
+			return nil, err
+		}
+	} else {
+		st, err := s.queries.GetStationByID(ctx, *stationID)
+		if err != nil {
+			return nil, apperrors.NewNotFoundError("Station")
+		}
+		if err := policy.RequireStationOwner(actor, st.OwnerID); err != nil {
+			return nil, err
+		}
+		station = &st
+	}
+
+	readings, err := parseCSV(data)
+	if err != nil {
+		return nil, apperrors.NewValidationError("Invalid weather file: " + err.Error())
+	}
+	if len(readings) == 0 {
+		return nil, apperrors.NewValidationError("The weather file has no readings")
+	}
+	if len(readings) > maxImportHours {
+		return nil, apperrors.NewValidationError(fmt.Sprintf("Import at most %d hours at a time", maxImportHours))
+	}
+
+	now := time.Now()
+	var storeFor *int32
+	if station != nil {
+		storeFor = &station.ID
+	}
+	resp, err := store(ctx, s.queries, storeFor, readings, SourceCSV, now)
+	if err != nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	if station != nil {
+		if _, err := forecast.Train(ctx, s.pool, s.queries, forecast.Lookup(station.ForecastModel), station.ID, now); err != nil {
+			log.Printf("forecast retraining failed for station %d: %v", station.ID, err)
+		}
+	}
+	return resp, nil
+}
+
+// --- helpers ---
+
+// store upserts the readings, as the station's own weather or, with a nil station, the area-wide weather.
+// Readings after now are weather forecasts.
+func store(ctx context.Context, q *generated.Queries, stationID *int32, readings []reading, source string, now time.Time) (*ImportResponse, error) {
+	resp := &ImportResponse{
+		Hours: len(readings),
+		From:  readings[0].At.UTC().Format(time.RFC3339),
+		To:    readings[len(readings)-1].At.UTC().Format(time.RFC3339),
+	}
+
+	area := generated.ImportAreaWeatherParams{Source: source}
+	for _, r := range readings {
+		isForecast := r.At.After(now)
+		if isForecast {
+			resp.ForecastHours++
+		}
+		area.ObservedAt = append(area.ObservedAt, pgtype.Timestamptz{Time: r.At, Valid: true})
+		area.Temperatures = append(area.Temperatures, r.Temperature)
+		area.Precipitations = append(area.Precipitations, r.Precipitation)
+		area.IsForecast = append(area.IsForecast, isForecast)
+	}
+
+	var err error
+	if stationID == nil {
+		resp.Imported, err = q.ImportAreaWeather(ctx, area)
+	} else {
+		resp.Imported, err = q.ImportStationWeather(ctx, generated.ImportStationWeatherParams{
+			StationID:      *stationID,
+			ObservedAt:     area.ObservedAt,
+			Temperatures:   area.Temperatures,
+			Precipitations: area.Precipitations,
+			IsForecast:     area.IsForecast,
+			Source:         area.Source,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func toHourResponse(w generated.WeatherHour) HourResponse {
+	resp := HourResponse{
+		Time:          w.ObservedAt.Time.UTC().Format(time.RFC3339),
+		Temperature:   w.Temperature,
+		Precipitation: w.Precipitation,
+		Forecast:      w.IsForecast,
+		Source:        w.Source,
+	}
+	if w.StationID.Valid {
+		resp.StationID = &w.StationID.Int32
+	}
+	return resp
+}